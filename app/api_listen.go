@@ -0,0 +1,22 @@
+//go:build !windows
+
+package app
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenUnix listens on a unix socket at the path that only the current user can
+// connect to. The socket is created with that mode rather than changed to it afterwards,
+// so that nobody else can connect in between
+func listenUnix(path string) (net.Listener, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+
+	mask := syscall.Umask(0077)
+	defer syscall.Umask(mask)
+
+	return net.Listen("unix", path)
+}
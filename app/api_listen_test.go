@@ -0,0 +1,59 @@
+//go:build !windows
+
+package app
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_listenUnix(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		expectErr bool
+	}{
+		{name: "with nothing at the path"},
+		{name: "with a socket left behind", existing: "socket"},
+		{name: "with a file at the path", existing: "file", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "wtf.sock")
+
+			switch tt.existing {
+			case "socket":
+				stale, err := net.Listen("unix", path)
+				assert.NoError(t, err)
+				// Keep the socket file around, the way a crashed run leaves it
+				stale.(*net.UnixListener).SetUnlinkOnClose(false)
+				_ = stale.Close()
+			case "file":
+				assert.NoError(t, os.WriteFile(path, []byte("keep me"), 0644))
+			}
+
+			listener, err := listenUnix(path)
+
+			if tt.expectErr {
+				assert.Error(t, err)
+
+				contents, _ := os.ReadFile(path)
+				assert.Equal(t, "keep me", string(contents))
+				return
+			}
+
+			assert.NoError(t, err)
+			defer func() { _ = listener.Close() }()
+
+			info, err := os.Lstat(path)
+			assert.NoError(t, err)
+			assert.NotZero(t, info.Mode()&os.ModeSocket)
+			assert.Zero(t, info.Mode().Perm()&0077)
+		})
+	}
+}
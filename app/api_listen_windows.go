@@ -0,0 +1,17 @@
+//go:build windows
+
+package app
+
+import (
+	"net"
+)
+
+// listenUnix listens on a unix socket at the path. Windows has no umask, so who can
+// connect to the socket follows the permissions of the directory it's in
+func listenUnix(path string) (net.Listener, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+
+	return net.Listen("unix", path)
+}
@@ -0,0 +1,368 @@
+package app
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/view"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const (
+	apiPrefix = "/api/widgets"
+
+	// apiUITimeout is the longest a request waits for the UI to respond before giving up
+	apiUITimeout = 5 * time.Second
+)
+
+// errUIUnavailable is returned when the UI doesn't run a request's work in time, i.e.:
+// because it hasn't started yet
+var errUIUnavailable = errors.New("the dashboard is not responding")
+
+// apiAction is the JSON representation of a widget's keyboard command
+type apiAction struct {
+	Key  string `json:"key"`
+	Text string `json:"text"`
+}
+
+// apiWidget is the JSON representation of a widget's state
+type apiWidget struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Title       string      `json:"title"`
+	Content     string      `json:"content"`
+	Error       string      `json:"error,omitempty"`
+	LastSuccess *time.Time  `json:"lastSuccess,omitempty"`
+	Refreshing  bool        `json:"refreshing"`
+	Stale       bool        `json:"stale"`
+	Actions     []apiAction `json:"actions"`
+}
+
+// apiServer serves the HTTP API for the dashboard that is currently displayed:
+//
+//	GET  /api/widgets                        lists every widget
+//	GET  /api/widgets/{name}                 describes a single widget
+//	POST /api/widgets/{name}/refresh         refreshes the widget
+//	POST /api/widgets/{name}/actions/{key}   runs the keyboard command bound to the key
+//
+// When a token is configured every request must include it in an
+// `Authorization: Bearer <token>` header. Requests from web pages, which carry an Origin
+// header, are always rejected, and without a token so are requests over TCP that aren't
+// addressed to localhost. Otherwise any page open in a browser could use the API
+type apiServer struct {
+	appMan *WtfAppManager
+	token  string
+
+	// checkHost is set when requests must be addressed to localhost if there's no token.
+	// Requests over a unix socket can't come from a browser, so it isn't checked for them
+	checkHost bool
+
+	// onUI runs the function on the UI goroutine and waits for it to finish, so that the
+	// widgets can't be changed underneath it by a config reload or dashboard switch
+	onUI func(func()) error
+}
+
+func newAPIServer(appMan *WtfAppManager, token string) *apiServer {
+	server := &apiServer{
+		appMan:    appMan,
+		token:     token,
+		checkHost: true,
+	}
+
+	server.onUI = func(fn func()) error {
+		done := make(chan struct{})
+		appMan.TViewApp.QueueUpdateDraw(func() {
+			fn()
+			close(done)
+		})
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(apiUITimeout):
+			return errUIUnavailable
+		}
+	}
+
+	return server
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// ServeAPI starts the HTTP API defined in wtf.api in the background, if it's enabled
+func (appMan *WtfAppManager) ServeAPI(config *config.Config) error {
+	settings := cfg.NewAPISettingsFromYAML(config)
+	if !settings.Enabled {
+		return nil
+	}
+
+	network, address, err := settings.Listen()
+	if err != nil {
+		return err
+	}
+
+	var listener net.Listener
+	if network == "unix" {
+		listener, err = listenUnix(address)
+	} else {
+		listener, err = net.Listen(network, address)
+	}
+	if err != nil {
+		return fmt.Errorf("could not start the API: %w", err)
+	}
+
+	server := newAPIServer(appMan, settings.Token)
+	server.checkHost = network != "unix"
+	go func() { _ = http.Serve(listener, server.handler()) }()
+
+	return nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (server *apiServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(apiPrefix, server.handleWidgets)
+	mux.HandleFunc(apiPrefix+"/", server.handleWidget)
+
+	return server.authorize(mux)
+}
+
+// authorize rejects requests that don't carry the token, if there is one, and requests
+// that a web page could have made
+func (server *apiServer) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Browsers add an Origin to the requests that pages make, including the simple
+		// cross-site POSTs that they send without asking first. Scripts don't
+		if r.Header.Get("Origin") != "" {
+			writeAPIError(w, http.StatusForbidden, "requests from web pages are not allowed")
+			return
+		}
+
+		// Through DNS rebinding, a page can reach the API under its own host name
+		if server.token == "" && server.checkHost && !cfg.IsLoopback(requestHostname(r)) {
+			writeAPIError(w, http.StatusForbidden, "requests must be addressed to localhost")
+			return
+		}
+
+		if server.token != "" {
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(server.token)) != 1 {
+				writeAPIError(w, http.StatusUnauthorized, "missing or invalid token")
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleWidgets lists every widget on the current dashboard
+func (server *apiServer) handleWidgets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	widgets := []apiWidget{}
+	err := server.onUI(func() {
+		wtfApp, err := server.appMan.Current()
+		if err != nil {
+			return
+		}
+
+		for _, widget := range wtfApp.widgets {
+			widgets = append(widgets, newAPIWidget(widget))
+		}
+	})
+	if err != nil {
+		writeAPIError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, widgets)
+}
+
+// handleWidget describes, refreshes or runs a keyboard command on a single widget
+func (server *apiServer) handleWidget(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.EscapedPath(), apiPrefix+"/"), "/", 3)
+	for idx, part := range parts {
+		unescaped, err := url.PathUnescape(part)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		parts[idx] = unescaped
+	}
+
+	name := parts[0]
+	route := strings.Join(parts[1:], "/")
+
+	switch {
+	case route == "" && r.Method == http.MethodGet:
+		server.withWidget(w, name, func(wtfApp *WtfApp, widget wtf.Wtfable) (int, interface{}) {
+			return http.StatusOK, newAPIWidget(widget)
+		})
+	case route == "refresh" && r.Method == http.MethodPost:
+		server.withWidget(w, name, func(wtfApp *WtfApp, widget wtf.Wtfable) (int, interface{}) {
+			go wtfApp.scheduler.Refresh(widget)
+			return http.StatusAccepted, map[string]string{"status": "refreshing"}
+		})
+	case len(parts) == 3 && parts[1] == "actions" && r.Method == http.MethodPost:
+		server.withWidget(w, name, func(wtfApp *WtfApp, widget wtf.Wtfable) (int, interface{}) {
+			action, ok := findKeyboardAction(widget, parts[2])
+			if !ok {
+				return http.StatusNotFound, apiErrorBody(fmt.Sprintf("%s has no keyboard command on %q", name, parts[2]))
+			}
+
+			action.Action()
+			return http.StatusOK, map[string]string{"status": "done"}
+		})
+	case route == "" || route == "refresh" || (len(parts) == 3 && parts[1] == "actions"):
+		writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+	default:
+		writeAPIError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// withWidget runs the function on the UI goroutine with the named widget from the
+// current dashboard, and writes the status and body it returns
+func (server *apiServer) withWidget(w http.ResponseWriter, name string, fn func(*WtfApp, wtf.Wtfable) (int, interface{})) {
+	status := http.StatusNotFound
+	var body interface{} = apiErrorBody(fmt.Sprintf("there is no widget named %q", name))
+
+	err := server.onUI(func() {
+		wtfApp, err := server.appMan.Current()
+		if err != nil {
+			return
+		}
+
+		for _, widget := range wtfApp.widgets {
+			if widget.Name() == name {
+				status, body = fn(wtfApp, widget)
+				return
+			}
+		}
+	})
+	if err != nil {
+		writeAPIError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	writeAPIResponse(w, status, body)
+}
+
+// findKeyboardAction returns the widget's keyboard command bound to the key. Named keys,
+// i.e.: "Ctrl-D", are matched ignoring case
+func findKeyboardAction(widget wtf.Wtfable, key string) (view.KeyboardAction, bool) {
+	actionable, ok := widget.(keyboardActionable)
+	if !ok {
+		return view.KeyboardAction{}, false
+	}
+
+	for _, action := range actionable.KeyboardActions() {
+		if action.Action == nil {
+			continue
+		}
+
+		if action.Key == key || (utf8.RuneCountInString(key) > 1 && strings.EqualFold(action.Key, key)) {
+			return action, true
+		}
+	}
+
+	return view.KeyboardAction{}, false
+}
+
+func newAPIWidget(widget wtf.Wtfable) apiWidget {
+	result := apiWidget{
+		Name:    widget.Name(),
+		Type:    widget.CommonSettings().Module.Type,
+		Title:   widget.CommonSettings().Title,
+		Stale:   widget.Stale(),
+		Actions: []apiAction{},
+	}
+
+	if rend, ok := widget.(interface{ Rendered() view.RenderedContent }); ok {
+		rendered := rend.Rendered()
+		if title := rendered.PlainTitle(); title != "" {
+			result.Title = title
+		}
+		result.Content = rendered.PlainContent()
+	}
+
+	if err := widget.LastError(); err != nil {
+		result.Error = err.Error()
+	}
+
+	if lastSuccess := widget.LastSuccess(); !lastSuccess.IsZero() {
+		result.LastSuccess = &lastSuccess
+	}
+
+	if refresher, ok := widget.(interface{ Refreshing() bool }); ok {
+		result.Refreshing = refresher.Refreshing()
+	}
+
+	if actionable, ok := widget.(keyboardActionable); ok {
+		for _, action := range actionable.KeyboardActions() {
+			if action.Action != nil {
+				result.Actions = append(result.Actions, apiAction{Key: action.Key, Text: action.Text})
+			}
+		}
+	}
+
+	return result
+}
+
+// requestHostname returns the host name the request was addressed to, without its port
+func requestHostname(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		return strings.Trim(r.Host, "[]")
+	}
+
+	return host
+}
+
+func apiErrorBody(msg string) map[string]string {
+	return map[string]string{"error": msg}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, msg string) {
+	writeAPIResponse(w, status, apiErrorBody(msg))
+}
+
+func writeAPIResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// removeStaleSocket removes a socket left behind at the path by a previous run, which
+// would stop the listener from starting. Anything else at the path is left alone, and
+// the listener fails on it instead
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if info.Mode()&os.ModeSocket == 0 {
+		return nil
+	}
+
+	return os.Remove(path)
+}
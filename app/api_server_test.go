@@ -0,0 +1,194 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func newTestAPIServer(token string) (*apiServer, *testWidget, *bool) {
+	widget := newPaletteWidget("github", "GitHub")
+	widget.Draw(func() (string, string, bool) { return "[green]GitHub", "[red]3 open[white] PRs", false })
+
+	opened := false
+	widget.SetKeyboardChar("o", func() { opened = true }, "Open in browser")
+
+	appMan := &WtfAppManager{
+		WtfApps: []*WtfApp{
+			{
+				scheduler: testScheduler(""),
+				widgets:   []wtf.Wtfable{widget},
+			},
+		},
+	}
+
+	server := newAPIServer(appMan, token)
+	server.onUI = func(fn func()) error {
+		fn()
+		return nil
+	}
+
+	return server, widget, &opened
+}
+
+func apiRequest(server *apiServer, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Host = "localhost:7483"
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	server.handler().ServeHTTP(rec, req)
+
+	return rec
+}
+
+func Test_apiServer_Widgets(t *testing.T) {
+	server, _, _ := newTestAPIServer("")
+
+	rec := apiRequest(server, http.MethodGet, "/api/widgets", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	widgets := []apiWidget{}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&widgets))
+
+	assert.Equal(t, 1, len(widgets))
+	assert.Equal(t, "github", widgets[0].Name)
+	assert.Equal(t, "GitHub", widgets[0].Title)
+	assert.Equal(t, "3 open PRs", widgets[0].Content)
+	assert.Contains(t, widgets[0].Actions, apiAction{Key: "o", Text: "Open in browser"})
+
+	rec = apiRequest(server, http.MethodPost, "/api/widgets", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func Test_apiServer_Widget(t *testing.T) {
+	server, widget, opened := newTestAPIServer("")
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "describing a widget",
+			method:         http.MethodGet,
+			path:           "/api/widgets/github",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"name":"github"`,
+		},
+		{
+			name:           "with an unknown widget",
+			method:         http.MethodGet,
+			path:           "/api/widgets/jira",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `there is no widget named \"jira\"`,
+		},
+		{
+			name:           "refreshing a widget",
+			method:         http.MethodPost,
+			path:           "/api/widgets/github/refresh",
+			expectedStatus: http.StatusAccepted,
+			expectedBody:   `"status":"refreshing"`,
+		},
+		{
+			name:           "refreshing with the wrong method",
+			method:         http.MethodGet,
+			path:           "/api/widgets/github/refresh",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "with an unknown keyboard command",
+			method:         http.MethodPost,
+			path:           "/api/widgets/github/actions/z",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `github has no keyboard command on \"z\"`,
+		},
+		{
+			name:           "running a keyboard command",
+			method:         http.MethodPost,
+			path:           "/api/widgets/github/actions/o",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"status":"done"`,
+		},
+		{
+			name:           "with an unknown route",
+			method:         http.MethodPost,
+			path:           "/api/widgets/github/cats",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := apiRequest(server, tt.method, tt.path, "")
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.expectedBody)
+		})
+	}
+
+	assert.True(t, *opened)
+	assert.Eventually(t, func() bool { return widget.refreshCount() == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func Test_apiServer_Token(t *testing.T) {
+	server, _, _ := newTestAPIServer("s3cret")
+
+	rec := apiRequest(server, http.MethodGet, "/api/widgets", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = apiRequest(server, http.MethodGet, "/api/widgets", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = apiRequest(server, http.MethodGet, "/api/widgets", "s3cret")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json"))
+}
+
+func Test_apiServer_WebPages(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		host     string
+		origin   string
+		expected int
+	}{
+		{name: "from a script", host: "localhost:7483", expected: http.StatusOK},
+		{name: "to a loopback address", host: "[::1]:7483", expected: http.StatusOK},
+		{name: "from a web page", host: "localhost:7483", origin: "https://example.com", expected: http.StatusForbidden},
+		{name: "from a web page with a token", token: "s3cret", host: "localhost:7483", origin: "https://example.com", expected: http.StatusForbidden},
+		{name: "to another host", host: "rebound.example.com:7483", expected: http.StatusForbidden},
+		{name: "to another host with a token", token: "s3cret", host: "wtf.example.com:7483", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _, opened := newTestAPIServer(tt.token)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/widgets/github/actions/o", nil)
+			req.Host = tt.host
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			server.handler().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+			assert.Equal(t, tt.expected == http.StatusOK, *opened)
+		})
+	}
+}
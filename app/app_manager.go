@@ -3,21 +3,28 @@ package app
 import (
 	"errors"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/olebedev/config"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/notify"
 )
 
 // WtfAppManager handles the instances of WtfApp, ensuring that they're displayed as requested
 type WtfAppManager struct {
-	WtfApps []*WtfApp
+	TViewApp *tview.Application
+	WtfApps  []*WtfApp
 
+	// screen is what the tview app last drew on. It's only used on the UI goroutine
+	screen   tcell.Screen
 	selected int
 }
 
 // NewAppManager creates and returns an instance of AppManager
 func NewAppManager() WtfAppManager {
 	appMan := WtfAppManager{
-		WtfApps: []*WtfApp{},
+		TViewApp: tview.NewApplication(),
+		WtfApps:  []*WtfApp{},
 	}
 
 	return appMan
@@ -25,16 +32,49 @@ func NewAppManager() WtfAppManager {
 
 // MakeNewWtfApp creates and starts a new instance of WtfApp from a set of configuration params
 func (appMan *WtfAppManager) MakeNewWtfApp(config *config.Config, configFilePath string) {
-	wtfApp := NewWtfApp(tview.NewApplication(), config, configFilePath)
+	wtfApp := NewWtfApp(appMan.TViewApp, config, configFilePath)
 	appMan.Add(wtfApp)
 
 	wtfApp.Start()
 }
 
+// MakeNewWtfApps creates and starts an instance of WtfApp for every dashboard defined
+// in the configuration. The first one is displayed onscreen
+func (appMan *WtfAppManager) MakeNewWtfApps(config *config.Config, configFilePath string) {
+	// All the dashboards share a single limit on the number of concurrent refreshes
+	limiter := newRefreshLimiter(config)
+
+	appMan.TViewApp.EnableMouse(mouseEnabled(config))
+	appMan.TViewApp.SetAfterDrawFunc(func(screen tcell.Screen) { appMan.screen = screen })
+	notify.SetBeeper(appMan.beep)
+	notify.SetFlasher(appMan.flash)
+
+	for _, dashboard := range cfg.LoadDashboards(config, configFilePath) {
+		wtfApp := NewWtfApp(appMan.TViewApp, dashboard.Config, dashboard.FilePath)
+		wtfApp.dashboard = dashboard
+		wtfApp.scheduler.limiter = limiter
+		appMan.Add(wtfApp)
+
+		// Widgets on hidden dashboards don't refresh until their dashboard is displayed
+		if len(appMan.WtfApps) > 1 {
+			wtfApp.scheduler.Pause()
+		}
+
+		wtfApp.Start()
+	}
+}
+
 // Add adds a WtfApp to the collection of apps that the AppManager manages.
 // This app is then available for display onscreen.
 func (appMan *WtfAppManager) Add(wtfApp *WtfApp) {
 	appMan.WtfApps = append(appMan.WtfApps, wtfApp)
+	wtfApp.exit = appMan.exit
+
+	if len(appMan.WtfApps) == 1 {
+		appMan.TViewApp.SetInputCapture(appMan.keyboardIntercept)
+		appMan.TViewApp.SetMouseCapture(appMan.mouseIntercept)
+		wtfApp.activate()
+	}
 }
 
 // Current returns the currently-displaying instance of WtfApp
@@ -55,7 +95,7 @@ func (appMan *WtfAppManager) Next() (*WtfApp, error) {
 		appMan.selected = 0
 	}
 
-	return appMan.Current()
+	return appMan.display()
 }
 
 // Prev cycles the WtfApps backwards by one, making the previous one in the
@@ -67,5 +107,89 @@ func (appMan *WtfAppManager) Prev() (*WtfApp, error) {
 		appMan.selected = len(appMan.WtfApps) - 1
 	}
 
-	return appMan.Current()
+	return appMan.display()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// display puts the currently-selected WtfApp onscreen and pauses the refreshing of the
+// widgets in all the others
+func (appMan *WtfAppManager) display() (*WtfApp, error) {
+	wtfApp, err := appMan.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range appMan.WtfApps {
+		if other != wtfApp {
+			other.scheduler.Pause()
+		}
+	}
+
+	wtfApp.scheduler.Resume()
+	wtfApp.activate()
+
+	return wtfApp, nil
+}
+
+// keyboardIntercept handles the keys that quit and that switch between dashboards and
+// themes, and passes every other key press on to the currently-displayed WtfApp. While
+// text is being typed into the WtfApp, every key but Ctrl-C goes to what it's typed into
+func (appMan *WtfAppManager) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	wtfApp, err := appMan.Current()
+	if err != nil {
+		return event
+	}
+
+	if event.Key() == tcell.KeyCtrlC {
+		appMan.exit()
+		return event
+	}
+
+	if wtfApp.takingInput() {
+		return event
+	}
+
+	switch event.Key() {
+	case tcell.KeyCtrlSpace:
+		_, _ = appMan.Next()
+		return nil
+	case tcell.KeyCtrlT:
+		appMan.cycleTheme()
+		return nil
+	}
+
+	return wtfApp.keyboardIntercept(event)
+}
+
+// exit stops every WtfApp and the tview app, which makes the displayed WtfApp's Execute
+// return, and leaves its exit message behind
+func (appMan *WtfAppManager) exit() {
+	appMan.stop()
+
+	if wtfApp, err := appMan.Current(); err == nil {
+		wtfApp.DisplayExitMessage()
+	}
+}
+
+// stop stops the widgets of every WtfApp, including those on dashboards that aren't
+// displayed, and then the tview app
+func (appMan *WtfAppManager) stop() {
+	for _, wtfApp := range appMan.WtfApps {
+		wtfApp.Stop()
+	}
+
+	if appMan.TViewApp != nil {
+		appMan.TViewApp.Stop()
+	}
+}
+
+// mouseIntercept passes mouse events on to the currently-displayed WtfApp
+func (appMan *WtfAppManager) mouseIntercept(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+	wtfApp, err := appMan.Current()
+	if err != nil {
+		return event, action
+	}
+
+	return wtfApp.mouseIntercept(event, action)
 }
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/support"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func newTestAppManager(widgets ...*testWidget) *WtfAppManager {
+	appMan := &WtfAppManager{TViewApp: tview.NewApplication()}
+
+	for _, widget := range widgets {
+		appMan.WtfApps = append(appMan.WtfApps, &WtfApp{
+			TViewApp:   appMan.TViewApp,
+			pages:      tview.NewPages(),
+			redrawChan: make(chan bool, 1),
+			scheduler:  testScheduler(""),
+			widgets:    []wtf.Wtfable{widget},
+		})
+	}
+
+	return appMan
+}
+
+func Test_WtfAppManager_keyboardIntercept_TakingInput(t *testing.T) {
+	appMan := newTestAppManager(newTestWidget(time.Minute), newTestWidget(time.Minute))
+	wtfApp, _ := appMan.Current()
+
+	// While the command palette is open, the keys that switch dashboards go to it
+	wtfApp.pages.AddPage(commandPalettePageName, tview.NewBox(), false, true)
+
+	event := tcell.NewEventKey(tcell.KeyCtrlSpace, 0, tcell.ModNone)
+	assert.Equal(t, event, appMan.keyboardIntercept(event))
+	assert.Equal(t, 0, appMan.selected)
+
+	wtfApp.pages.RemovePage(commandPalettePageName)
+
+	assert.Nil(t, appMan.keyboardIntercept(event))
+	assert.Equal(t, 1, appMan.selected)
+}
+
+func Test_WtfAppManager_keyboardIntercept_Quit(t *testing.T) {
+	displayed := newTestWidget(time.Minute)
+	hidden := newTestWidget(time.Minute)
+	appMan := &WtfAppManager{TViewApp: tview.NewApplication()}
+
+	for _, widget := range []*testWidget{displayed, hidden} {
+		config, _ := config.ParseYaml("wtf:\n  exitMessage:\n    display: false")
+		widgets := []wtf.Wtfable{widget}
+
+		appMan.Add(&WtfApp{
+			TViewApp:     appMan.TViewApp,
+			config:       config,
+			focusTracker: NewFocusTracker(appMan.TViewApp, widgets, config),
+			ghUser:       &support.GitHubUser{IsContributor: true},
+			pages:        tview.NewPages(),
+			redrawChan:   make(chan bool, 1),
+			scheduler:    testScheduler(""),
+			widgets:      widgets,
+		})
+	}
+
+	// "q" quits from the displayed dashboard, and stops the hidden one's widgets too
+	appMan.keyboardIntercept(tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone))
+
+	assert.True(t, displayed.Disabled())
+	assert.True(t, hidden.Disabled())
+}
+
+func Test_WtfAppManager_stop(t *testing.T) {
+	displayed := newTestWidget(time.Minute)
+	hidden := newTestWidget(time.Minute)
+	appMan := newTestAppManager(displayed, hidden)
+	assert.False(t, hidden.Disabled())
+
+	appMan.stop()
+
+	assert.True(t, displayed.Disabled())
+	assert.True(t, hidden.Disabled())
+}
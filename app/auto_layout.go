@@ -0,0 +1,186 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// placement is where the auto layout puts a widget onscreen. Its top and height are in
+// terminal rows, and its column is an index into the layout's column widths
+type placement struct {
+	widget wtf.Wtfable
+	column int
+	top    int
+	height int
+}
+
+// autoLayout flows the widgets into as many columns as fit the width, in order, and
+// divides each column's height between its widgets by weight after giving each its
+// minimum height. It returns the width of every column and where each widget goes
+func autoLayout(widgets []wtf.Wtfable, layout *cfg.LayoutSettings, width, height int) ([]int, []placement) {
+	ordered := []wtf.Wtfable{}
+	for _, widget := range widgets {
+		if !widget.Disabled() {
+			ordered = append(ordered, widget)
+		}
+	}
+
+	if len(ordered) == 0 || width <= 0 || height <= 0 {
+		return []int{}, []placement{}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i].CommonSettings(), ordered[j].CommonSettings()
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return a.Name < b.Name
+	})
+
+	numCols := layoutColumns(ordered, layout, width)
+	colWidths := divide(width, numCols)
+
+	// Each widget goes into the column with the least weight in it so far, which keeps
+	// the columns balanced while keeping the widgets roughly in reading order
+	columns := make([][]wtf.Wtfable, numCols)
+	loads := make([]int, numCols)
+
+	for _, widget := range ordered {
+		col := 0
+		for idx := range loads {
+			if loads[idx] < loads[col] {
+				col = idx
+			}
+		}
+
+		columns[col] = append(columns[col], widget)
+		loads[col] += weight(widget)
+	}
+
+	placements := []placement{}
+
+	for col, colWidgets := range columns {
+		top := 0
+
+		for idx, rows := range columnHeights(colWidgets, height) {
+			placements = append(placements, placement{widget: colWidgets[idx], column: col, top: top, height: rows})
+			top += rows
+		}
+	}
+
+	return colWidths, placements
+}
+
+// layoutColumns returns the number of columns to use at the width, reduced until every
+// widget's minimum width fits in a column
+func layoutColumns(widgets []wtf.Wtfable, layout *cfg.LayoutSettings, width int) int {
+	minWidth := 0
+	for _, widget := range widgets {
+		if widget.CommonSettings().MinWidth > minWidth {
+			minWidth = widget.CommonSettings().MinWidth
+		}
+	}
+
+	numCols := layout.Columns(width)
+	for numCols > 1 && width/numCols < minWidth {
+		numCols--
+	}
+
+	if numCols > len(widgets) {
+		numCols = len(widgets)
+	}
+
+	if numCols < 1 {
+		return 1
+	}
+
+	return numCols
+}
+
+// columnHeights gives each widget its minimum height, and shares the rest of the height
+// between them by weight. If the minimums don't fit they are ignored
+func columnHeights(widgets []wtf.Wtfable, height int) []int {
+	heights := make([]int, len(widgets))
+
+	minTotal := 0
+	for _, widget := range widgets {
+		minTotal += widget.CommonSettings().MinHeight
+	}
+
+	if minTotal <= height {
+		for idx, widget := range widgets {
+			heights[idx] = widget.CommonSettings().MinHeight
+		}
+	} else {
+		minTotal = 0
+	}
+
+	weights := make([]int, len(widgets))
+	for idx, widget := range widgets {
+		weights[idx] = weight(widget)
+	}
+
+	for idx, share := range divideByWeight(height-minTotal, weights) {
+		heights[idx] += share
+	}
+
+	return heights
+}
+
+// divide splits the total into the given number of near-equal parts, the first ones
+// taking the remainder
+func divide(total, parts int) []int {
+	weights := make([]int, parts)
+	for idx := range weights {
+		weights[idx] = 1
+	}
+
+	return divideByWeight(total, weights)
+}
+
+// divideByWeight splits the total in proportion to the weights, handing out what is left
+// after rounding down to the parts that lost the most to rounding
+func divideByWeight(total int, weights []int) []int {
+	shares := make([]int, len(weights))
+
+	sum := 0
+	for _, w := range weights {
+		sum += w
+	}
+
+	if sum == 0 {
+		return shares
+	}
+
+	remainders := make([]int, len(weights))
+	order := make([]int, len(weights))
+
+	allotted := 0
+	for idx, w := range weights {
+		shares[idx] = total * w / sum
+		remainders[idx] = total * w % sum
+		order[idx] = idx
+		allotted += shares[idx]
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+
+	for idx := 0; allotted < total; idx = (idx + 1) % len(order) {
+		shares[order[idx]]++
+		allotted++
+	}
+
+	return shares
+}
+
+func weight(widget wtf.Wtfable) int {
+	if widget.CommonSettings().Weight < 1 {
+		return 1
+	}
+
+	return widget.CommonSettings().Weight
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func newLayoutWidget(name string, order, minWidth, minHeight, weight int) *testWidget {
+	widget := newTestWidget(0)
+
+	settings := widget.CommonSettings()
+	settings.Name = name
+	settings.Order = order
+	settings.MinWidth = minWidth
+	settings.MinHeight = minHeight
+	settings.Weight = weight
+
+	return widget
+}
+
+func placed(placements []placement) map[string][3]int {
+	result := map[string][3]int{}
+	for _, place := range placements {
+		result[place.widget.CommonSettings().Name] = [3]int{place.column, place.top, place.height}
+	}
+	return result
+}
+
+func Test_autoLayout(t *testing.T) {
+	layout := &cfg.LayoutSettings{
+		Type:           cfg.AutoLayout,
+		Breakpoints:    []cfg.Breakpoint{{Below: 120, Columns: 1}},
+		MaxColumns:     3,
+		MinColumnWidth: 40,
+	}
+
+	widgets := []wtf.Wtfable{
+		newLayoutWidget("c", 0, 0, 0, 1),
+		newLayoutWidget("a", 1, 0, 0, 2),
+		newLayoutWidget("b", 0, 0, 0, 1),
+		newLayoutWidget("d", 2, 0, 10, 1),
+	}
+
+	colWidths, placements := autoLayout(widgets, layout, 130, 30)
+
+	// b and c sort before a by order, and d goes into the leftmost column with the least
+	// weight, where it gets its minimum height plus its share of the rest
+	assert.Equal(t, []int{44, 43, 43}, colWidths)
+	assert.Equal(t, map[string][3]int{
+		"b": {0, 0, 10},
+		"c": {1, 0, 30},
+		"a": {2, 0, 30},
+		"d": {0, 10, 20},
+	}, placed(placements))
+
+	colWidths, placements = autoLayout(widgets, layout, 100, 40)
+
+	assert.Equal(t, []int{100}, colWidths)
+	assert.Equal(t, map[string][3]int{
+		"b": {0, 0, 6},
+		"c": {0, 6, 6},
+		"a": {0, 12, 12},
+		"d": {0, 24, 16},
+	}, placed(placements))
+}
+
+func Test_autoLayout_MinWidth(t *testing.T) {
+	layout := &cfg.LayoutSettings{Type: cfg.AutoLayout, MaxColumns: 3, MinColumnWidth: 40}
+
+	widgets := []wtf.Wtfable{
+		newLayoutWidget("a", 0, 70, 0, 1),
+		newLayoutWidget("b", 0, 0, 0, 1),
+		newLayoutWidget("c", 0, 0, 0, 1),
+	}
+
+	colWidths, _ := autoLayout(widgets, layout, 150, 10)
+	assert.Equal(t, []int{75, 75}, colWidths)
+
+	colWidths, _ = autoLayout(widgets[1:2], layout, 150, 10)
+	assert.Equal(t, []int{150}, colWidths)
+}
+
+func Test_divideByWeight(t *testing.T) {
+	assert.Equal(t, []int{4, 3, 3}, divide(10, 3))
+	assert.Equal(t, []int{3, 7}, divideByWeight(10, []int{1, 2}))
+	assert.Equal(t, []int{0, 0}, divideByWeight(10, []int{0, 0}))
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/view"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const (
+	commandPalettePageName = "commandPalette"
+
+	paletteHeight = 20
+	paletteWidth  = 80
+)
+
+// paletteCommand is a command that can be chosen from the command palette
+type paletteCommand struct {
+	label string
+	run   func()
+}
+
+// keyboardActionable is implemented by widgets that have keyboard commands
+type keyboardActionable interface {
+	KeyboardActions() []view.KeyboardAction
+}
+
+// paletteCommands returns a command to focus each focusable widget, followed by every
+// keyboard command registered on it. Keyboard commands focus the widget before running
+func (wtfApp *WtfApp) paletteCommands() []paletteCommand {
+	commands := []paletteCommand{}
+
+	for _, widget := range wtfApp.focusTracker.focusables() {
+		name := widget.Name()
+		label := widgetLabel(widget)
+
+		focus := func() {
+			wtfApp.focusTracker.FocusOnName(name)
+
+			if wtfApp.zoomed != nil && wtfApp.zoomed.Name() != name {
+				wtfApp.unzoom()
+			}
+		}
+
+		commands = append(commands, paletteCommand{label: "Focus " + label, run: focus})
+
+		actionable, ok := widget.(keyboardActionable)
+		if !ok {
+			continue
+		}
+
+		for _, action := range actionable.KeyboardActions() {
+			if action.Action == nil {
+				continue
+			}
+
+			fn := action.Action
+
+			commands = append(commands, paletteCommand{
+				label: fmt.Sprintf("%s: %s (%s)", label, action.Text, action.Key),
+				run: func() {
+					focus()
+					fn()
+				},
+			})
+		}
+	}
+
+	return commands
+}
+
+// filterPaletteCommands returns the commands that fuzzy-match the query, best match first
+func filterPaletteCommands(commands []paletteCommand, query string) []paletteCommand {
+	type scoredCommand struct {
+		command paletteCommand
+		score   int
+	}
+
+	scored := []scoredCommand{}
+	for _, command := range commands {
+		if score, ok := utils.FuzzyMatch(query, command.label); ok {
+			scored = append(scored, scoredCommand{command, score})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	filtered := make([]paletteCommand, len(scored))
+	for idx, item := range scored {
+		filtered[idx] = item.command
+	}
+
+	return filtered
+}
+
+// showCommandPalette displays a modal dialog that fuzzy-searches every widget and keyboard
+// command, and runs the one chosen
+func (wtfApp *WtfApp) showCommandPalette() {
+	commands := wtfApp.paletteCommands()
+	filtered := commands
+
+	input := tview.NewInputField()
+	input.SetLabel("> ")
+	input.SetFieldBackgroundColor(tcell.ColorDefault)
+
+	list := tview.NewList()
+	list.ShowSecondaryText(false)
+	list.SetHighlightFullLine(true)
+
+	populate := func(query string) {
+		filtered = filterPaletteCommands(commands, query)
+
+		list.Clear()
+		for _, command := range filtered {
+			list.AddItem(tview.Escape(command.label), "", 0, nil)
+		}
+	}
+	populate("")
+
+	closeFunc := func() {
+		wtfApp.pages.RemovePage(commandPalettePageName)
+		wtfApp.restoreFocus()
+	}
+
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeFunc()
+		case tcell.KeyEnter:
+			closeFunc()
+
+			if idx := list.GetCurrentItem(); idx >= 0 && idx < len(filtered) {
+				filtered[idx].run()
+			}
+		case tcell.KeyUp, tcell.KeyCtrlP:
+			if list.GetCurrentItem() > 0 {
+				list.SetCurrentItem(list.GetCurrentItem() - 1)
+			}
+		case tcell.KeyDown, tcell.KeyCtrlN:
+			if list.GetCurrentItem() < list.GetItemCount()-1 {
+				list.SetCurrentItem(list.GetCurrentItem() + 1)
+			}
+		default:
+			return event
+		}
+
+		return nil
+	})
+	input.SetChangedFunc(populate)
+
+	palette := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	palette.SetBorder(true)
+	palette.SetTitle(" Command Palette ")
+
+	// Center the palette over the dashboard
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(palette, paletteHeight, 0, true).
+			AddItem(nil, 0, 1, false), paletteWidth, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	wtfApp.pages.AddPage(commandPalettePageName, modal, true, true)
+	wtfApp.TViewApp.SetFocus(input)
+}
+
+// widgetLabel returns the name a widget is listed under in the command palette
+func widgetLabel(widget wtf.Wtfable) string {
+	title := widget.CommonSettings().Title
+	if title == "" || title == widget.Name() {
+		return widget.Name()
+	}
+
+	return fmt.Sprintf("%s (%s)", title, widget.Name())
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/view"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func newPaletteWidget(name, title string) *testWidget {
+	common := &cfg.Common{
+		Module:    cfg.Module{Name: name, Type: name},
+		Enabled:   true,
+		Focusable: true,
+		Title:     title,
+	}
+
+	return &testWidget{
+		TextWidget: view.NewTextWidget(nil, make(chan bool), nil, common),
+	}
+}
+
+func commandLabels(commands []paletteCommand) []string {
+	labels := []string{}
+	for _, command := range commands {
+		labels = append(labels, command.label)
+	}
+
+	return labels
+}
+
+func Test_paletteCommands(t *testing.T) {
+	github := newPaletteWidget("github", "GitHub")
+	refreshed := false
+	github.SetKeyboardChar("r", func() { refreshed = true }, "Refresh widget")
+
+	clocks := newPaletteWidget("clocks", "")
+
+	emptyConfig, _ := config.ParseYaml("")
+	wtfApp := &WtfApp{
+		focusTracker: NewFocusTracker(tview.NewApplication(), []wtf.Wtfable{github, clocks}, emptyConfig),
+	}
+
+	commands := wtfApp.paletteCommands()
+
+	assert.Equal(
+		t,
+		[]string{
+			"Focus GitHub (github)",
+			"GitHub (github): Open the documentation for this module in a browser (\\)",
+			"GitHub (github): Refresh widget (r)",
+			"GitHub (github): Search: Ctrl-F again to filter, n/N to move between matches (Ctrl-F)",
+			"Focus clocks",
+			"clocks: Open the documentation for this module in a browser (\\)",
+			"clocks: Search: Ctrl-F again to filter, n/N to move between matches (Ctrl-F)",
+		},
+		commandLabels(commands),
+	)
+
+	commands[2].run()
+
+	assert.True(t, refreshed)
+	assert.Equal(t, "github", wtfApp.focusTracker.FocusedName())
+}
+
+func Test_filterPaletteCommands(t *testing.T) {
+	commands := []paletteCommand{
+		{label: "Focus Jenkins"},
+		{label: "GitHub: Refresh widget (r)"},
+		{label: "Focus GitHub"},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{
+			name:     "with no query",
+			query:    "",
+			expected: []string{"Focus Jenkins", "GitHub: Refresh widget (r)", "Focus GitHub"},
+		},
+		{
+			name:     "with no matches",
+			query:    "zzz",
+			expected: []string{},
+		},
+		{
+			name:     "with the best match first",
+			query:    "fg",
+			expected: []string{"Focus GitHub", "GitHub: Refresh widget (r)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, commandLabels(filterPaletteCommands(commands, tt.query)))
+		})
+	}
+}
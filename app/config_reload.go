@@ -0,0 +1,264 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cache"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/notify"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// moduleChanges describes how the modules defined in a changed configuration differ
+// from the ones that are currently running
+type moduleChanges struct {
+	added     []string
+	changed   []string
+	moved     []string
+	removed   []string
+	unchanged []string
+}
+
+// diffModules compares the enabled modules in two configurations. A module has moved if
+// only its position settings differ, and has changed if any other setting differs. If
+// any of the global settings that modules read their defaults from (colors, sigils,
+// etc.), or the layout that their positions are read for, differ then every module is
+// considered to have changed
+func diffModules(oldConfig, newConfig *config.Config) moduleChanges {
+	changes := moduleChanges{}
+
+	oldModules := enabledModules(oldConfig)
+	newModules := enabledModules(newConfig)
+
+	globalsChanged := !reflect.DeepEqual(globalSettings(oldConfig), globalSettings(newConfig))
+
+	for name, newModule := range newModules {
+		oldModule, ok := oldModules[name]
+
+		switch {
+		case !ok:
+			changes.added = append(changes.added, name)
+		case globalsChanged:
+			changes.changed = append(changes.changed, name)
+		case reflect.DeepEqual(oldModule, newModule):
+			changes.unchanged = append(changes.unchanged, name)
+		case reflect.DeepEqual(withoutPosition(oldModule), withoutPosition(newModule)):
+			changes.moved = append(changes.moved, name)
+		default:
+			changes.changed = append(changes.changed, name)
+		}
+	}
+
+	for name := range oldModules {
+		if _, ok := newModules[name]; !ok {
+			changes.removed = append(changes.removed, name)
+		}
+	}
+
+	for _, names := range [][]string{changes.added, changes.changed, changes.moved, changes.removed, changes.unchanged} {
+		sort.Strings(names)
+	}
+
+	return changes
+}
+
+// enabledModules returns the raw configuration of every enabled module, keyed by module name
+func enabledModules(config *config.Config) map[string]map[string]interface{} {
+	modules := map[string]map[string]interface{}{}
+
+	mods, err := config.Map("wtf.mods")
+	if err != nil {
+		return modules
+	}
+
+	for name, mod := range mods {
+		modMap, ok := mod.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if enabled, _ := modMap["enabled"].(bool); enabled {
+			modules[name] = modMap
+		}
+	}
+
+	return modules
+}
+
+// globalSettings returns the global settings that modules read their defaults from. The
+// grid is excluded because it only affects the layout, not the widgets themselves, and
+// the API, log and notifications because they don't affect either. The layout is always
+// included, because how the modules read their positions depends on it
+func globalSettings(config *config.Config) map[string]interface{} {
+	settings := map[string]interface{}{}
+
+	// Widgets are built with the layout's settings even if it isn't set at all
+	layout, _ := config.Get("wtf.layout")
+	if layout != nil {
+		settings["layout"] = layout.Root
+	} else {
+		settings["layout"] = nil
+	}
+
+	wtfSettings, err := config.Map("wtf")
+	if err != nil {
+		return settings
+	}
+
+	for key, val := range wtfSettings {
+		switch key {
+		case "api", "dashboards", "grid", "layout", "log", "mods", "name", "notifications":
+			continue
+		default:
+			settings[key] = val
+		}
+	}
+
+	return settings
+}
+
+func withoutPosition(module map[string]interface{}) map[string]interface{} {
+	stripped := map[string]interface{}{}
+
+	for key, val := range module {
+		if key != "position" {
+			stripped[key] = val
+		}
+	}
+
+	return stripped
+}
+
+/* -------------------- Reloading -------------------- */
+
+// reload applies a changed configuration to the running app. Widgets whose settings are
+// unchanged keep running with their data, scroll position and selection intact, widgets
+// that have only moved are repositioned, and only new or changed widgets are rebuilt.
+// The configuration is compared with the running one and swapped in on the UI goroutine,
+// in one go, so that quick successive changes are each compared with the one before
+func (wtfApp *WtfApp) reload(config *config.Config) {
+	wtfApp.TViewApp.QueueUpdateDraw(func() {
+		wtfApp.applyConfig(config)
+	})
+}
+
+// applyConfig swaps the configuration and widgets for new ones. It must be called on the
+// UI goroutine. The new widgets are made and checked before any running widget is
+// stopped, so that if they're invalid the running configuration carries on as it was
+func (wtfApp *WtfApp) applyConfig(config *config.Config) {
+	changes := diffModules(wtfApp.config, config)
+
+	running := map[string]wtf.Wtfable{}
+	for _, widget := range wtfApp.widgets {
+		running[widget.Name()] = widget
+	}
+
+	kept := map[string]wtf.Wtfable{}
+	for _, name := range changes.unchanged {
+		kept[name] = running[name]
+	}
+
+	// Moved widgets are checked in their new positions, and put back if the new
+	// configuration is rejected
+	oldPositions := map[string]cfg.PositionSettings{}
+	for _, name := range changes.moved {
+		widget := running[name]
+		oldPositions[name] = widget.CommonSettings().PositionSettings
+
+		moduleConfig, _ := config.Get("wtf.mods." + name)
+		widget.CommonSettings().PositionSettings = cfg.NewPositionSettingsFromYAML(moduleConfig, config)
+
+		kept[name] = widget
+	}
+
+	newWidgets := []wtf.Wtfable{}
+	for _, name := range append(changes.changed, changes.added...) {
+		widget := MakeWidget(wtfApp.TViewApp, wtfApp.pages, name, config, wtfApp.redrawChan)
+		if widget == nil {
+			continue
+		}
+
+		kept[name] = widget
+		newWidgets = append(newWidgets, widget)
+	}
+
+	// The widgets are in the same order as when the app starts, so that they're given the
+	// same focus keys
+	widgets := []wtf.Wtfable{}
+	for _, name := range moduleNames(config) {
+		if widget, ok := kept[name]; ok {
+			widgets = append(widgets, widget)
+		}
+	}
+
+	if problems := reloadProblems(wtfApp.validator, widgets); len(problems) > 0 {
+		for _, problem := range problems {
+			logger.Error("the changed config is invalid, keeping the running config", "problem", problem)
+		}
+
+		for _, widget := range newWidgets {
+			widget.Stop()
+		}
+		for name, position := range oldPositions {
+			running[name].CommonSettings().PositionSettings = position
+		}
+
+		return
+	}
+
+	for _, name := range append(changes.removed, changes.changed...) {
+		running[name].Stop()
+	}
+
+	openURLUtil := utils.ToStrs(config.UList("wtf.openUrlUtil", []interface{}{}))
+	utils.Init(config.UString("wtf.openFileUtil", "open"), openURLUtil)
+	logger.Init(config)
+	cache.Init(config)
+	notify.Init(config)
+
+	wtfApp.TViewApp.EnableMouse(mouseEnabled(config))
+
+	focusedName := wtfApp.focusTracker.FocusedName()
+
+	// The zoomed widget might have been removed or moved, so put it back in the grid
+	wtfApp.unzoom()
+
+	wtfApp.config = config
+	wtfApp.widgets = widgets
+
+	wtfApp.display.rebuild(widgets, config)
+	wtfApp.focusTracker = NewFocusTracker(wtfApp.TViewApp, widgets, config)
+
+	if focusedName != "" {
+		wtfApp.focusTracker.FocusOnName(focusedName)
+	}
+
+	wtfApp.scheduler.ScheduleAll(newWidgets)
+}
+
+// reloadProblems returns the configuration errors and invalid key remappings of the
+// widgets, one message each
+func reloadProblems(validator *ModuleValidator, widgets []wtf.Wtfable) []string {
+	problems := []string{}
+
+	for name, errs := range validator.Errors(widgets) {
+		for _, err := range errs {
+			problems = append(problems, fmt.Sprintf("%s.position: %s: %v", name, err.String(), err.Error()))
+		}
+	}
+
+	for name, errs := range validator.KeyErrors(widgets) {
+		for _, err := range errs {
+			problems = append(problems, fmt.Sprintf("%s.keys: %s", name, err.Error()))
+		}
+	}
+
+	sort.Strings(problems)
+
+	return problems
+}
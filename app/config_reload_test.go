@@ -0,0 +1,228 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/radovskyb/watcher"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const reloadBase = `
+wtf:
+  colors:
+    border:
+      focused: orange
+  grid:
+    columns: [20, 20]
+    rows: [3, 3]
+  mods:
+    moved:
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+    changed:
+      enabled: true
+      refreshInterval: 30
+    removed:
+      enabled: true
+    disabled:
+      enabled: true
+    untouched:
+      enabled: true
+      title: "Untouched"
+`
+
+const reloadNew = `
+wtf:
+  colors:
+    border:
+      focused: orange
+  grid:
+    columns: [40, 40]
+    rows: [3, 3]
+  mods:
+    moved:
+      enabled: true
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: 1
+    changed:
+      enabled: true
+      refreshInterval: 60
+    disabled:
+      enabled: false
+    untouched:
+      enabled: true
+      title: "Untouched"
+    added:
+      enabled: true
+`
+
+func Test_diffModules(t *testing.T) {
+	oldConfig, _ := config.ParseYaml(reloadBase)
+	newConfig, _ := config.ParseYaml(reloadNew)
+
+	changes := diffModules(oldConfig, newConfig)
+
+	assert.Equal(t, []string{"added"}, changes.added)
+	assert.Equal(t, []string{"changed"}, changes.changed)
+	assert.Equal(t, []string{"moved"}, changes.moved)
+	assert.Equal(t, []string{"disabled", "removed"}, changes.removed)
+	assert.Equal(t, []string{"untouched"}, changes.unchanged)
+}
+
+func Test_diffModules_GlobalsChanged(t *testing.T) {
+	oldConfig, _ := config.ParseYaml(reloadBase)
+	newConfig, _ := config.ParseYaml(reloadBase)
+
+	_ = newConfig.Set("wtf.colors.border.focused", "red")
+
+	changes := diffModules(oldConfig, newConfig)
+
+	assert.Equal(t, []string{"changed", "disabled", "moved", "removed", "untouched"}, changes.changed)
+	assert.Empty(t, changes.unchanged)
+	assert.Empty(t, changes.moved)
+}
+
+func Test_diffModules_LayoutChanged(t *testing.T) {
+	oldConfig, _ := config.ParseYaml(reloadBase)
+	newConfig, _ := config.ParseYaml(reloadBase)
+
+	_ = newConfig.Set("wtf.layout", map[string]interface{}{"type": "auto"})
+
+	changes := diffModules(oldConfig, newConfig)
+
+	assert.Equal(t, []string{"changed", "disabled", "moved", "removed", "untouched"}, changes.changed)
+
+	// The grid on its own only moves modules
+	_ = oldConfig.Set("wtf.layout", map[string]interface{}{"type": "auto"})
+	_ = newConfig.Set("wtf.grid.columns", []interface{}{40, 40})
+
+	changes = diffModules(oldConfig, newConfig)
+
+	assert.Empty(t, changes.changed)
+}
+
+func Test_watchConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0600))
+	}
+
+	write("config.yml", "include:\n  - one.yml\n  - two.yml\n")
+	write("one.yml", "wtf:\n  refreshInterval: 2\n")
+	write("two.yml", "wtf:\n  name: two\n")
+
+	configPath := filepath.Join(dir, "config.yml")
+	watch := watcher.New()
+	watched := map[string]bool{}
+
+	watchConfigFiles(watch, watched, configPath)
+	assert.Equal(t, map[string]bool{filepath.Join(dir, "one.yml"): true, filepath.Join(dir, "two.yml"): true}, watched)
+	assert.Contains(t, watch.WatchedFiles(), filepath.Join(dir, "two.yml"))
+
+	// Files that are no longer included stop being watched
+	write("config.yml", "include: one.yml\n")
+	watchConfigFiles(watch, watched, configPath)
+	assert.Equal(t, map[string]bool{filepath.Join(dir, "one.yml"): true}, watched)
+	assert.NotContains(t, watch.WatchedFiles(), filepath.Join(dir, "two.yml"))
+
+	// A config that can't be read leaves the watched files as they are
+	write("config.yml", "include: missing.yml\n")
+	watchConfigFiles(watch, watched, configPath)
+	assert.Equal(t, map[string]bool{filepath.Join(dir, "one.yml"): true}, watched)
+}
+
+const reloadOrder = `
+wtf:
+  grid:
+    columns: [20, 20, 20]
+    rows: [3]
+  log:
+    path: %s
+  mods:
+    charlie:
+      type: nope
+      enabled: true
+      position: {top: 0, left: 2, height: 1, width: 1}
+    alpha:
+      type: nope
+      enabled: true
+      refreshInterval: %d
+      position: {top: 0, %s height: 1, width: 1}
+    bravo:
+      type: nope
+      enabled: true
+      position: {top: 0, left: 1, height: 1, width: 1}
+`
+
+func newTestReloadApp(t *testing.T, config *config.Config) *WtfApp {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	wtfApp := &WtfApp{
+		TViewApp:   tview.NewApplication(),
+		config:     config,
+		pages:      tview.NewPages(),
+		redrawChan: make(chan bool, 10),
+		scheduler:  testScheduler(""),
+		validator:  NewModuleValidator(),
+	}
+	wtfApp.widgets = MakeWidgets(wtfApp.TViewApp, wtfApp.pages, config, wtfApp.redrawChan)
+	wtfApp.display = NewDisplay(wtfApp.widgets, config)
+	wtfApp.focusTracker = NewFocusTracker(wtfApp.TViewApp, wtfApp.widgets, config)
+
+	return wtfApp
+}
+
+func widgetNames(widgets []wtf.Wtfable) []string {
+	names := []string{}
+	for _, widget := range widgets {
+		names = append(names, widget.Name())
+	}
+	return names
+}
+
+func Test_applyConfig_Order(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "log.txt")
+
+	oldConfig, _ := config.ParseYaml(fmt.Sprintf(reloadOrder, logPath, 30, "left: 0,"))
+	wtfApp := newTestReloadApp(t, oldConfig)
+	assert.Equal(t, []string{"alpha", "bravo", "charlie"}, widgetNames(wtfApp.widgets))
+
+	// A changed module is rebuilt, and stays where it was among the others
+	newConfig, _ := config.ParseYaml(fmt.Sprintf(reloadOrder, logPath, 60, "left: 0,"))
+	wtfApp.applyConfig(newConfig)
+
+	assert.Same(t, newConfig, wtfApp.config)
+	assert.Equal(t, []string{"alpha", "bravo", "charlie"}, widgetNames(wtfApp.widgets))
+}
+
+func Test_applyConfig_Invalid(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "log.txt")
+
+	oldConfig, _ := config.ParseYaml(fmt.Sprintf(reloadOrder, logPath, 30, "left: 0,"))
+	wtfApp := newTestReloadApp(t, oldConfig)
+	widgets := wtfApp.widgets
+
+	// alpha is changed, and loses the left of its position
+	newConfig, _ := config.ParseYaml(fmt.Sprintf(reloadOrder, logPath, 60, ""))
+	wtfApp.applyConfig(newConfig)
+
+	assert.Same(t, oldConfig, wtfApp.config)
+	assert.Equal(t, widgets, wtfApp.widgets)
+	for _, widget := range widgets {
+		assert.True(t, widget.Enabled(), widget.Name())
+	}
+}
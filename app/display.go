@@ -1,8 +1,10 @@
 package app
 
 import (
+	"github.com/gdamore/tcell/v2"
 	"github.com/olebedev/config"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
@@ -11,6 +13,16 @@ import (
 type Display struct {
 	Grid   *tview.Grid
 	config *config.Config
+	layout *cfg.LayoutSettings
+
+	// widgets are the widgets placed by the auto layout, which are re-flowed whenever
+	// the screen size changes
+	widgets []wtf.Wtfable
+
+	// laidOutWidth and laidOutHeight are the size of the screen the auto layout was last
+	// computed for. They are reset to force it to be computed again
+	laidOutWidth  int
+	laidOutHeight int
 }
 
 // NewDisplay creates and returns a Display
@@ -27,6 +39,8 @@ func NewDisplay(widgets []wtf.Wtfable, config *config.Config) *Display {
 		),
 	)
 
+	display.Grid.SetDrawFunc(display.reflow)
+
 	display.build(widgets)
 
 	return &display
@@ -34,11 +48,26 @@ func NewDisplay(widgets []wtf.Wtfable, config *config.Config) *Display {
 
 /* -------------------- Unexported Functions -------------------- */
 
+// rebuild replaces the contents of the grid with the given widgets, laid out according
+// to the given configuration
+func (display *Display) rebuild(widgets []wtf.Wtfable, config *config.Config) {
+	display.config = config
+
+	display.Grid.Clear()
+	display.build(widgets)
+}
+
 func (display *Display) add(widget wtf.Wtfable) {
 	if widget.Disabled() {
 		return
 	}
 
+	if display.layout.IsAuto() {
+		display.widgets = append(display.widgets, widget)
+		display.invalidate()
+		return
+	}
+
 	display.Grid.AddItem(
 		widget.TextView(),
 		widget.CommonSettings().Top,
@@ -51,13 +80,52 @@ func (display *Display) add(widget wtf.Wtfable) {
 	)
 }
 
+// arrange lays the auto layout's widgets out on a grid of single-row rows and one
+// column per layout column, for a screen of the given size
+func (display *Display) arrange(width, height int) {
+	colWidths, placements := autoLayout(display.widgets, display.layout, width, height)
+
+	rows := make([]int, height)
+	for idx := range rows {
+		rows[idx] = 1
+	}
+
+	display.Grid.Clear()
+	display.Grid.SetColumns(colWidths...)
+	display.Grid.SetRows(rows...)
+
+	for _, place := range placements {
+		if place.height <= 0 {
+			continue
+		}
+
+		display.Grid.AddItem(place.widget.TextView(), place.top, place.column, place.height, 1, 0, 0, false)
+	}
+
+	display.laidOutWidth = width
+	display.laidOutHeight = height
+}
+
 func (display *Display) build(widgets []wtf.Wtfable) *tview.Grid {
+	display.layout = cfg.NewLayoutSettingsFromYAML(display.config)
+	display.widgets = []wtf.Wtfable{}
+	display.invalidate()
+
+	display.Grid.SetBorder(false)
+
+	if display.layout.IsAuto() {
+		for _, widget := range widgets {
+			display.add(widget)
+		}
+
+		return display.Grid
+	}
+
 	cols := utils.ToInts(display.config.UList("wtf.grid.columns"))
 	rows := utils.ToInts(display.config.UList("wtf.grid.rows"))
 
 	display.Grid.SetColumns(cols...)
 	display.Grid.SetRows(rows...)
-	display.Grid.SetBorder(false)
 
 	for _, widget := range widgets {
 		display.add(widget)
@@ -65,3 +133,35 @@ func (display *Display) build(widgets []wtf.Wtfable) *tview.Grid {
 
 	return display.Grid
 }
+
+// invalidate makes the auto layout be computed again the next time the grid is drawn
+func (display *Display) invalidate() {
+	display.laidOutWidth = -1
+	display.laidOutHeight = -1
+}
+
+// reflow is called each time the grid is drawn, before its items are. It re-computes the
+// auto layout when the screen has changed size, or the widgets have changed
+func (display *Display) reflow(_ tcell.Screen, x, y, width, height int) (int, int, int, int) {
+	if display.layout.IsAuto() && (width != display.laidOutWidth || height != display.laidOutHeight) {
+		display.arrange(width, height)
+	}
+
+	return x, y, width, height
+}
+
+// remove takes the widget off the grid
+func (display *Display) remove(widget wtf.Wtfable) {
+	if !display.layout.IsAuto() {
+		display.Grid.RemoveItem(widget.TextView())
+		return
+	}
+
+	for idx, placed := range display.widgets {
+		if placed == widget {
+			display.widgets = append(display.widgets[:idx], display.widgets[idx+1:]...)
+			break
+		}
+	}
+	display.invalidate()
+}
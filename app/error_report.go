@@ -0,0 +1,74 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/view"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const errorsPageName = "errors"
+
+// errorReport describes the state of every widget whose most recent refresh failed,
+// including the full chain of errors that caused the failure
+func errorReport(widgets []wtf.Wtfable) string {
+	reports := []string{}
+
+	for _, widget := range widgets {
+		err := widget.LastError()
+		if err == nil {
+			continue
+		}
+
+		lastUpdated := "never updated"
+		if lastSuccess := widget.LastSuccess(); !lastSuccess.IsZero() {
+			lastUpdated = "last updated " + wtf.ShortDuration(time.Since(lastSuccess)) + " ago"
+		}
+
+		report := fmt.Sprintf("[red]%s[white] (%s)\n", widget.Name(), lastUpdated)
+		for idx, cause := range errorChain(err) {
+			report += fmt.Sprintf("  %d. %s\n", idx+1, cause.Error())
+		}
+
+		reports = append(reports, report)
+	}
+
+	if len(reports) == 0 {
+		return "No errors"
+	}
+
+	return strings.Join(reports, "\n")
+}
+
+// errorChain returns the error followed by every error that it wraps
+func errorChain(err error) []error {
+	chain := []error{}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		chain = append(chain, err)
+	}
+
+	return chain
+}
+
+// showErrors displays a modal dialog with the errors of the focused widget, or of every
+// widget if none has focus
+func (wtfApp *WtfApp) showErrors() {
+	widgets := wtfApp.widgets
+	if focused := wtfApp.focusTracker.FocusedWidget(); focused != nil {
+		widgets = []wtf.Wtfable{focused}
+	}
+
+	closeFunc := func() {
+		wtfApp.pages.RemovePage(errorsPageName)
+		wtfApp.restoreFocus()
+	}
+
+	modal := view.NewBillboardModal(errorReport(widgets), closeFunc)
+
+	wtfApp.pages.AddPage(errorsPageName, modal, false, true)
+	wtfApp.TViewApp.SetFocus(modal)
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/view"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func newReportWidget(name string, err error) *testWidget {
+	widget := &testWidget{
+		TextWidget: view.NewTextWidget(nil, make(chan bool), nil, &cfg.Common{Module: cfg.Module{Name: name}}),
+	}
+	widget.SetError(err)
+
+	return widget
+}
+
+func Test_errorChain(t *testing.T) {
+	inner := errors.New("connection refused")
+	outer := fmt.Errorf("fetching checks: %w", inner)
+
+	assert.Equal(t, []error{outer, inner}, errorChain(outer))
+	assert.Equal(t, []error{}, errorChain(nil))
+}
+
+func Test_errorReport(t *testing.T) {
+	failing := newReportWidget("github", fmt.Errorf("fetching issues: %w", errors.New("timeout")))
+	healthy := newReportWidget("clocks", nil)
+
+	assert.Equal(t, "No errors", errorReport([]wtf.Wtfable{healthy}))
+	assert.Equal(
+		t,
+		"[red]github[white] (never updated)\n  1. fetching issues: timeout\n  2. timeout\n",
+		errorReport([]wtf.Wtfable{healthy, failing}),
+	)
+}
@@ -74,6 +74,42 @@ func (tracker *FocusTracker) FocusOn(char string) bool {
 	return hasFocusable
 }
 
+// FocusOnName puts the focus on the focusable widget with the given name
+func (tracker *FocusTracker) FocusOnName(name string) bool {
+	for idx, focusable := range tracker.focusables() {
+		if focusable.Name() == name {
+			tracker.blur(tracker.Idx)
+			tracker.Idx = idx
+			tracker.focus(tracker.Idx)
+
+			tracker.IsFocused = true
+			return true
+		}
+	}
+
+	return false
+}
+
+// FocusedName returns the name of the widget that currently has focus, or an empty
+// string if no widget has focus
+func (tracker *FocusTracker) FocusedName() string {
+	widget := tracker.FocusedWidget()
+	if widget == nil {
+		return ""
+	}
+
+	return widget.Name()
+}
+
+// FocusedWidget returns the widget that currently has focus, or nil if no widget has focus
+func (tracker *FocusTracker) FocusedWidget() wtf.Wtfable {
+	if !tracker.IsFocused {
+		return nil
+	}
+
+	return tracker.focusableAt(tracker.Idx)
+}
+
 // Next sets the focus on the next widget in the widget list. If the current widget is
 // the last widget, sets focus on the first widget.
 func (tracker *FocusTracker) Next() {
@@ -3,15 +3,27 @@ package app
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/logrusorgru/aurora"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/view"
 	"github.com/wtfutil/wtf/wtf"
 )
 
 // ModuleValidator is responsible for validating the state of a module's configuration
 type ModuleValidator struct{}
 
+// globalKeys are the keys that WtfApp.keyboardIntercept and WtfAppManager.keyboardIntercept
+// handle before the focused widget sees them, so widgets' commands can't be moved onto them
+var globalKeys = []string{"Backtab", "Ctrl-C", "Ctrl-E", "Ctrl-P", "Ctrl-R", "Ctrl-Space", "Ctrl-T", "Ctrl-Z", "Esc", "Tab"}
+
+// keyRemappable is implemented by widgets whose keyboard commands can be remapped
+type keyRemappable interface {
+	KeyBindingErrors() []view.KeyBindingError
+	KeyRemappings() []view.KeyRemapping
+}
+
 type widgetError struct {
 	name             string
 	validationErrors []cfg.Validatable
@@ -26,20 +38,81 @@ func NewModuleValidator() *ModuleValidator {
 // If it finds any it stringifies them, writes them to the console, and kills the app gracefully
 func (val *ModuleValidator) Validate(widgets []wtf.Wtfable) {
 	validationErrors := validate(widgets)
+	keyErrors := val.KeyErrors(widgets)
 
-	if len(validationErrors) > 0 {
+	if len(validationErrors) > 0 || len(keyErrors) > 0 {
 		fmt.Println()
 		for _, error := range validationErrors {
 			for _, message := range error.errorMessages() {
 				fmt.Println(message)
 			}
 		}
+
+		for _, widget := range widgets {
+			for _, message := range keyErrorMessages(widget.Name(), keyErrors[widget.Name()]) {
+				fmt.Println(message)
+			}
+		}
 		fmt.Println()
 
 		os.Exit(1)
 	}
 }
 
+// Errors rolls through all the enabled widgets and returns the configuration errors
+// found in each, keyed by widget name. Unlike Validate, it does not kill the app
+func (val *ModuleValidator) Errors(widgets []wtf.Wtfable) map[string][]cfg.Validatable {
+	errors := map[string][]cfg.Validatable{}
+
+	for _, err := range validate(widgets) {
+		errors[err.name] = err.validationErrors
+	}
+
+	return errors
+}
+
+// KeyErrors rolls through all the enabled widgets and returns the `keys` remappings that
+// could not be applied, or that move commands onto keys the app handles itself, keyed
+// by widget name
+func (val *ModuleValidator) KeyErrors(widgets []wtf.Wtfable) map[string][]view.KeyBindingError {
+	errors := map[string][]view.KeyBindingError{}
+
+	reserved := map[string]bool{}
+	for _, key := range globalKeys {
+		reserved[strings.ToLower(key)] = true
+	}
+	for _, widget := range widgets {
+		if widget.FocusChar() != "" {
+			reserved[widget.FocusChar()] = true
+		}
+	}
+
+	for _, widget := range widgets {
+		remappable, ok := widget.(keyRemappable)
+		if !ok {
+			continue
+		}
+
+		widgetErrors := remappable.KeyBindingErrors()
+
+		for _, remapping := range remappable.KeyRemappings() {
+			if reserved[strings.ToLower(remapping.To)] {
+				widgetErrors = append(widgetErrors, view.KeyBindingError{
+					From:   remapping.From,
+					To:     remapping.To,
+					Reason: "the key is reserved by wtfutil",
+				})
+			}
+		}
+
+		if len(widgetErrors) > 0 {
+			errors[widget.Name()] = widgetErrors
+		}
+	}
+
+	return errors
+}
+
 func validate(widgets []wtf.Wtfable) (widgetErrors []widgetError) {
 	for _, widget := range widgets {
 		err := widgetError{name: widget.Name()}
@@ -58,6 +131,30 @@ func validate(widgets []wtf.Wtfable) (widgetErrors []widgetError) {
 	return widgetErrors
 }
 
+func keyErrorMessages(name string, keyErrors []view.KeyBindingError) (messages []string) {
+	if len(keyErrors) == 0 {
+		return messages
+	}
+
+	widgetMessage := fmt.Sprintf(
+		"%s in %s configuration",
+		aurora.Red("Errors"),
+		aurora.Yellow(
+			fmt.Sprintf(
+				"%s.keys",
+				name,
+			),
+		),
+	)
+	messages = append(messages, widgetMessage)
+
+	for _, e := range keyErrors {
+		messages = append(messages, fmt.Sprintf(" - %s\t%s %v", e.From, aurora.Red("Error:"), e.Error()))
+	}
+
+	return messages
+}
+
 func (err widgetError) errorMessages() (messages []string) {
 	widgetMessage := fmt.Sprintf(
 		"%s in %s configuration",
@@ -7,6 +7,8 @@ import (
 	"github.com/logrusorgru/aurora"
 	"github.com/olebedev/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/view"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -100,3 +102,70 @@ func Test_validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_KeyErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     map[string]string
+		expected map[string][]view.KeyBindingError
+	}{
+		{
+			name:     "with no remappings",
+			keys:     map[string]string{},
+			expected: map[string][]view.KeyBindingError{},
+		},
+		{
+			name:     "with a remapping onto a free key",
+			keys:     map[string]string{"r": "x"},
+			expected: map[string][]view.KeyBindingError{},
+		},
+		{
+			name: "with a remapping onto a global key",
+			keys: map[string]string{"r": "ctrl-p"},
+			expected: map[string][]view.KeyBindingError{
+				"github": {{From: "r", To: "ctrl-p", Reason: "the key is reserved by wtfutil"}},
+			},
+		},
+		{
+			name: "with a remapping onto Esc",
+			keys: map[string]string{"r": "esc"},
+			expected: map[string][]view.KeyBindingError{
+				"github": {{From: "r", To: "esc", Reason: "the key is reserved by wtfutil"}},
+			},
+		},
+		{
+			name: "with a remapping onto a focus char",
+			keys: map[string]string{"r": "1"},
+			expected: map[string][]view.KeyBindingError{
+				"github": {{From: "r", To: "1", Reason: "the key is reserved by wtfutil"}},
+			},
+		},
+		{
+			name: "with a remapping onto another command",
+			keys: map[string]string{"r": "j"},
+			expected: map[string][]view.KeyBindingError{
+				"github": {{From: "r", To: "j", Reason: "the key is already bound to another command"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			common := &cfg.Common{
+				Module:    cfg.Module{Name: "github", Type: "github"},
+				Enabled:   true,
+				Focusable: true,
+				Keys:      tt.keys,
+			}
+
+			widget := &testWidget{
+				TextWidget: view.NewTextWidget(nil, make(chan bool), nil, common),
+			}
+			widget.SetFocusChar("1")
+			widget.SetKeyboardChar("j", func() {}, "Select next item")
+			widget.SetKeyboardChar("r", func() {}, "Refresh widget")
+
+			assert.Equal(t, tt.expected, NewModuleValidator().KeyErrors([]wtf.Wtfable{widget}))
+		})
+	}
+}
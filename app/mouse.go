@@ -0,0 +1,94 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// mouseHandler is implemented by widgets that respond to clicks and the mouse wheel
+// themselves, such as selecting the row that was clicked on
+type mouseHandler interface {
+	HandleMouse(action tview.MouseAction, event *tcell.EventMouse) bool
+}
+
+// mouseEnabled returns TRUE if the mouse is turned on in the configuration with:
+//
+//	wtf:
+//	  mouse: true
+func mouseEnabled(config *config.Config) bool {
+	return config.UBool("wtf.mouse", false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// mouseIntercept handles mouse events on the dashboard's widgets. Clicking a widget
+// focuses it, double-clicking it does what its Enter key does, and the wheel scrolls it
+// or moves its selection. Mouse events in dialogs, i.e.: the help or the command palette,
+// are left to tview
+func (wtfApp *WtfApp) mouseIntercept(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+	if page, _ := wtfApp.pages.GetFrontPage(); page != gridPageName && page != zoomPageName {
+		return event, action
+	}
+
+	widget := wtfApp.widgetAt(event.Position())
+	if widget == nil {
+		return event, action
+	}
+
+	switch action {
+	case tview.MouseLeftClick:
+		if !widget.Focusable() {
+			return nil, action
+		}
+
+		if wtfApp.focusTracker.FocusedName() != widget.Name() {
+			wtfApp.focusTracker.FocusOnName(widget.Name())
+		}
+
+		if handler, ok := widget.(mouseHandler); ok {
+			handler.HandleMouse(action, event)
+		}
+
+		return nil, action
+	case tview.MouseLeftDoubleClick:
+		if wtfApp.focusTracker.FocusedName() != widget.Name() {
+			return nil, action
+		}
+
+		if capture := widget.TextView().GetInputCapture(); capture != nil {
+			capture(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+		}
+
+		return nil, action
+	case tview.MouseScrollUp, tview.MouseScrollDown:
+		if handler, ok := widget.(mouseHandler); ok && handler.HandleMouse(action, event) {
+			return nil, action
+		}
+	case tview.MouseLeftDown, tview.MouseLeftUp:
+		// Keep tview from focusing the widget's view without the focus tracker knowing
+		return nil, action
+	}
+
+	return event, action
+}
+
+// widgetAt returns the widget displayed at the screen position, or nil if there isn't one
+func (wtfApp *WtfApp) widgetAt(x, y int) wtf.Wtfable {
+	if wtfApp.zoomed != nil {
+		if wtfApp.zoomed.TextView().InRect(x, y) {
+			return wtfApp.zoomed
+		}
+
+		return nil
+	}
+
+	for _, widget := range wtfApp.widgets {
+		if !widget.Disabled() && widget.TextView().InRect(x, y) {
+			return widget
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func testMouseApp() (*WtfApp, *testWidget, *testWidget) {
+	github := newPaletteWidget("github", "GitHub")
+	github.TextView().SetRect(0, 0, 10, 10)
+
+	jira := newPaletteWidget("jira", "Jira")
+	jira.TextView().SetRect(10, 0, 10, 10)
+
+	tviewApp := tview.NewApplication()
+	widgets := []wtf.Wtfable{github, jira}
+
+	wtfApp := &WtfApp{
+		TViewApp:     tviewApp,
+		focusTracker: NewFocusTracker(tviewApp, widgets, &config.Config{Root: map[string]interface{}{}}),
+		pages:        tview.NewPages(),
+		widgets:      widgets,
+	}
+	wtfApp.pages.AddPage(gridPageName, tview.NewGrid(), true, true)
+
+	return wtfApp, github, jira
+}
+
+func Test_widgetAt(t *testing.T) {
+	wtfApp, github, jira := testMouseApp()
+
+	assert.Equal(t, wtf.Wtfable(github), wtfApp.widgetAt(3, 3))
+	assert.Equal(t, wtf.Wtfable(jira), wtfApp.widgetAt(12, 3))
+	assert.Nil(t, wtfApp.widgetAt(30, 3))
+
+	wtfApp.zoomed = jira
+	assert.Nil(t, wtfApp.widgetAt(3, 3))
+}
+
+func Test_mouseIntercept(t *testing.T) {
+	wtfApp, _, jira := testMouseApp()
+
+	opened := false
+	jira.SetKeyboardKey(tcell.KeyEnter, func() { opened = true }, "Open")
+
+	click := tcell.NewEventMouse(12, 3, tcell.Button1, tcell.ModNone)
+
+	// Double-clicking a widget that doesn't have focus does nothing
+	event, _ := wtfApp.mouseIntercept(click, tview.MouseLeftDoubleClick)
+	assert.Nil(t, event)
+	assert.False(t, opened)
+
+	event, _ = wtfApp.mouseIntercept(click, tview.MouseLeftClick)
+	assert.Nil(t, event)
+	assert.Equal(t, "jira", wtfApp.focusTracker.FocusedName())
+
+	_, _ = wtfApp.mouseIntercept(click, tview.MouseLeftDoubleClick)
+	assert.True(t, opened)
+
+	// Clicks outside the widgets, and in dialogs, are left to tview
+	outside := tcell.NewEventMouse(30, 3, tcell.Button1, tcell.ModNone)
+	event, _ = wtfApp.mouseIntercept(outside, tview.MouseLeftClick)
+	assert.Equal(t, outside, event)
+
+	wtfApp.pages.AddPage("help", tview.NewBox(), true, true)
+	event, _ = wtfApp.mouseIntercept(click, tview.MouseLeftClick)
+	assert.Equal(t, click, event)
+}
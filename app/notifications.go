@@ -0,0 +1,62 @@
+package app
+
+import (
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const (
+	flashCount    = 3
+	flashInterval = 300 * time.Millisecond
+)
+
+// beep rings the terminal bell for a notification. It rings through the screen, so that
+// it isn't written into the middle of what's being drawn
+func (appMan *WtfAppManager) beep() {
+	go appMan.TViewApp.QueueUpdate(func() {
+		if appMan.screen != nil {
+			_ = appMan.screen.Beep()
+		}
+	})
+}
+
+// flash draws attention to a notification by flashing the border of the named widget,
+// if it's on the dashboard that is displayed, between the error color and its own color
+func (appMan *WtfAppManager) flash(moduleName string) {
+	go func() {
+		for idx := 0; idx < flashCount*2; idx++ {
+			lit := idx%2 == 0
+
+			appMan.TViewApp.QueueUpdateDraw(func() {
+				wtfApp, err := appMan.Current()
+				if err != nil {
+					return
+				}
+
+				for _, widget := range wtfApp.widgets {
+					if widget.Name() == moduleName {
+						widget.TextView().SetBorderColor(wtf.ColorFor(flashColor(widget, lit)))
+					}
+				}
+			})
+
+			time.Sleep(flashInterval)
+		}
+	}()
+}
+
+// flashColor returns the border color of a flashing widget, which goes back to the color
+// it would normally have when the flash is over
+func flashColor(widget wtf.Wtfable, lit bool) string {
+	colors := widget.CommonSettings().Colors.BorderTheme
+
+	switch {
+	case lit:
+		return colors.Error
+	case widget.TextView().HasFocus():
+		return colors.Focused
+	default:
+		return widget.BorderColor()
+	}
+}
@@ -1,38 +1,232 @@
 package app
 
 import (
+	"math"
+	"math/rand"
+	"sync"
 	"time"
 
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-// Schedule kicks off the first refresh of a module's data and then queues the rest of the
-// data refreshes on a timer
-func Schedule(widget wtf.Wtfable) {
+const (
+	defaultMaxBackoff    = "10m"
+	defaultMaxConcurrent = 8
+	defaultStagger       = "2s"
+)
+
+// Scheduler refreshes a collection of widgets on their refresh intervals. It spreads the
+// first refreshes out over a short period instead of making them all at once, adds
+// random jitter to every interval, backs off exponentially from widgets whose refreshes
+// are failing, and limits how many refreshes can run at the same time.
+//
+// It is configured by the optional `wtf.refresh` settings:
+//
+//	wtf:
+//	  refresh:
+//	    jitter: 0.1          # fraction of the refresh interval to randomly add or remove
+//	    maxBackoff: 10m      # longest delay between the refreshes of a failing widget
+//	    maxConcurrent: 8     # how many widgets can refresh at the same time
+//	    stagger: 2s          # the period over which the first refreshes are spread
+type Scheduler struct {
+	jitter     float64
+	maxBackoff time.Duration
+	stagger    time.Duration
+
+	// limiter is a semaphore that caps the number of concurrent refreshes. It is
+	// shared between the schedulers of all the dashboards
+	limiter chan struct{}
+
+	// resumeChan is non-nil while the scheduler is paused, and is closed to resume it
+	resumeChan  chan struct{}
+	resumeMutex *sync.Mutex
+}
+
+// NewScheduler creates and returns an instance of Scheduler configured from the
+// `wtf.refresh` settings
+func NewScheduler(config *config.Config) *Scheduler {
+	scheduler := &Scheduler{
+		jitter:     config.UFloat64("wtf.refresh.jitter", 0),
+		maxBackoff: cfg.ParseTimeString(config, "wtf.refresh.maxBackoff", defaultMaxBackoff),
+		stagger:    cfg.ParseTimeString(config, "wtf.refresh.stagger", defaultStagger),
+
+		limiter:     newRefreshLimiter(config),
+		resumeMutex: &sync.Mutex{},
+	}
+
+	return scheduler
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Pause stops the scheduler from refreshing its widgets until Resume is called. Widgets
+// that come due while the scheduler is paused are refreshed as soon as it resumes
+func (scheduler *Scheduler) Pause() {
+	scheduler.resumeMutex.Lock()
+	defer scheduler.resumeMutex.Unlock()
+
+	if scheduler.resumeChan == nil {
+		scheduler.resumeChan = make(chan struct{})
+	}
+}
+
+// Paused returns TRUE if the scheduler is paused, FALSE if it is not
+func (scheduler *Scheduler) Paused() bool {
+	scheduler.resumeMutex.Lock()
+	defer scheduler.resumeMutex.Unlock()
+
+	return scheduler.resumeChan != nil
+}
+
+// Refresh refreshes the widget immediately, waiting for a free refresh slot if the
+// maximum number of concurrent refreshes are already running
+func (scheduler *Scheduler) Refresh(widget wtf.Wtfable) {
+	scheduler.limiter <- struct{}{}
+	defer func() { <-scheduler.limiter }()
+
 	widget.Refresh()
+}
 
-	interval := widget.CommonSettings().RefreshInterval
+// Resume restarts a paused scheduler
+func (scheduler *Scheduler) Resume() {
+	scheduler.resumeMutex.Lock()
+	defer scheduler.resumeMutex.Unlock()
 
-	if interval <= 0 {
-		return
+	if scheduler.resumeChan != nil {
+		close(scheduler.resumeChan)
+		scheduler.resumeChan = nil
 	}
+}
 
-	timer := time.NewTicker(interval)
+// Schedule kicks off the first refresh of a module's data after the initial delay and
+// then queues the rest of the data refreshes on a timer. It returns when the widget is
+// stopped or disabled.
+//
+// A widget whose refreshes keep failing is backed off, but only the failures its module
+// reports with SetError count. A module that doesn't report them is refreshed on its
+// interval whether its refreshes succeed or not
+func (scheduler *Scheduler) Schedule(widget wtf.Wtfable, initialDelay time.Duration) {
+	delay := initialDelay
+	failures := 0
 
 	for {
+		if !scheduler.wait(widget, delay) {
+			return
+		}
+
+		scheduler.Refresh(widget)
+
+		interval := widget.CommonSettings().RefreshInterval
+		if interval <= 0 {
+			return
+		}
+
+		if widget.LastError() != nil {
+			failures++
+		} else {
+			failures = 0
+		}
+
+		delay = scheduler.jittered(scheduler.backoff(interval, failures))
+	}
+}
+
+// ScheduleAll schedules every widget, spreading their first refreshes evenly over the
+// stagger period
+func (scheduler *Scheduler) ScheduleAll(widgets []wtf.Wtfable) {
+	for idx, widget := range widgets {
+		go scheduler.Schedule(widget, scheduler.initialDelay(idx, len(widgets)))
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// backoff returns how long to wait before the next refresh of a widget that has failed
+// to refresh the given number of times in a row. The delay doubles with each failure, up
+// to the maximum backoff, but is never shorter than the widget's refresh interval
+func (scheduler *Scheduler) backoff(interval time.Duration, failures int) time.Duration {
+	if failures <= 0 {
+		return interval
+	}
+
+	delay := float64(interval) * math.Pow(2, float64(failures))
+	if delay > float64(scheduler.maxBackoff) {
+		delay = float64(scheduler.maxBackoff)
+	}
+
+	if time.Duration(delay) < interval {
+		return interval
+	}
+
+	return time.Duration(delay)
+}
+
+// initialDelay returns how long the widget at the given position should wait before
+// its first refresh
+func (scheduler *Scheduler) initialDelay(idx, count int) time.Duration {
+	if count <= 1 || scheduler.stagger <= 0 {
+		return 0
+	}
+
+	return scheduler.stagger * time.Duration(idx) / time.Duration(count)
+}
+
+// jittered randomly lengthens or shortens the delay by up to the jitter fraction
+func (scheduler *Scheduler) jittered(delay time.Duration) time.Duration {
+	if scheduler.jitter <= 0 {
+		return delay
+	}
+
+	// #nosec G404 -- the jitter does not need to be cryptographically random
+	offset := (rand.Float64()*2 - 1) * scheduler.jitter * float64(delay)
+
+	return delay + time.Duration(offset)
+}
+
+// resumed returns a channel that is closed when the scheduler is resumed, or nil if
+// the scheduler is not paused
+func (scheduler *Scheduler) resumed() chan struct{} {
+	scheduler.resumeMutex.Lock()
+	defer scheduler.resumeMutex.Unlock()
+
+	return scheduler.resumeChan
+}
+
+// wait waits out the delay, and then for the scheduler to be resumed if it is paused.
+// It returns FALSE if the widget was stopped or disabled while waiting
+func (scheduler *Scheduler) wait(widget wtf.Wtfable, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case quit := <-widget.QuitChan():
+		if quit {
+			return false
+		}
+	}
+
+	if resumeChan := scheduler.resumed(); resumeChan != nil {
 		select {
-		case <-timer.C:
-			if widget.Enabled() {
-				widget.Refresh()
-			} else {
-				timer.Stop()
-				return
-			}
+		case <-resumeChan:
 		case quit := <-widget.QuitChan():
 			if quit {
-				timer.Stop()
-				return
+				return false
 			}
 		}
 	}
+
+	return widget.Enabled()
+}
+
+// newRefreshLimiter creates the semaphore that caps the number of concurrent refreshes
+func newRefreshLimiter(config *config.Config) chan struct{} {
+	maxConcurrent := config.UInt("wtf.refresh.maxConcurrent", defaultMaxConcurrent)
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrent
+	}
+
+	return make(chan struct{}, maxConcurrent)
 }
@@ -1,10 +1,16 @@
 package app
 
 import (
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/view"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 const (
@@ -68,7 +74,7 @@ func Test_RefreshInterval(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			widget := MakeWidget(nil, nil, tt.moduleName, tt.config, make(chan bool))
 
-			interval := widget.CommonSettings().RefreshInterval // same declaration as in scheduler.go#Schedule
+			interval := widget.CommonSettings().RefreshInterval // same declaration as in scheduler.go#Scheduler.Schedule
 			timer := time.NewTicker(interval)
 
 			attempts := 0
@@ -88,3 +94,219 @@ func Test_RefreshInterval(t *testing.T) {
 		})
 	}
 }
+
+/* -------------------- Scheduler -------------------- */
+
+type testWidget struct {
+	view.TextWidget
+
+	refreshes int32
+	running   *int32
+	maxSeen   *int32
+	duration  time.Duration
+}
+
+func newTestWidget(interval time.Duration) *testWidget {
+	common := &cfg.Common{
+		Module:          cfg.Module{Name: "test widget"},
+		Enabled:         true,
+		RefreshInterval: interval,
+	}
+
+	return &testWidget{
+		TextWidget: view.NewTextWidget(nil, make(chan bool), nil, common),
+	}
+}
+
+func (widget *testWidget) Refresh() {
+	atomic.AddInt32(&widget.refreshes, 1)
+
+	if widget.running != nil {
+		running := atomic.AddInt32(widget.running, 1)
+		for {
+			maxSeen := atomic.LoadInt32(widget.maxSeen)
+			if running <= maxSeen || atomic.CompareAndSwapInt32(widget.maxSeen, maxSeen, running) {
+				break
+			}
+		}
+
+		time.Sleep(widget.duration)
+		atomic.AddInt32(widget.running, -1)
+	}
+}
+
+func (widget *testWidget) refreshCount() int {
+	return int(atomic.LoadInt32(&widget.refreshes))
+}
+
+func testScheduler(yaml string) *Scheduler {
+	ymlConfig, _ := config.ParseYaml(yaml)
+	return NewScheduler(ymlConfig)
+}
+
+func Test_NewScheduler(t *testing.T) {
+	scheduler := testScheduler(`
+wtf:
+  refresh:
+    jitter: 0.25
+    maxBackoff: 1m
+    maxConcurrent: 3
+    stagger: 5`)
+
+	assert.Equal(t, 0.25, scheduler.jitter)
+	assert.Equal(t, time.Minute, scheduler.maxBackoff)
+	assert.Equal(t, 5*time.Second, scheduler.stagger)
+	assert.Equal(t, 3, cap(scheduler.limiter))
+
+	defaults := testScheduler("wtf:\n  refreshInterval: 1")
+
+	assert.Equal(t, 0.0, defaults.jitter)
+	assert.Equal(t, 10*time.Minute, defaults.maxBackoff)
+	assert.Equal(t, 2*time.Second, defaults.stagger)
+	assert.Equal(t, defaultMaxConcurrent, cap(defaults.limiter))
+}
+
+func Test_Scheduler_backoff(t *testing.T) {
+	scheduler := testScheduler("wtf:\n  refresh:\n    maxBackoff: 1m")
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		failures int
+		expected time.Duration
+	}{
+		{name: "no failures", interval: 5 * time.Second, failures: 0, expected: 5 * time.Second},
+		{name: "one failure", interval: 5 * time.Second, failures: 1, expected: 10 * time.Second},
+		{name: "three failures", interval: 5 * time.Second, failures: 3, expected: 40 * time.Second},
+		{name: "capped", interval: 5 * time.Second, failures: 10, expected: time.Minute},
+		{name: "interval longer than cap", interval: time.Hour, failures: 2, expected: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, scheduler.backoff(tt.interval, tt.failures))
+		})
+	}
+}
+
+func Test_Scheduler_initialDelay(t *testing.T) {
+	scheduler := testScheduler("wtf:\n  refresh:\n    stagger: 4s")
+
+	assert.Equal(t, time.Duration(0), scheduler.initialDelay(0, 4))
+	assert.Equal(t, time.Second, scheduler.initialDelay(1, 4))
+	assert.Equal(t, 3*time.Second, scheduler.initialDelay(3, 4))
+	assert.Equal(t, time.Duration(0), scheduler.initialDelay(0, 1))
+}
+
+func Test_Scheduler_jittered(t *testing.T) {
+	scheduler := testScheduler("wtf:\n  refresh:\n    jitter: 0.2")
+
+	for i := 0; i < 100; i++ {
+		delay := scheduler.jittered(10 * time.Second)
+
+		assert.GreaterOrEqual(t, delay, 8*time.Second)
+		assert.LessOrEqual(t, delay, 12*time.Second)
+	}
+
+	noJitter := testScheduler("wtf:\n  refreshInterval: 1")
+	assert.Equal(t, 10*time.Second, noJitter.jittered(10*time.Second))
+}
+
+func Test_Scheduler_Schedule(t *testing.T) {
+	scheduler := testScheduler("wtf:\n  refresh:\n    stagger: 0")
+
+	widget := newTestWidget(10 * time.Millisecond)
+	done := make(chan bool)
+
+	go func() {
+		scheduler.Schedule(widget, 0)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return widget.refreshCount() >= 3 }, time.Second, time.Millisecond)
+
+	widget.Stop()
+	<-done
+}
+
+func Test_Scheduler_ScheduleWithoutInterval(t *testing.T) {
+	scheduler := testScheduler("wtf:\n  refresh:\n    stagger: 0")
+	widget := newTestWidget(0)
+
+	scheduler.Schedule(widget, 0)
+
+	assert.Equal(t, 1, widget.refreshCount())
+}
+
+func Test_Scheduler_BacksOffFailingWidgets(t *testing.T) {
+	scheduler := testScheduler("wtf:\n  refresh:\n    maxBackoff: 1h")
+
+	healthy := newTestWidget(20 * time.Millisecond)
+	failing := newTestWidget(20 * time.Millisecond)
+	failing.SetError(errors.New("busted"))
+
+	// This one stands in for a module that fails without reporting it with SetError
+	unreported := newTestWidget(20 * time.Millisecond)
+
+	go scheduler.Schedule(healthy, 0)
+	go scheduler.Schedule(failing, 0)
+	go scheduler.Schedule(unreported, 0)
+
+	time.Sleep(300 * time.Millisecond)
+	healthy.Stop()
+	failing.Stop()
+	unreported.Stop()
+
+	// The failing widget refreshes at 0, 40, 120 and 280ms, the others every 20ms
+	assert.Greater(t, healthy.refreshCount(), 8)
+	assert.LessOrEqual(t, failing.refreshCount(), 4)
+	assert.Greater(t, unreported.refreshCount(), 8)
+}
+
+func Test_Scheduler_LimitsConcurrentRefreshes(t *testing.T) {
+	scheduler := testScheduler("wtf:\n  refresh:\n    maxConcurrent: 2\n    stagger: 0")
+
+	var running, maxSeen int32
+	widgets := []wtf.Wtfable{}
+
+	for i := 0; i < 6; i++ {
+		widget := newTestWidget(0)
+		widget.running = &running
+		widget.maxSeen = &maxSeen
+		widget.duration = 20 * time.Millisecond
+
+		widgets = append(widgets, widget)
+	}
+
+	scheduler.ScheduleAll(widgets)
+
+	assert.Eventually(t, func() bool {
+		total := 0
+		for _, widget := range widgets {
+			total += widget.(*testWidget).refreshCount()
+		}
+		return total == 6 && atomic.LoadInt32(&running) == 0
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxSeen))
+}
+
+func Test_Scheduler_PauseAndResume(t *testing.T) {
+	scheduler := testScheduler("wtf:\n  refresh:\n    stagger: 0")
+	scheduler.Pause()
+
+	assert.True(t, scheduler.Paused())
+
+	widget := newTestWidget(10 * time.Millisecond)
+	go scheduler.Schedule(widget, 0)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, widget.refreshCount())
+
+	scheduler.Resume()
+
+	assert.False(t, scheduler.Paused())
+	assert.Eventually(t, func() bool { return widget.refreshCount() >= 1 }, time.Second, time.Millisecond)
+
+	widget.Stop()
+}
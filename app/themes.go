@@ -0,0 +1,108 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// colorApplier is implemented by widgets that can re-color their views when the theme
+// changes
+type colorApplier interface {
+	ApplyColors()
+}
+
+// renderedRedrawer is implemented by widgets that can draw their last content again
+// without fetching it
+type renderedRedrawer interface {
+	RedrawRendered()
+}
+
+// noColorScreen is the screen wtfutil draws on when NO_COLOR is set. Modules color their
+// content with inline color tags that the monochrome theme doesn't reach, so colors are
+// removed as each cell is drawn. Cells with a background color are drawn in reverse video
+// instead, so that selected rows stay visible
+type noColorScreen struct {
+	tcell.Screen
+}
+
+// SetContent draws the cell in the terminal's default colors
+func (screen *noColorScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	_, bg, _ := style.Decompose()
+
+	style = style.Foreground(tcell.ColorDefault).Background(tcell.ColorDefault)
+	if bg != tcell.ColorDefault {
+		style = style.Reverse(true)
+	}
+
+	screen.Screen.SetContent(x, y, mainc, combc, style)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// newNoColorScreen creates and initializes a screen that draws without colors
+func newNoColorScreen() (tcell.Screen, error) {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := screen.Init(); err != nil {
+		return nil, err
+	}
+
+	return &noColorScreen{Screen: screen}, nil
+}
+
+// cycleTheme switches every dashboard to the next theme. The theme can't be changed when
+// NO_COLOR is set. It's called on the UI goroutine
+func (appMan *WtfAppManager) cycleTheme() {
+	if cfg.NoColor() {
+		return
+	}
+
+	current, err := appMan.Current()
+	if err != nil {
+		return
+	}
+
+	cfg.SetTheme(nextTheme(cfg.ThemeNames(), cfg.ThemeName(current.config)))
+
+	for _, wtfApp := range appMan.WtfApps {
+		wtfApp.applyTheme()
+	}
+}
+
+// applyTheme re-colors every widget, and the dashboard behind them, with the current
+// theme. Widgets are drawn again from their last content rather than refreshed, so no
+// data is fetched. Colors that modules put in their content change when they next refresh
+func (wtfApp *WtfApp) applyTheme() {
+	for _, widget := range wtfApp.widgets {
+		settings := widget.CommonSettings()
+		settings.Colors = cfg.NewColorThemeFromYAML(settings.Config, wtfApp.config)
+
+		if applier, ok := widget.(colorApplier); ok {
+			applier.ApplyColors()
+		}
+
+		if redrawer, ok := widget.(renderedRedrawer); ok {
+			redrawer.RedrawRendered()
+		}
+	}
+
+	background := wtf.ColorFor(wtfApp.widgets[0].CommonSettings().Colors.WidgetTheme.Background)
+
+	wtfApp.pages.Box.SetBackgroundColor(background)
+	wtfApp.display.Grid.SetBackgroundColor(background)
+}
+
+// nextTheme returns the theme after the current one, wrapping around to the first
+func nextTheme(names []string, current string) string {
+	for idx, name := range names {
+		if name == current {
+			return names[(idx+1)%len(names)]
+		}
+	}
+
+	return names[0]
+}
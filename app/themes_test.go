@@ -0,0 +1,63 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func Test_nextTheme(t *testing.T) {
+	names := []string{"default", "light", "monochrome"}
+
+	assert.Equal(t, "light", nextTheme(names, "default"))
+	assert.Equal(t, "default", nextTheme(names, "monochrome"))
+	assert.Equal(t, "default", nextTheme(names, "deleted"))
+}
+
+func Test_noColorScreen(t *testing.T) {
+	simulation := tcell.NewSimulationScreen("")
+	assert.NoError(t, simulation.Init())
+
+	screen := &noColorScreen{Screen: simulation}
+
+	screen.SetContent(0, 0, 'a', nil, tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true))
+	screen.SetContent(1, 0, 'b', nil, tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorGreen))
+
+	_, _, style, _ := simulation.GetContent(0, 0)
+	fg, bg, attrs := style.Decompose()
+	assert.Equal(t, tcell.ColorDefault, fg)
+	assert.Equal(t, tcell.ColorDefault, bg)
+	assert.Equal(t, tcell.AttrBold, attrs)
+
+	_, _, style, _ = simulation.GetContent(1, 0)
+	fg, bg, attrs = style.Decompose()
+	assert.Equal(t, tcell.ColorDefault, fg)
+	assert.Equal(t, tcell.ColorDefault, bg)
+	assert.Equal(t, tcell.AttrReverse, attrs)
+}
+
+func Test_applyTheme(t *testing.T) {
+	widget := newPaletteWidget("github", "GitHub")
+	widget.CommonSettings().Config = &config.Config{Root: map[string]interface{}{}}
+	widget.RedrawChan = make(chan bool, 10)
+	widget.Draw(func() (string, string, bool) { return "GitHub", "3 open PRs", false })
+
+	wtfApp := &WtfApp{
+		config:    &config.Config{Root: map[string]interface{}{}},
+		display:   &Display{Grid: tview.NewGrid()},
+		pages:     tview.NewPages(),
+		scheduler: testScheduler(""),
+		widgets:   []wtf.Wtfable{widget},
+	}
+
+	wtfApp.applyTheme()
+
+	// The widget is drawn again from what it last displayed, without fetching its data
+	assert.Equal(t, 0, widget.refreshCount())
+	assert.Equal(t, 1, len(widget.RedrawChan))
+	assert.Equal(t, "3 open PRs", widget.View.GetText(true))
+}
@@ -2,8 +2,8 @@ package app
 
 import (
 	"fmt"
-	"log"
 	"os"
+	"path/filepath"
 	"time"
 
 	_ "github.com/gdamore/tcell/terminfo/extended"
@@ -12,11 +12,18 @@ import (
 	"github.com/radovskyb/watcher"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/support"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
 
+// textInput is implemented by widgets that can take text input, such as a search query.
+// While they are, every key press goes to them instead of the app
+type textInput interface {
+	TakingInput() bool
+}
+
 // WtfApp is the container for a collection of widgets that are all constructed from a single
 // configuration file and displayed together
 type WtfApp struct {
@@ -24,13 +31,22 @@ type WtfApp struct {
 
 	config         *config.Config
 	configFilePath string
+	dashboard      *cfg.Dashboard
 	display        *Display
 	focusTracker   FocusTracker
 	ghUser         *support.GitHubUser
 	pages          *tview.Pages
+	scheduler      *Scheduler
 	validator      *ModuleValidator
 	widgets        []wtf.Wtfable
 
+	// zoomed is the widget that is being shown full screen, if any
+	zoomed wtf.Wtfable
+
+	// exit quits instead of Exit once the app is managed by a WtfAppManager, so that the
+	// widgets of every dashboard are stopped, not only this one's
+	exit func()
+
 	// The redrawChan channel is used to allow modules to signal back to the main loop that
 	// the screen needs to be explicitly redrawn, instead of waiting for tcell to redraw
 	// on a user event, because something has visually changed
@@ -44,6 +60,7 @@ func NewWtfApp(tviewApp *tview.Application, config *config.Config, configFilePat
 
 		config:         config,
 		configFilePath: configFilePath,
+		dashboard:      &cfg.Dashboard{FilePath: configFilePath, Config: config},
 		pages:          tview.NewPages(),
 
 		redrawChan: make(chan bool, 1),
@@ -62,12 +79,13 @@ func NewWtfApp(tviewApp *tview.Application, config *config.Config, configFilePat
 
 	wtfApp.display = NewDisplay(wtfApp.widgets, wtfApp.config)
 	wtfApp.focusTracker = NewFocusTracker(wtfApp.TViewApp, wtfApp.widgets, wtfApp.config)
+	wtfApp.scheduler = NewScheduler(wtfApp.config)
 	wtfApp.validator = NewModuleValidator()
 
 	githubAPIKey := readGitHubAPIKey(wtfApp.config)
 	wtfApp.ghUser = support.NewGitHubUser(githubAPIKey)
 
-	wtfApp.pages.AddPage("grid", wtfApp.display.Grid, true, true)
+	wtfApp.pages.AddPage(gridPageName, wtfApp.display.Grid, true, true)
 
 	wtfApp.validator.Validate(wtfApp.widgets)
 
@@ -78,9 +96,6 @@ func NewWtfApp(tviewApp *tview.Application, config *config.Config, configFilePat
 		),
 	)
 
-	wtfApp.TViewApp.SetInputCapture(wtfApp.keyboardIntercept)
-	wtfApp.TViewApp.SetRoot(wtfApp.pages, true)
-
 	// Create a watcher to handle calls to redraw the screen
 	go handleRedraws(wtfApp.TViewApp, wtfApp.redrawChan)
 
@@ -105,6 +120,11 @@ func handleRedraws(tviewApp *tview.Application, redrawChan chan bool) {
 
 // Exit quits the app
 func (wtfApp *WtfApp) Exit() {
+	if wtfApp.exit != nil {
+		wtfApp.exit()
+		return
+	}
+
 	wtfApp.Stop()
 	wtfApp.TViewApp.Stop()
 	wtfApp.DisplayExitMessage()
@@ -113,6 +133,15 @@ func (wtfApp *WtfApp) Exit() {
 
 // Execute starts the underlying tview app
 func (wtfApp *WtfApp) Execute() error {
+	if cfg.NoColor() {
+		screen, err := newNoColorScreen()
+		if err != nil {
+			return err
+		}
+
+		wtfApp.TViewApp.SetScreen(screen)
+	}
+
 	if err := wtfApp.TViewApp.Run(); err != nil {
 		return err
 	}
@@ -137,6 +166,16 @@ func (wtfApp *WtfApp) Stop() {
 
 /* -------------------- Unexported Functions -------------------- */
 
+// activate puts this app's widgets onscreen, restoring the focus to whichever widget
+// had it when the app was last displayed
+func (wtfApp *WtfApp) activate() {
+	wtfApp.TViewApp.SetRoot(wtfApp.pages, true)
+
+	if wtfApp.focusTracker.IsFocused {
+		wtfApp.focusTracker.Refocus()
+	}
+}
+
 func (wtfApp *WtfApp) stopAllWidgets() {
 	for _, widget := range wtfApp.widgets {
 		widget.Stop()
@@ -146,29 +185,33 @@ func (wtfApp *WtfApp) stopAllWidgets() {
 func (wtfApp *WtfApp) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	// These keys are global keys used by the app. Widgets should not implement these keys
 	switch event.Key() {
-	case tcell.KeyCtrlC:
-		wtfApp.Stop()
-		wtfApp.TViewApp.Stop()
-		wtfApp.DisplayExitMessage()
+	case tcell.KeyCtrlE:
+		wtfApp.showErrors()
+		return nil
+	case tcell.KeyCtrlP:
+		wtfApp.showCommandPalette()
+		return nil
 	case tcell.KeyCtrlR:
 		wtfApp.refreshAllWidgets()
 		return nil
-	case tcell.KeyCtrlSpace:
-		// FIXME: This can't reside in the app, the app doesn't know about
-		// the AppManager. The AppManager needs to catch this one
-		fmt.Println("Next app")
+	case tcell.KeyCtrlZ:
+		wtfApp.toggleZoom()
 		return nil
 	case tcell.KeyTab:
 		wtfApp.focusTracker.Next()
+		wtfApp.unzoom()
 	case tcell.KeyBacktab:
 		wtfApp.focusTracker.Prev()
+		wtfApp.unzoom()
 		return nil
 	case tcell.KeyEsc:
 		wtfApp.focusTracker.None()
+		wtfApp.unzoom()
 	}
 
 	// Checks to see if any widget has been assigned the pressed key as its focus key
 	if wtfApp.focusTracker.FocusOn(string(event.Rune())) {
+		wtfApp.unzoom()
 		return nil
 	}
 
@@ -178,6 +221,7 @@ func (wtfApp *WtfApp) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 		case "q":
 			wtfApp.Exit()
 		case "/":
+			// "/" only opens a focused widget's help. Searching is on Ctrl-F
 			return nil
 		default:
 		}
@@ -186,16 +230,37 @@ func (wtfApp *WtfApp) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	return event
 }
 
+// takingInput returns TRUE while every key press goes to what's being typed into: the
+// command palette's search field, or a widget that's taking text input, such as a search
+// query
+func (wtfApp *WtfApp) takingInput() bool {
+	if wtfApp.pages.HasPage(commandPalettePageName) {
+		return true
+	}
+
+	input, ok := wtfApp.focusTracker.FocusedWidget().(textInput)
+	return ok && input.TakingInput()
+}
+
 func (wtfApp *WtfApp) refreshAllWidgets() {
 	for _, widget := range wtfApp.widgets {
-		go widget.Refresh()
+		go wtfApp.scheduler.Refresh(widget)
 	}
 }
 
-func (wtfApp *WtfApp) scheduleWidgets() {
-	for _, widget := range wtfApp.widgets {
-		go Schedule(widget)
+// restoreFocus gives the focus back to the widget that had it before a modal dialog was
+// opened, or to the dashboard if no widget had it
+func (wtfApp *WtfApp) restoreFocus() {
+	if wtfApp.focusTracker.IsFocused {
+		wtfApp.focusTracker.Refocus()
+		return
 	}
+
+	wtfApp.TViewApp.SetFocus(wtfApp.pages)
+}
+
+func (wtfApp *WtfApp) scheduleWidgets() {
+	wtfApp.scheduler.ScheduleAll(wtfApp.widgets)
 }
 
 func (wtfApp *WtfApp) watchForConfigChanges() {
@@ -204,38 +269,87 @@ func (wtfApp *WtfApp) watchForConfigChanges() {
 	// Notify write events
 	watch.FilterOps(watcher.Write)
 
+	absPath, _ := utils.ExpandHomeDir(wtfApp.configFilePath)
+
+	// The files that are watched, by their absolute paths. Only used by this goroutine
+	// once watching starts
+	watched := map[string]bool{}
+
 	go func() {
 		for {
 			select {
 			case <-watch.Event:
-				wtfApp.Stop()
+				// A change can include or stop including files
+				watchConfigFiles(watch, watched, absPath)
 
-				config := cfg.LoadWtfConfigFile(wtfApp.configFilePath)
-				newApp := NewWtfApp(wtfApp.TViewApp, config, wtfApp.configFilePath)
-				openURLUtil := utils.ToStrs(config.UList("wtf.openUrlUtil", []interface{}{}))
-				utils.Init(config.UString("wtf.openFileUtil", "open"), openURLUtil)
+				// The dashboard is only used by this goroutine, to find its config again
+				config, err := wtfApp.dashboard.Reload()
+				if err != nil {
+					logger.Error("failed to reload the config file, keeping the running config", "path", absPath, "error", err)
+					continue
+				}
+				wtfApp.dashboard.Config = config
 
-				newApp.Start()
+				wtfApp.reload(config)
 			case err := <-watch.Error:
 				if err == watcher.ErrWatchedFileDeleted {
 					// Usually happens because the watcher looks for the file as the OS is updating it
 					continue
 				}
-				log.Fatalln(err)
+				logger.Error("config watcher failed", "error", err)
 			case <-watch.Closed:
 				return
 			}
 		}
 	}()
 
-	// Watch config file for changes.
-	absPath, _ := utils.ExpandHomeDir(wtfApp.configFilePath)
+	// Watch config file, and the files it includes, for changes.
 	if err := watch.Add(absPath); err != nil {
-		log.Fatalln(err)
+		logger.Error("failed to watch the config file, changes won't be reloaded", "path", absPath, "error", err)
+		return
 	}
+	watchConfigFiles(watch, watched, absPath)
 
 	// Start the watching process - it'll check for changes every 100ms.
 	if err := watch.Start(time.Millisecond * 100); err != nil {
-		log.Fatalln(err)
+		logger.Error("failed to watch the config file, changes won't be reloaded", "path", absPath, "error", err)
+	}
+}
+
+// watchConfigFiles has the watcher watch the files that the config file includes, and
+// stop watching the ones it no longer includes. If the config file can't be read, the
+// files that are watched stay as they are
+func watchConfigFiles(watch *watcher.Watcher, watched map[string]bool, configFilePath string) {
+	paths, err := cfg.ConfigFilePaths(configFilePath)
+	if err != nil {
+		return
+	}
+
+	configPath, _ := filepath.Abs(configFilePath)
+	included := map[string]bool{configPath: true}
+
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil || absPath == configPath {
+			continue
+		}
+		included[absPath] = true
+
+		if watched[absPath] {
+			continue
+		}
+
+		if err := watch.Add(absPath); err != nil {
+			logger.Warn("failed to watch an included config file, changes to it won't be reloaded", "path", absPath, "error", err)
+			continue
+		}
+		watched[absPath] = true
+	}
+
+	for path := range watched {
+		if !included[path] {
+			_ = watch.Remove(path)
+			delete(watched, path)
+		}
 	}
 }
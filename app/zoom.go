@@ -0,0 +1,55 @@
+package app
+
+import (
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const (
+	gridPageName = "grid"
+	zoomPageName = "zoom"
+)
+
+// toggleZoom shows the focused widget full screen, or puts the zoomed widget back in
+// its cell in the grid
+func (wtfApp *WtfApp) toggleZoom() {
+	if wtfApp.zoomed != nil {
+		wtfApp.unzoom()
+		return
+	}
+
+	wtfApp.zoom(wtfApp.focusTracker.FocusedWidget())
+}
+
+// zoom takes the widget out of the grid and shows it full screen on its own page. It
+// keeps its view, so its keyboard controls and refreshes carry on working as before
+func (wtfApp *WtfApp) zoom(widget wtf.Wtfable) {
+	if widget == nil {
+		return
+	}
+
+	view := widget.TextView()
+
+	wtfApp.display.remove(widget)
+	wtfApp.pages.AddPage(zoomPageName, view, true, false)
+
+	// Hide the grid, rather than draw over it, so that it doesn't show through the
+	// zoomed widget's transparent background
+	wtfApp.pages.SwitchToPage(zoomPageName)
+
+	wtfApp.zoomed = widget
+	wtfApp.TViewApp.SetFocus(view)
+}
+
+// unzoom puts the zoomed widget back in its cell in the grid, if a widget is zoomed
+func (wtfApp *WtfApp) unzoom() {
+	if wtfApp.zoomed == nil {
+		return
+	}
+
+	wtfApp.pages.RemovePage(zoomPageName)
+	wtfApp.display.add(wtfApp.zoomed)
+	wtfApp.pages.SwitchToPage(gridPageName)
+
+	wtfApp.zoomed = nil
+	wtfApp.restoreFocus()
+}
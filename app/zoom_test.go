@@ -0,0 +1,60 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func testZoomApp(t *testing.T) (*WtfApp, *testWidget) {
+	widget := newPaletteWidget("github", "GitHub")
+
+	gridConfig, err := config.ParseYaml("wtf:\n  grid:\n    columns: [10]\n    rows: [10]\n")
+	assert.NoError(t, err)
+
+	tviewApp := tview.NewApplication()
+	widgets := []wtf.Wtfable{widget}
+
+	wtfApp := &WtfApp{
+		TViewApp:     tviewApp,
+		display:      NewDisplay(widgets, gridConfig),
+		focusTracker: NewFocusTracker(tviewApp, widgets, gridConfig),
+		pages:        tview.NewPages(),
+		widgets:      widgets,
+	}
+	wtfApp.pages.AddPage(gridPageName, wtfApp.display.Grid, true, true)
+
+	return wtfApp, widget
+}
+
+func Test_toggleZoom(t *testing.T) {
+	wtfApp, widget := testZoomApp(t)
+	wtfApp.focusTracker.FocusOnName("github")
+
+	wtfApp.toggleZoom()
+
+	front, _ := wtfApp.pages.GetFrontPage()
+	assert.Equal(t, zoomPageName, front)
+	assert.Equal(t, wtf.Wtfable(widget), wtfApp.zoomed)
+
+	wtfApp.toggleZoom()
+
+	front, _ = wtfApp.pages.GetFrontPage()
+	assert.Equal(t, gridPageName, front)
+	assert.False(t, wtfApp.pages.HasPage(zoomPageName))
+	assert.Nil(t, wtfApp.zoomed)
+	assert.Equal(t, "github", wtfApp.focusTracker.FocusedName())
+}
+
+func Test_toggleZoom_WithoutFocus(t *testing.T) {
+	wtfApp, _ := testZoomApp(t)
+
+	wtfApp.toggleZoom()
+
+	front, _ := wtfApp.pages.GetFrontPage()
+	assert.Equal(t, gridPageName, front)
+	assert.Nil(t, wtfApp.zoomed)
+}
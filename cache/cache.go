@@ -0,0 +1,252 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const (
+	// DirName is the name of the directory, inside the config directory, that the cache
+	// is stored in
+	DirName = "cache"
+
+	// defaultMaxSizeMB is the default size, in megabytes, that the cache is trimmed to
+	defaultMaxSizeMB = 10
+
+	fileExt = ".json"
+)
+
+// ErrNotCached is returned when there is no unexpired cache entry for a key
+var ErrNotCached = errors.New("not cached")
+
+var (
+	store      *Store
+	storeMutex = &sync.Mutex{}
+
+	unsafeChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+)
+
+// entry is the format that each cached payload is written to disk in
+type entry struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Store persists the most recent successful payload of modules to disk, so that they
+// have something to display when wtfutil starts, before their first refresh finishes.
+// When the files in the store grow beyond the maximum size the oldest are removed
+type Store struct {
+	dir     string
+	maxSize int64
+	mutex   *sync.Mutex
+}
+
+// NewStore creates and returns a store that keeps its files in the given directory
+func NewStore(dir string, maxSize int64) *Store {
+	return &Store{
+		dir:     dir,
+		maxSize: maxSize,
+		mutex:   &sync.Mutex{},
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Dir returns the absolute path to the cache directory
+func Dir() (string, error) {
+	configDir, err := cfg.WtfConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, DirName), nil
+}
+
+// Clear removes every entry from the cache directory, and returns the path to it
+func Clear() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return dir, NewStore(dir, 0).Clear()
+}
+
+// Init configures the shared store from the optional `wtf.cache` settings:
+//
+//	wtf:
+//	  cache:
+//	    maxSize: 10      # megabytes
+func Init(config *config.Config) {
+	dir, err := Dir()
+	if err != nil {
+		return
+	}
+
+	maxSizeMB := config.UInt("wtf.cache.maxSize", defaultMaxSizeMB)
+
+	storeMutex.Lock()
+	defer storeMutex.Unlock()
+
+	store = NewStore(dir, int64(maxSizeMB)*1024*1024)
+}
+
+// Load reads the payload cached under the key from the shared store into data. See
+// Store.Load
+func Load(key string, ttl time.Duration, data interface{}) (time.Time, error) {
+	shared := sharedStore()
+	if shared == nil {
+		return time.Time{}, ErrNotCached
+	}
+
+	return shared.Load(key, ttl, data)
+}
+
+// Save writes the payload to the shared store under the key. See Store.Save
+func Save(key string, data interface{}) error {
+	shared := sharedStore()
+	if shared == nil {
+		return nil
+	}
+
+	return shared.Save(key, data)
+}
+
+// Clear removes every entry from the store
+func (store *Store) Clear() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return os.RemoveAll(store.dir)
+}
+
+// Load reads the payload cached under the key into data, and returns the time it was
+// cached. It returns ErrNotCached if there is no entry for the key, or if the entry is
+// older than the ttl. A ttl of zero means entries never expire
+func (store *Store) Load(key string, ttl time.Duration, data interface{}) (time.Time, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	bytes, err := os.ReadFile(store.path(key))
+	if err != nil {
+		return time.Time{}, ErrNotCached
+	}
+
+	cached := entry{}
+	if err := json.Unmarshal(bytes, &cached); err != nil {
+		return time.Time{}, err
+	}
+
+	if ttl > 0 && time.Since(cached.Timestamp) > ttl {
+		return time.Time{}, ErrNotCached
+	}
+
+	if err := json.Unmarshal(cached.Data, data); err != nil {
+		return time.Time{}, err
+	}
+
+	return cached.Timestamp, nil
+}
+
+// Save writes the payload to the store under the key, timestamped with the current time,
+// and then trims the store to its maximum size
+func (store *Store) Save(key string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(entry{Timestamp: time.Now(), Data: payload})
+	if err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if err := os.MkdirAll(store.dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that a crash never leaves a half-written entry
+	tmpPath := store.path(key) + ".tmp"
+	if err := os.WriteFile(tmpPath, bytes, 0600); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, store.path(key)); err != nil {
+		return err
+	}
+
+	return store.trim()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (store *Store) path(key string) string {
+	return filepath.Join(store.dir, unsafeChars.ReplaceAllString(key, "_")+fileExt)
+}
+
+// trim removes the least recently written entries until the total size of the store is
+// no larger than its maximum size
+func (store *Store) trim() error {
+	if store.maxSize <= 0 {
+		return nil
+	}
+
+	dirEntries, err := os.ReadDir(store.dir)
+	if err != nil {
+		return err
+	}
+
+	files := []os.FileInfo{}
+	total := int64(0)
+
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || filepath.Ext(dirEntry.Name()) != fileExt {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		files = append(files, info)
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	for _, file := range files {
+		if total <= store.maxSize {
+			break
+		}
+
+		if err := os.Remove(filepath.Join(store.dir, file.Name())); err != nil {
+			return err
+		}
+
+		total -= file.Size()
+	}
+
+	return nil
+}
+
+func sharedStore() *Store {
+	storeMutex.Lock()
+	defer storeMutex.Unlock()
+
+	return store
+}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type payload struct {
+	Name  string
+	Count int
+}
+
+func Test_SaveAndLoad(t *testing.T) {
+	store := NewStore(t.TempDir(), 0)
+
+	err := store.Save("hibp-hibp", payload{Name: "cats", Count: 3})
+	assert.NoError(t, err)
+
+	actual := payload{}
+	timestamp, err := store.Load("hibp-hibp", time.Hour, &actual)
+
+	assert.NoError(t, err)
+	assert.Equal(t, payload{Name: "cats", Count: 3}, actual)
+	assert.WithinDuration(t, time.Now(), timestamp, time.Minute)
+}
+
+func Test_Load(t *testing.T) {
+	tests := []struct {
+		name        string
+		age         time.Duration
+		ttl         time.Duration
+		expectedErr error
+	}{
+		{
+			name:        "when not expired",
+			age:         time.Minute,
+			ttl:         time.Hour,
+			expectedErr: nil,
+		},
+		{
+			name:        "when expired",
+			age:         2 * time.Hour,
+			ttl:         time.Hour,
+			expectedErr: ErrNotCached,
+		},
+		{
+			name:        "when the ttl is zero",
+			age:         1000 * time.Hour,
+			ttl:         0,
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			content := `{"timestamp":"` + time.Now().Add(-tt.age).Format(time.RFC3339) + `","data":{"Name":"cats"}}`
+			_ = os.WriteFile(filepath.Join(dir, "covid-covid.json"), []byte(content), 0600)
+
+			actual := payload{}
+			_, err := NewStore(dir, 0).Load("covid-covid", tt.ttl, &actual)
+
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func Test_Load_WhenMissing(t *testing.T) {
+	_, err := NewStore(t.TempDir(), 0).Load("nope", time.Hour, &payload{})
+
+	assert.Equal(t, ErrNotCached, err)
+}
+
+func Test_Save_TrimsToMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir, 150)
+
+	assert.NoError(t, store.Save("first", payload{Name: "first"}))
+
+	// Make sure the first entry is the oldest
+	old := time.Now().Add(-time.Hour)
+	_ = os.Chtimes(filepath.Join(dir, "first.json"), old, old)
+
+	assert.NoError(t, store.Save("second", payload{Name: "second"}))
+	assert.NoError(t, store.Save("third", payload{Name: "third"}))
+
+	_, err := store.Load("first", 0, &payload{})
+	assert.Equal(t, ErrNotCached, err)
+
+	_, err = store.Load("third", 0, &payload{})
+	assert.NoError(t, err)
+}
+
+func Test_Clear(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), DirName)
+	store := NewStore(dir, 0)
+
+	assert.NoError(t, store.Save("todo", payload{}))
+	assert.NoError(t, store.Clear())
+
+	_, err := os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func Test_path(t *testing.T) {
+	store := NewStore("/tmp/cache", 0)
+
+	assert.Equal(t, "/tmp/cache/github-my_repos.json", store.path("github-my repos"))
+	assert.Equal(t, "/tmp/cache/cmdrunner-.._etc.json", store.path("cmdrunner-../etc"))
+}
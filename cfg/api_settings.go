@@ -0,0 +1,77 @@
+package cfg
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/olebedev/config"
+)
+
+const (
+	apiPath = "wtf.api"
+
+	defaultAPIAddress = "127.0.0.1:7483"
+
+	// unixSocketPrefix marks an API address as the path to a unix socket
+	unixSocketPrefix = "unix:"
+)
+
+// APISettings defines the optional HTTP API that exposes the state of the running
+// dashboard's widgets and lets scripts refresh them or trigger their keyboard commands.
+// It is off by default:
+//
+//	wtf:
+//	  api:
+//	    enabled: true
+//	    address: 127.0.0.1:7483     # or unix:~/.config/wtf/wtf.sock
+//	    token: ${secret:wtf-api}    # required when listening beyond localhost
+type APISettings struct {
+	Address string
+	Enabled bool
+	Token   string
+}
+
+// NewAPISettingsFromYAML creates and returns the API settings defined in wtf.api
+func NewAPISettingsFromYAML(globalConfig *config.Config) *APISettings {
+	return &APISettings{
+		Address: globalConfig.UString(apiPath+".address", defaultAPIAddress),
+		Enabled: globalConfig.UBool(apiPath+".enabled", false),
+		Token:   globalConfig.UString(apiPath+".token", ""),
+	}
+}
+
+// Listen returns the network and address to listen on, as used by net.Listen. Listening
+// on anything other than localhost or a unix socket requires a token. Without one, the
+// API only answers requests addressed to localhost that don't come from a web page
+func (settings *APISettings) Listen() (string, string, error) {
+	if strings.HasPrefix(settings.Address, unixSocketPrefix) {
+		path, err := expandHomeDir(strings.TrimPrefix(settings.Address, unixSocketPrefix))
+		if err != nil {
+			return "", "", err
+		}
+
+		return "unix", path, nil
+	}
+
+	host, _, err := net.SplitHostPort(settings.Address)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid API address %q: %w", settings.Address, err)
+	}
+
+	if settings.Token == "" && !IsLoopback(host) {
+		return "", "", fmt.Errorf("the API cannot listen on %q without a token", settings.Address)
+	}
+
+	return "tcp", settings.Address, nil
+}
+
+// IsLoopback returns TRUE if the host name or IP address is this machine's
+func IsLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
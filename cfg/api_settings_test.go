@@ -0,0 +1,75 @@
+package cfg
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewAPISettingsFromYAML(t *testing.T) {
+	emptyConfig, _ := config.ParseYaml("")
+	settings := NewAPISettingsFromYAML(emptyConfig)
+
+	assert.Equal(t, &APISettings{Address: "127.0.0.1:7483"}, settings)
+}
+
+func Test_APISettings_Listen(t *testing.T) {
+	tests := []struct {
+		name            string
+		settings        APISettings
+		expectedNetwork string
+		expectedAddress string
+		expectedErr     string
+	}{
+		{
+			name:            "with localhost",
+			settings:        APISettings{Address: "localhost:7483"},
+			expectedNetwork: "tcp",
+			expectedAddress: "localhost:7483",
+		},
+		{
+			name:            "with a loopback IP",
+			settings:        APISettings{Address: "[::1]:7483"},
+			expectedNetwork: "tcp",
+			expectedAddress: "[::1]:7483",
+		},
+		{
+			name:            "with a unix socket",
+			settings:        APISettings{Address: "unix:/tmp/wtf.sock"},
+			expectedNetwork: "unix",
+			expectedAddress: "/tmp/wtf.sock",
+		},
+		{
+			name:        "with every interface and no token",
+			settings:    APISettings{Address: ":7483"},
+			expectedErr: `the API cannot listen on ":7483" without a token`,
+		},
+		{
+			name:            "with every interface and a token",
+			settings:        APISettings{Address: ":7483", Token: "s3cret"},
+			expectedNetwork: "tcp",
+			expectedAddress: ":7483",
+		},
+		{
+			name:        "with an invalid address",
+			settings:    APISettings{Address: "localhost"},
+			expectedErr: `invalid API address "localhost": address localhost: missing port in address`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, address, err := tt.settings.Listen()
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedNetwork, network)
+			assert.Equal(t, tt.expectedAddress, address)
+		})
+	}
+}
@@ -41,10 +41,18 @@ type Common struct {
 
 	DocPath string
 
+	HTTP *HTTPSettings `help:"Overrides the global wtf.http settings of the HTTP client used by modules that support them: proxy, caFile, certFile, keyFile, timeout, retries, retryWait, maxRetryWait, rateLimit, userAgent and verifyServerCertificate." optional:"true"`
+
+	Keys map[string]string `help:"Remaps or disables the module's keyboard commands. Each key is the key a command is bound to by default, each value the key to bind it to instead, or an empty string to disable it. Applied on top of the global wtf.keys setting." optional:"true"`
+
 	Bordered        bool          `help:"Whether or not the module should be displayed with a border." values:"true, false" optional:"true" default:"true"`
+	Cache           bool          `help:"Whether or not modules that support caching keep their last data on disk, to display on startup." values:"true, false" optional:"true" default:"true"`
+	CacheTTL        time.Duration `help:"How long cached data is displayed on startup before it is considered too old." values:"A positive integer followed by a time unit (ns, us or µs, ms, s, m, h, or nothing which defaults to s)" optional:"true" default:"24h"`
 	Enabled         bool          `help:"Whether or not this module is executed and if its data displayed onscreen." values:"true, false" optional:"true" default:"false"`
 	Focusable       bool          `help:"Whether or  not this module is focusable." values:"true, false" optional:"true" default:"false"`
+	HistorySize     int           `help:"How many samples of each metric are kept by modules that chart the history of their values." optional:"true" default:"60"`
 	LanguageTag     string        `help:"The BCP 47 langauge tag to localize text to." values:"Any supported BCP 47 language tag." optional:"true" default:"en-CA"`
+	PersistHistory  bool          `help:"Whether or not modules that chart the history of their values keep it on disk, to carry on from it on startup." values:"true, false" optional:"true" default:"false"`
 	RefreshInterval time.Duration `help:"How often this module will update its data." values:"A positive integer followed by a time unit (ns, us or ÃÂµs, ms, s, m, h, or nothing which defaults to s)" optional:"true"`
 	Title           string        `help:"The title string to show when displaying this module" optional:"true"`
 
@@ -53,56 +61,27 @@ type Common struct {
 
 // NewCommonSettingsFromModule returns a common settings configuration tailed to the given module
 func NewCommonSettingsFromModule(name, defaultTitle string, defaultFocusable bool, moduleConfig *config.Config, globalConfig *config.Config) *Common {
-	baseColors := NewDefaultColorTheme()
-
-	colorsConfig, err := globalConfig.Get("wtf.colors")
-	if err != nil && strings.Contains(err.Error(), "Nonexistent map") {
-		// Create a default colors config to fill in for the missing one
-		// This comes into play when the configuration file does not contain a `colors:` key, i.e:
-		//
-		//     wtf:
-		//       # colors:                <- missing
-		//       refreshInterval: 1
-		//       openFileUtil: "open"
-		//
-		colorsConfig, _ = NewDefaultColorConfig()
-	}
-
-	// And finally create a third instance to be the final default fallback in case there are empty or nil values in
-	// the colors extracted from the config file (aka colorsConfig)
-	defaultColorTheme := NewDefaultColorTheme()
-
-	baseColors.BorderTheme.Focusable = moduleConfig.UString("colors.border.focusable", colorsConfig.UString("border.focusable", defaultColorTheme.BorderTheme.Focusable))
-	baseColors.BorderTheme.Focused = moduleConfig.UString("colors.border.focused", colorsConfig.UString("border.focused", defaultColorTheme.BorderTheme.Focused))
-	baseColors.BorderTheme.Unfocusable = moduleConfig.UString("colors.border.normal", colorsConfig.UString("border.normal", defaultColorTheme.BorderTheme.Unfocusable))
-
-	baseColors.CheckboxTheme.Checked = moduleConfig.UString("colors.checked", colorsConfig.UString("checked", defaultColorTheme.CheckboxTheme.Checked))
-
-	baseColors.RowTheme.EvenForeground = moduleConfig.UString("colors.rows.even", colorsConfig.UString("rows.even", defaultColorTheme.RowTheme.EvenForeground))
-	baseColors.RowTheme.OddForeground = moduleConfig.UString("colors.rows.odd", colorsConfig.UString("rows.odd", defaultColorTheme.RowTheme.OddForeground))
-
-	baseColors.TextTheme.Label = moduleConfig.UString("colors.label", colorsConfig.UString("label", defaultColorTheme.TextTheme.Label))
-	baseColors.TextTheme.Subheading = moduleConfig.UString("colors.subheading", colorsConfig.UString("subheading", defaultColorTheme.TextTheme.Subheading))
-	baseColors.TextTheme.Text = moduleConfig.UString("colors.text", colorsConfig.UString("text", defaultColorTheme.TextTheme.Text))
-	baseColors.TextTheme.Title = moduleConfig.UString("colors.title", colorsConfig.UString("title", defaultColorTheme.TextTheme.Title))
-
-	baseColors.WidgetTheme.Background = moduleConfig.UString("colors.background", colorsConfig.UString("background", defaultColorTheme.WidgetTheme.Background))
-
 	common := Common{
-		Colors: baseColors,
+		Colors: NewColorThemeFromYAML(moduleConfig, globalConfig),
 
 		Module: Module{
 			Name: name,
 			Type: moduleConfig.UString("type", name),
 		},
 
-		PositionSettings: NewPositionSettingsFromYAML(moduleConfig),
+		PositionSettings: NewPositionSettingsFromYAML(moduleConfig, globalConfig),
 
 		Bordered:        moduleConfig.UBool("border", true),
+		Cache:           moduleConfig.UBool("cache", true),
+		CacheTTL:        ParseTimeString(moduleConfig, "cacheTTL", "24h"),
 		Config:          moduleConfig,
 		Enabled:         moduleConfig.UBool("enabled", false),
 		Focusable:       moduleConfig.UBool("focusable", defaultFocusable),
+		HTTP:            NewHTTPSettingsFromYAML(moduleConfig, globalConfig),
+		HistorySize:     moduleConfig.UInt("historySize", 60),
+		Keys:            NewKeyBindingsFromYAML(moduleConfig, globalConfig),
 		LanguageTag:     globalConfig.UString("wtf.language", defaultLanguageTag),
+		PersistHistory:  moduleConfig.UBool("persistHistory", false),
 		RefreshInterval: ParseTimeString(moduleConfig, "refreshInterval", "300s"),
 		Title:           moduleConfig.UString("title", defaultTitle),
 
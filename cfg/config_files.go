@@ -87,21 +87,46 @@ func WtfConfigDir() (string, error) {
 	return configDir, nil
 }
 
-// LoadWtfConfigFile loads the specified config file
+// LoadWtfConfigFile loads the specified config file, along with the files it includes,
+// and resolves its module templates and ${...} interpolations. If it can't, it displays
+// the error and exits
 func LoadWtfConfigFile(filePath string) *config.Config {
-	absPath, _ := expandHomeDir(filePath)
-
-	cfg, err := config.ParseYamlFile(absPath)
+	cfg, err := ReadWtfConfigFile(filePath)
 	if err != nil {
-		displayWtfConfigFileLoadError(absPath, err)
-		os.Exit(1)
+		exitWithConfigError(filePath, err)
 	}
 
 	return cfg
 }
 
+// ReadWtfConfigFile loads the specified config file like LoadWtfConfigFile, but returns
+// the error instead of exiting, i.e.: to keep the running config when a reload fails
+func ReadWtfConfigFile(filePath string) (*config.Config, error) {
+	absPath, _ := expandHomeDir(filePath)
+
+	return loadConfigFile(absPath)
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
+// exitWithConfigError displays the error in loading the config file, or a file that it
+// refers to, and exits
+func exitWithConfigError(filePath string, err error) {
+	var configErr *ConfigError
+	if errors.As(err, &configErr) {
+		if configErr.Key != "" {
+			displayWtfConfigFileProcessError(err)
+			os.Exit(1)
+		}
+
+		filePath = configErr.FilePath
+	}
+
+	absPath, _ := expandHomeDir(filePath)
+	displayWtfConfigFileLoadError(absPath, err)
+	os.Exit(1)
+}
+
 // chmodConfigFile sets the mode of the config file to r+w for the owner only
 func chmodConfigFile() {
 	configDir, _ := WtfConfigDir()
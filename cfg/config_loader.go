@@ -0,0 +1,479 @@
+package cfg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olebedev/config"
+)
+
+const (
+	// includeKey is the top-level key that lists the other files a config file includes
+	includeKey = "include"
+
+	// extendsKey is the module key that names the template a module is based on
+	extendsKey = "extends"
+
+	// templatesPath is where module templates are defined
+	templatesPath = "wtf.templates"
+)
+
+// interpolation matches ${ENV_VAR} and ${secret:service} references in config values.
+// A reference can be escaped by doubling the dollar sign: $${NOT_INTERPOLATED}
+var interpolation = regexp.MustCompile(`\$?\$\{([^}]*)\}`)
+
+// ConfigError is an error in a config file that was found while loading it
+type ConfigError struct {
+	FilePath string
+	Key      string
+	Err      error
+}
+
+func (err *ConfigError) Error() string {
+	if err.Key == "" {
+		return fmt.Sprintf("%s: %s", err.FilePath, err.Err.Error())
+	}
+
+	return fmt.Sprintf("%s: %s: %s", err.FilePath, err.Key, err.Err.Error())
+}
+
+func (err *ConfigError) Unwrap() error {
+	return err.Err
+}
+
+// configLoader reads a config file along with the files it includes, and resolves the
+// module templates and interpolations in it. It keeps track of which file every key
+// came from, so that errors name the file that needs fixing
+type configLoader struct {
+	// sources maps dotted key paths to the file that set them
+	sources map[string]string
+
+	// loading is the set of files currently being read, to catch include cycles
+	loading map[string]bool
+
+	// files are the files that have been read, in the order they were read in
+	files []string
+}
+
+// loadConfigFile reads the config file at the path, and returns it with its includes
+// merged in, its module templates applied, and its values interpolated. Config files
+// can use:
+//
+//	include:
+//	  - "~/.config/wtf/github.yml"
+//	  - "teams/*.yml"
+//
+// to merge other config files into themselves. Paths are relative to the including
+// file, and may be globs. The including file's own settings take precedence.
+//
+//	wtf:
+//	  templates:
+//	    base-github:
+//	      apiKey: "${secret:github}"
+//	      refreshInterval: 5m
+//	  mods:
+//	    github-work:
+//	      extends: base-github
+//	      repositories: ["wtfutil/wtf"]
+//
+// deep-merges a template into a module's settings, the module's own settings taking
+// precedence. Templates can themselves extend other templates.
+//
+// ${ENV_VAR} is replaced by the environment variable, and ${secret:service} by the
+// service's secret from the secret store. A reference that can't be resolved is an error
+// only if its value is used: in the wtf settings and the enabled modules. Anywhere else
+// it's left as it is.
+func loadConfigFile(filePath string) (*config.Config, error) {
+	loader := newConfigLoader()
+
+	root, err := loader.read(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loader.applyTemplates(root, filePath); err != nil {
+		return nil, err
+	}
+
+	cfg := &config.Config{Root: root}
+
+	interpolated, err := loader.interpolate(cfg, root, "")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Root = interpolated
+
+	return cfg, nil
+}
+
+// ConfigFilePaths returns the absolute paths of the config file and of every file it
+// includes, directly or through other included files
+func ConfigFilePaths(filePath string) ([]string, error) {
+	loader := newConfigLoader()
+
+	if _, err := loader.read(filePath); err != nil {
+		return nil, err
+	}
+
+	return loader.files, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func newConfigLoader() *configLoader {
+	return &configLoader{
+		sources: map[string]string{},
+		loading: map[string]bool{},
+	}
+}
+
+// read parses the file and merges the files it includes into it
+func (loader *configLoader) read(filePath string) (map[string]interface{}, error) {
+	absPath, err := expandHomeDir(filePath)
+	if err != nil {
+		return nil, &ConfigError{FilePath: filePath, Err: err}
+	}
+
+	if loader.loading[absPath] {
+		return nil, &ConfigError{FilePath: filePath, Key: includeKey, Err: fmt.Errorf("%s includes itself", filePath)}
+	}
+	loader.loading[absPath] = true
+	defer delete(loader.loading, absPath)
+
+	loader.files = append(loader.files, absPath)
+
+	cfg, err := config.ParseYamlFile(absPath)
+	if err != nil {
+		return nil, &ConfigError{FilePath: filePath, Err: err}
+	}
+
+	root, ok := cfg.Root.(map[string]interface{})
+	if !ok {
+		if cfg.Root != nil {
+			return nil, &ConfigError{FilePath: filePath, Err: fmt.Errorf("the config file must be a map")}
+		}
+
+		root = map[string]interface{}{}
+	}
+
+	includes, err := includePaths(cfg)
+	if err != nil {
+		return nil, &ConfigError{FilePath: filePath, Key: includeKey, Err: err}
+	}
+	delete(root, includeKey)
+
+	merged := map[string]interface{}{}
+
+	for _, include := range includes {
+		pattern, err := expandHomeDir(include)
+		if err != nil {
+			return nil, &ConfigError{FilePath: filePath, Key: includeKey, Err: err}
+		}
+
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(absPath), pattern)
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, &ConfigError{FilePath: filePath, Key: includeKey, Err: fmt.Errorf("%q: %w", include, err)}
+		}
+
+		// A glob that matches nothing is fine, a file that does not exist is a mistake
+		if len(matches) == 0 && !strings.ContainsAny(include, "*?[") {
+			return nil, &ConfigError{FilePath: filePath, Key: includeKey, Err: fmt.Errorf("%q does not exist", include)}
+		}
+		sort.Strings(matches)
+
+		for _, match := range matches {
+			included, err := loader.read(match)
+			if err != nil {
+				return nil, err
+			}
+
+			loader.merge(merged, included, "", "")
+		}
+	}
+
+	loader.merge(merged, root, "", filePath)
+
+	return merged, nil
+}
+
+// applyTemplates replaces the settings of every module that extends a template with
+// the template's settings merged with its own
+func (loader *configLoader) applyTemplates(root map[string]interface{}, filePath string) error {
+	cfg := &config.Config{Root: root}
+
+	modsPaths := []string{"wtf.mods"}
+	for idx := range cfg.UList("wtf.dashboards") {
+		modsPaths = append(modsPaths, fmt.Sprintf("wtf.dashboards.%d.mods", idx))
+	}
+
+	for _, modsPath := range modsPaths {
+		mods, err := cfg.Map(modsPath)
+		if err != nil {
+			continue
+		}
+
+		for name, mod := range mods {
+			modPath := modsPath + "." + name
+
+			settings, ok := mod.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			extended, err := loader.extend(cfg, settings, modPath, []string{})
+			if err != nil {
+				return err
+			}
+
+			mods[name] = extended
+		}
+	}
+
+	return nil
+}
+
+// extend returns the settings deep-merged over the template they extend, if any
+func (loader *configLoader) extend(cfg *config.Config, settings map[string]interface{}, path string, seen []string) (map[string]interface{}, error) {
+	extends, ok := settings[extendsKey]
+	if !ok {
+		return settings, nil
+	}
+
+	name, ok := extends.(string)
+	if !ok || name == "" {
+		return nil, loader.errorAt(path+"."+extendsKey, fmt.Errorf("must be the name of a template"))
+	}
+
+	for _, seenName := range seen {
+		if seenName == name {
+			return nil, loader.errorAt(path+"."+extendsKey, fmt.Errorf("template %q extends itself", name))
+		}
+	}
+
+	templatePath := templatesPath + "." + name
+
+	template, err := cfg.Map(templatePath)
+	if err != nil {
+		return nil, loader.errorAt(path+"."+extendsKey, fmt.Errorf("there is no template named %q", name))
+	}
+
+	base, err := loader.extend(cfg, template, templatePath, append(seen, name))
+	if err != nil {
+		return nil, err
+	}
+
+	extended := map[string]interface{}{}
+	loader.copySources(templatePath, path)
+	loader.merge(extended, base, path, "")
+	loader.merge(extended, settings, path, "")
+	delete(extended, extendsKey)
+
+	return extended, nil
+}
+
+// interpolate returns the value with the references in all of its strings replaced
+func (loader *configLoader) interpolate(cfg *config.Config, value interface{}, path string) (interface{}, error) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, val := range typed {
+			interpolated, err := loader.interpolate(cfg, val, joinPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+
+			typed[key] = interpolated
+		}
+
+		return typed, nil
+	case []interface{}:
+		for idx, val := range typed {
+			interpolated, err := loader.interpolate(cfg, val, joinPath(path, strconv.Itoa(idx)))
+			if err != nil {
+				return nil, err
+			}
+
+			typed[idx] = interpolated
+		}
+
+		return typed, nil
+	case string:
+		var refErr error
+
+		result := interpolation.ReplaceAllStringFunc(typed, func(match string) string {
+			if strings.HasPrefix(match, "$$") {
+				return match[1:]
+			}
+
+			resolved, err := resolveReference(cfg, interpolation.FindStringSubmatch(match)[1])
+			if err != nil && refErr == nil {
+				refErr = err
+			}
+
+			return resolved
+		})
+
+		if refErr != nil {
+			if !usedPath(cfg, path) {
+				return typed, nil
+			}
+
+			return nil, loader.errorAt(path, refErr)
+		}
+
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// merge deep-merges src into dst. Maps are merged key by key, and any other value in
+// src replaces the one in dst. If filePath is set, the merged keys are recorded as
+// coming from that file
+func (loader *configLoader) merge(dst, src map[string]interface{}, path, filePath string) {
+	for key, srcVal := range src {
+		keyPath := joinPath(path, key)
+
+		if filePath != "" {
+			loader.sources[keyPath] = filePath
+		}
+
+		srcMap, srcIsMap := srcVal.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+
+		switch {
+		case srcIsMap && dstIsMap:
+			loader.merge(dstMap, srcMap, keyPath, filePath)
+		case srcIsMap:
+			copied := map[string]interface{}{}
+			loader.merge(copied, srcMap, keyPath, filePath)
+			dst[key] = copied
+		default:
+			dst[key] = srcVal
+		}
+	}
+}
+
+// copySources records the keys under the template path as coming from the same files
+// when they are copied under the module path
+func (loader *configLoader) copySources(templatePath, path string) {
+	for keyPath, filePath := range loader.sources {
+		if strings.HasPrefix(keyPath, templatePath+".") {
+			copiedPath := path + strings.TrimPrefix(keyPath, templatePath)
+			if _, ok := loader.sources[copiedPath]; !ok {
+				loader.sources[copiedPath] = filePath
+			}
+		}
+	}
+}
+
+// errorAt returns a ConfigError for the key, naming the file that set it
+func (loader *configLoader) errorAt(path string, err error) *ConfigError {
+	for keyPath := path; keyPath != ""; {
+		if filePath, ok := loader.sources[keyPath]; ok {
+			return &ConfigError{FilePath: filePath, Key: path, Err: err}
+		}
+
+		idx := strings.LastIndex(keyPath, ".")
+		if idx < 0 {
+			break
+		}
+		keyPath = keyPath[:idx]
+	}
+
+	return &ConfigError{FilePath: "config", Key: path, Err: err}
+}
+
+// includePaths returns the files the config includes, which can be a single path or a
+// list of them
+func includePaths(cfg *config.Config) ([]string, error) {
+	if paths, err := cfg.List(includeKey); err == nil {
+		includes := []string{}
+		for _, path := range paths {
+			str, ok := path.(string)
+			if !ok {
+				return nil, fmt.Errorf("must be a path or a list of paths")
+			}
+			includes = append(includes, str)
+		}
+
+		return includes, nil
+	}
+
+	if _, err := cfg.Get(includeKey); err != nil {
+		return []string{}, nil
+	}
+
+	path, err := cfg.String(includeKey)
+	if err != nil {
+		return nil, fmt.Errorf("must be a path or a list of paths")
+	}
+
+	return []string{path}, nil
+}
+
+// usedPath returns TRUE if the value at the dotted path is read when the config is
+// displayed: anything in the wtf settings except the templates, which the modules have
+// been merged with, and the modules that aren't enabled
+func usedPath(cfg *config.Config, path string) bool {
+	parts := strings.Split(path, ".")
+
+	if parts[0] != "wtf" {
+		return false
+	}
+
+	switch {
+	case len(parts) < 2:
+		return true
+	case parts[1] == "templates":
+		return false
+	case parts[1] == "mods" && len(parts) > 2:
+		return cfg.UBool(strings.Join(parts[:3], ".")+".enabled", false)
+	case parts[1] == "dashboards" && len(parts) > 4 && parts[3] == "mods":
+		return cfg.UBool(strings.Join(parts[:5], ".")+".enabled", false)
+	default:
+		return true
+	}
+}
+
+// resolveReference returns the value of a ${...} reference
+func resolveReference(cfg *config.Config, ref string) (string, error) {
+	if strings.HasPrefix(ref, "secret:") {
+		service := strings.TrimPrefix(ref, "secret:")
+
+		secret, err := FetchSecret(cfg, service)
+		if err != nil {
+			return "", err
+		}
+
+		if secret == nil {
+			return "", fmt.Errorf("no secret for service %q", service)
+		}
+
+		return secret.Secret, nil
+	}
+
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref)
+	}
+
+	return value, nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,225 @@
+package cfg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	return dir
+}
+
+func Test_loadConfigFile_Include(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml": `
+include:
+  - base.yml
+  - "teams/*.yml"
+wtf:
+  refreshInterval: 2
+  mods:
+    clocks:
+      title: Local clocks
+`,
+		"base.yml": `
+wtf:
+  refreshInterval: 1
+  colors:
+    border:
+      focused: orange
+  mods:
+    clocks:
+      enabled: true
+      title: Clocks
+`,
+		"teams/a.yml": "wtf:\n  mods:\n    jira:\n      enabled: true\n",
+		"teams/b.yml": "wtf:\n  mods:\n    gitlab:\n      enabled: false\n",
+	})
+
+	cfg, err := loadConfigFile(filepath.Join(dir, "config.yml"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, cfg.UInt("wtf.refreshInterval"))
+	assert.Equal(t, "orange", cfg.UString("wtf.colors.border.focused"))
+	assert.Equal(t, "Local clocks", cfg.UString("wtf.mods.clocks.title"))
+	assert.True(t, cfg.UBool("wtf.mods.clocks.enabled"))
+	assert.True(t, cfg.UBool("wtf.mods.jira.enabled"))
+	assert.False(t, cfg.UBool("wtf.mods.gitlab.enabled", true))
+
+	_, err = cfg.Get(includeKey)
+	assert.Error(t, err)
+}
+
+func Test_loadConfigFile_IncludeErrors(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"missing.yml": "include: nope.yml\n",
+		"cycle.yml":   "include: other.yml\n",
+		"other.yml":   "include: cycle.yml\n",
+	})
+
+	_, err := loadConfigFile(filepath.Join(dir, "missing.yml"))
+	assert.EqualError(t, err, filepath.Join(dir, "missing.yml")+`: include: "nope.yml" does not exist`)
+
+	_, err = loadConfigFile(filepath.Join(dir, "cycle.yml"))
+	assert.ErrorContains(t, err, "includes itself")
+}
+
+func Test_loadConfigFile_Templates(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml": `
+include: templates.yml
+wtf:
+  mods:
+    work:
+      extends: github-work
+      enabled: true
+      colors:
+        text: red
+    other:
+      enabled: true
+`,
+		"templates.yml": `
+wtf:
+  templates:
+    github:
+      type: github
+      refreshInterval: 5m
+      colors:
+        text: white
+        label: blue
+    github-work:
+      extends: github
+      baseURL: https://github.example.com
+`,
+	})
+
+	cfg, err := loadConfigFile(filepath.Join(dir, "config.yml"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "github", cfg.UString("wtf.mods.work.type"))
+	assert.Equal(t, "5m", cfg.UString("wtf.mods.work.refreshInterval"))
+	assert.Equal(t, "https://github.example.com", cfg.UString("wtf.mods.work.baseURL"))
+	assert.Equal(t, "red", cfg.UString("wtf.mods.work.colors.text"))
+	assert.Equal(t, "blue", cfg.UString("wtf.mods.work.colors.label"))
+	assert.Equal(t, "white", cfg.UString("wtf.templates.github.colors.text"))
+	assert.Equal(t, "", cfg.UString("wtf.mods.work.extends"))
+	assert.Equal(t, "", cfg.UString("wtf.mods.other.type"))
+}
+
+func Test_loadConfigFile_TemplateErrors(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml": "include: mods.yml\n",
+		"mods.yml":   "wtf:\n  mods:\n    work:\n      extends: nope\n",
+	})
+
+	_, err := loadConfigFile(filepath.Join(dir, "config.yml"))
+	assert.EqualError(t, err, filepath.Join(dir, "mods.yml")+`: wtf.mods.work.extends: there is no template named "nope"`)
+}
+
+func Test_loadConfigFile_Interpolation(t *testing.T) {
+	t.Setenv("WTF_TEST_TOKEN", "abc123")
+	t.Setenv("WTF_SECRET_JIRA", "sekrit")
+
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml": `
+wtf:
+  secretStore: env
+  mods:
+    github:
+      apiKey: "${WTF_TEST_TOKEN}"
+      title: "Token ${WTF_TEST_TOKEN}, literally $${WTF_TEST_TOKEN}"
+      repositories:
+        - "${WTF_TEST_TOKEN}/wtf"
+    jira:
+      apiKey: "${secret:jira}"
+`,
+		"unset.yml":    "wtf:\n  mods:\n    github:\n      enabled: true\n      apiKey: ${WTF_TEST_UNSET}\n",
+		"nosecret.yml": "wtf:\n  secretStore: env\n  mods:\n    jira:\n      enabled: true\n      apiKey: ${secret:nope}\n",
+	})
+
+	cfg, err := loadConfigFile(filepath.Join(dir, "config.yml"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "abc123", cfg.UString("wtf.mods.github.apiKey"))
+	assert.Equal(t, "Token abc123, literally ${WTF_TEST_TOKEN}", cfg.UString("wtf.mods.github.title"))
+	assert.Equal(t, "abc123/wtf", cfg.UString("wtf.mods.github.repositories.0"))
+	assert.Equal(t, "sekrit", cfg.UString("wtf.mods.jira.apiKey"))
+
+	_, err = loadConfigFile(filepath.Join(dir, "unset.yml"))
+	assert.EqualError(t, err, filepath.Join(dir, "unset.yml")+": wtf.mods.github.apiKey: environment variable WTF_TEST_UNSET is not set")
+
+	_, err = loadConfigFile(filepath.Join(dir, "nosecret.yml"))
+	assert.EqualError(t, err, filepath.Join(dir, "nosecret.yml")+`: wtf.mods.jira.apiKey: no secret for service "nope"`)
+}
+
+func Test_loadConfigFile_UnusedInterpolation(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml": `
+anchors:
+  token: "${WTF_TEST_UNSET}"
+wtf:
+  templates:
+    base:
+      apiKey: "${WTF_TEST_UNSET}"
+  mods:
+    github:
+      enabled: false
+      apiKey: "${WTF_TEST_UNSET}"
+  dashboards:
+    - name: work
+      mods:
+        jira:
+          apiKey: "${WTF_TEST_UNSET}"
+`,
+		"global.yml":    "wtf:\n  colors:\n    border: ${WTF_TEST_UNSET}\n",
+		"dashboard.yml": "wtf:\n  dashboards:\n    - mods:\n        jira:\n          enabled: true\n          apiKey: ${WTF_TEST_UNSET}\n",
+	})
+
+	// Values that aren't used are left as they are
+	cfg, err := loadConfigFile(filepath.Join(dir, "config.yml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "${WTF_TEST_UNSET}", cfg.UString("wtf.mods.github.apiKey"))
+
+	_, err = loadConfigFile(filepath.Join(dir, "global.yml"))
+	assert.EqualError(t, err, filepath.Join(dir, "global.yml")+": wtf.colors.border: environment variable WTF_TEST_UNSET is not set")
+
+	_, err = loadConfigFile(filepath.Join(dir, "dashboard.yml"))
+	assert.EqualError(t, err, filepath.Join(dir, "dashboard.yml")+": wtf.dashboards.0.mods.jira.apiKey: environment variable WTF_TEST_UNSET is not set")
+}
+
+func Test_ConfigFilePaths(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml":       "include:\n  - base.yml\n  - \"teams/*.yml\"\n",
+		"base.yml":         "wtf:\n  refreshInterval: 2\n",
+		"teams/one.yml":    "include: ../shared.yml\n",
+		"shared.yml":       "wtf:\n  name: shared\n",
+		"teams/ignored.md": "",
+	})
+
+	paths, err := ConfigFilePaths(filepath.Join(dir, "config.yml"))
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]string{
+			filepath.Join(dir, "config.yml"),
+			filepath.Join(dir, "base.yml"),
+			filepath.Join(dir, "teams", "one.yml"),
+			filepath.Join(dir, "shared.yml"),
+		},
+		paths,
+	)
+
+	_, err = ConfigFilePaths(filepath.Join(dir, "missing.yml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package cfg
+
+import (
+	"fmt"
+
+	"github.com/olebedev/config"
+)
+
+const (
+	// defaultDashboardName is the name given to the dashboard defined by the top-level
+	// `wtf.mods` key when no `wtf.name` is configured
+	defaultDashboardName = "main"
+
+	dashboardsPath = "wtf.dashboards"
+)
+
+// Dashboard is a named collection of modules that is displayed onscreen as a single
+// screen. A configuration file can define several dashboards, either inline:
+//
+//	wtf:
+//	  dashboards:
+//	    - name: oncall
+//	      grid:
+//	        columns: [40, 40]
+//	        rows: [10, 10]
+//	      mods:
+//	        ...
+//
+// or as references to other configuration files:
+//
+//	wtf:
+//	  dashboards:
+//	    - name: personal
+//	      config: "~/.config/wtf/personal.yml"
+//
+// The modules defined in the top-level `wtf.mods` key are always the first dashboard.
+type Dashboard struct {
+	Name     string
+	FilePath string
+	Config   *config.Config
+
+	// ModsPath is the dotted path to the dashboard's modules within the file at FilePath,
+	// i.e.: "wtf.dashboards.1.mods"
+	ModsPath string
+
+	// inline is TRUE if the dashboard was defined in the `wtf.dashboards` list of the
+	// file at FilePath, FALSE if it is the whole of the file at FilePath
+	inline bool
+}
+
+// LoadDashboards returns all the dashboards defined by the configuration, in the order
+// in which they are defined. It always returns at least one dashboard
+func LoadDashboards(globalConfig *config.Config, filePath string) []*Dashboard {
+	dashboards, err := readDashboards(globalConfig, filePath)
+	if err != nil {
+		exitWithConfigError(filePath, err)
+	}
+
+	return dashboards
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Reload re-reads the dashboard's configuration from disk and returns it. If it can't
+// be read, it returns the error and the dashboard's configuration is left as it is
+func (dashboard *Dashboard) Reload() (*config.Config, error) {
+	globalConfig, err := ReadWtfConfigFile(dashboard.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dashboard.inline {
+		return globalConfig, nil
+	}
+
+	dashboards, err := readDashboards(globalConfig, dashboard.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reloaded := range dashboards {
+		if reloaded.inline && reloaded.Name == dashboard.Name {
+			return reloaded.Config, nil
+		}
+	}
+
+	// The dashboard was removed from the configuration file. Keep displaying the old one
+	return dashboard.Config, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// readDashboards returns the dashboards like LoadDashboards, or the error in reading the
+// configuration files that dashboards refer to
+func readDashboards(globalConfig *config.Config, filePath string) ([]*Dashboard, error) {
+	dashboards := []*Dashboard{}
+
+	mainDashboard := &Dashboard{
+		Name:     globalConfig.UString("wtf.name", defaultDashboardName),
+		FilePath: filePath,
+		Config:   globalConfig,
+		ModsPath: "wtf.mods",
+	}
+
+	if mods, err := globalConfig.Map("wtf.mods"); err == nil && len(mods) > 0 {
+		dashboards = append(dashboards, mainDashboard)
+	}
+
+	for idx := range globalConfig.UList(dashboardsPath) {
+		dashConfig, err := globalConfig.Get(fmt.Sprintf("%s.%d", dashboardsPath, idx))
+		if err != nil {
+			continue
+		}
+
+		dashboard, err := newDashboard(globalConfig, filePath, dashConfig, idx)
+		if err != nil {
+			return nil, err
+		}
+
+		dashboards = append(dashboards, dashboard)
+	}
+
+	if len(dashboards) == 0 {
+		dashboards = append(dashboards, mainDashboard)
+	}
+
+	return dashboards, nil
+}
+
+func newDashboard(globalConfig *config.Config, filePath string, dashConfig *config.Config, idx int) (*Dashboard, error) {
+	name := dashConfig.UString("name", fmt.Sprintf("dashboard %d", idx+1))
+
+	// A dashboard that points at another file is loaded entirely from that file
+	if path := dashConfig.UString("config", ""); path != "" {
+		pathConfig, err := ReadWtfConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Dashboard{
+			Name:     name,
+			FilePath: path,
+			Config:   pathConfig,
+			ModsPath: "wtf.mods",
+		}, nil
+	}
+
+	return &Dashboard{
+		Name:     name,
+		FilePath: filePath,
+		Config:   inlineDashboardConfig(globalConfig, dashConfig),
+		ModsPath: fmt.Sprintf("%s.%d.mods", dashboardsPath, idx),
+		inline:   true,
+	}, nil
+}
+
+// inlineDashboardConfig builds a stand-alone configuration for an inline dashboard. The
+// global `wtf` settings are inherited and any settings defined in the dashboard entry
+// (grid, mods, colors, etc.) replace them
+func inlineDashboardConfig(globalConfig *config.Config, dashConfig *config.Config) *config.Config {
+	wtfSettings := map[string]interface{}{}
+
+	if globalSettings, err := globalConfig.Map("wtf"); err == nil {
+		for key, val := range globalSettings {
+			wtfSettings[key] = val
+		}
+	}
+
+	if dashSettings, err := dashConfig.Map(""); err == nil {
+		for key, val := range dashSettings {
+			wtfSettings[key] = val
+		}
+	}
+
+	delete(wtfSettings, "config")
+	delete(wtfSettings, "dashboards")
+	delete(wtfSettings, "mods")
+	delete(wtfSettings, "name")
+
+	if mods, err := dashConfig.Map("mods"); err == nil {
+		wtfSettings["mods"] = mods
+	}
+
+	return &config.Config{
+		Root: map[string]interface{}{
+			"wtf": wtfSettings,
+		},
+	}
+}
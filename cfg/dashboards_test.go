@@ -0,0 +1,109 @@
+package cfg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const dashboardsYAML = `
+wtf:
+  grid:
+    columns: [20, 20]
+    rows: [3, 3]
+  refreshInterval: 1
+  mods:
+    uptime:
+      type: cmdrunner
+      enabled: true
+  dashboards:
+    - name: oncall
+      grid:
+        columns: [40]
+        rows: [10]
+      mods:
+        pagerduty:
+          enabled: true
+    - mods:
+        todo:
+          enabled: true
+`
+
+func Test_LoadDashboards(t *testing.T) {
+	tests := []struct {
+		name          string
+		yaml          string
+		expectedNames []string
+	}{
+		{
+			name:          "without modules",
+			yaml:          "wtf:\n  refreshInterval: 1",
+			expectedNames: []string{"main"},
+		},
+		{
+			name:          "with only top-level modules",
+			yaml:          "wtf:\n  name: home\n  mods:\n    todo:\n      enabled: true",
+			expectedNames: []string{"home"},
+		},
+		{
+			name:          "with inline dashboards",
+			yaml:          dashboardsYAML,
+			expectedNames: []string{"main", "oncall", "dashboard 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			globalConfig, err := config.ParseYaml(tt.yaml)
+			assert.NoError(t, err)
+
+			actual := []string{}
+			for _, dashboard := range LoadDashboards(globalConfig, "config.yml") {
+				actual = append(actual, dashboard.Name)
+			}
+
+			assert.Equal(t, tt.expectedNames, actual)
+		})
+	}
+}
+
+func Test_InlineDashboardConfig(t *testing.T) {
+	globalConfig, _ := config.ParseYaml(dashboardsYAML)
+	dashboards := LoadDashboards(globalConfig, "config.yml")
+
+	oncall := dashboards[1].Config
+
+	assert.Equal(t, []interface{}{40}, oncall.UList("wtf.grid.columns"))
+	assert.Equal(t, 1, oncall.UInt("wtf.refreshInterval"))
+
+	mods, err := oncall.Map("wtf.mods")
+	assert.NoError(t, err)
+	assert.Contains(t, mods, "pagerduty")
+	assert.NotContains(t, mods, "uptime")
+
+	_, err = oncall.Get("wtf.dashboards")
+	assert.Error(t, err)
+}
+
+func Test_Dashboard_Reload(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{"config.yml": dashboardsYAML})
+	filePath := filepath.Join(dir, "config.yml")
+
+	globalConfig, err := ReadWtfConfigFile(filePath)
+	assert.NoError(t, err)
+	oncall := LoadDashboards(globalConfig, filePath)[1]
+
+	reloaded, err := oncall.Reload()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{40}, reloaded.UList("wtf.grid.columns"))
+
+	// A config that can't be loaded is an error, rather than exiting
+	assert.NoError(t, os.WriteFile(filePath, []byte("wtf:\n  colors:\n    border: ${WTF_TEST_UNSET}\n"), 0600))
+
+	reloaded, err = oncall.Reload()
+	assert.EqualError(t, err, filePath+": wtf.colors.border: environment variable WTF_TEST_UNSET is not set")
+	assert.Nil(t, reloaded)
+}
@@ -7,6 +7,7 @@ import (
 
 // BorderTheme defines the default color scheme for drawing widget borders
 type BorderTheme struct {
+	Error       string
 	Focusable   string
 	Focused     string
 	Unfocusable string
@@ -55,6 +56,7 @@ type ColorTheme struct {
 func NewDefaultColorTheme() ColorTheme {
 	defaultTheme := ColorTheme{
 		BorderTheme: BorderTheme{
+			Error:       "red",
 			Focusable:   "blue",
 			Focused:     "orange",
 			Unfocusable: "gray",
@@ -46,3 +46,9 @@ func displayWtfConfigFileLoadError(path string, err error) {
 	fmt.Println()
 	displayError(err)
 }
+
+func displayWtfConfigFileProcessError(err error) {
+	fmt.Printf("\n%s Could not load the configuration.\n", aurora.Red("ERROR"))
+	fmt.Println()
+	displayError(err)
+}
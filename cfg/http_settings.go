@@ -0,0 +1,90 @@
+package cfg
+
+import (
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+const (
+	httpPath = "wtf.http"
+
+	defaultHTTPUserAgent = "wtfutil"
+)
+
+// HTTPSettings configures the HTTP client that modules make their requests with. They
+// are set for every module in wtf.http, and can be overridden for a single module in its
+// own http section:
+//
+//	wtf:
+//	  http:
+//	    proxy: http://proxy.example.com:3128
+//	    caFile: ~/.config/wtf/corp-ca.pem
+//	    timeout: 30s
+//	    retries: 2                 # retries on 429 and 5xx responses, honoring Retry-After
+//	    retryWait: 1s              # doubled after every retry
+//	    maxRetryWait: 30s
+//	    rateLimit: 5               # requests per second per host, 0 for no limit
+//	  mods:
+//	    jenkins:
+//	      http:
+//	        certFile: ~/.config/wtf/client.pem
+//	        keyFile: ~/.config/wtf/client-key.pem
+//	        verifyServerCertificate: false
+//
+// A verifyServerCertificate key in the module's own settings, which predates the http
+// section, overrides both.
+type HTTPSettings struct {
+	CAFile                  string
+	CertFile                string
+	KeyFile                 string
+	MaxRetryWait            time.Duration
+	Proxy                   string
+	RateLimit               float64
+	Retries                 int
+	RetryWait               time.Duration
+	Timeout                 time.Duration
+	UserAgent               string
+	VerifyServerCertificate bool
+}
+
+// NewHTTPSettingsFromYAML creates and returns the HTTP settings for a module, from its
+// http section on top of those in wtf.http
+func NewHTTPSettingsFromYAML(moduleConfig *config.Config, globalConfig *config.Config) *HTTPSettings {
+	global, err := globalConfig.Get(httpPath)
+	if err != nil {
+		global = &config.Config{}
+	}
+
+	module, err := moduleConfig.Get("http")
+	if err != nil {
+		module = &config.Config{}
+	}
+
+	str := func(key, defaultValue string) string {
+		return module.UString(key, global.UString(key, defaultValue))
+	}
+
+	duration := func(key, defaultValue string) time.Duration {
+		if _, err := module.Get(key); err == nil {
+			return ParseTimeString(module, key, defaultValue)
+		}
+		return ParseTimeString(global, key, defaultValue)
+	}
+
+	settings := HTTPSettings{
+		CAFile:                  str("caFile", ""),
+		CertFile:                str("certFile", ""),
+		KeyFile:                 str("keyFile", ""),
+		MaxRetryWait:            duration("maxRetryWait", "30s"),
+		Proxy:                   str("proxy", ""),
+		RateLimit:               module.UFloat64("rateLimit", global.UFloat64("rateLimit", 0)),
+		Retries:                 module.UInt("retries", global.UInt("retries", 2)),
+		RetryWait:               duration("retryWait", "1s"),
+		Timeout:                 duration("timeout", "30s"),
+		UserAgent:               str("userAgent", defaultHTTPUserAgent),
+		VerifyServerCertificate: moduleConfig.UBool("verifyServerCertificate", module.UBool("verifyServerCertificate", global.UBool("verifyServerCertificate", true))),
+	}
+
+	return &settings
+}
@@ -0,0 +1,88 @@
+package cfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewHTTPSettingsFromYAML(t *testing.T) {
+	globalConfig, _ := config.ParseYaml(`
+wtf:
+  http:
+    proxy: http://proxy.example.com:3128
+    retries: 4
+    timeout: 10s
+    rateLimit: 2.5
+`)
+
+	tests := []struct {
+		name     string
+		module   string
+		expected HTTPSettings
+	}{
+		{
+			name:   "with the global settings",
+			module: "enabled: true",
+			expected: HTTPSettings{
+				MaxRetryWait:            30 * time.Second,
+				Proxy:                   "http://proxy.example.com:3128",
+				RateLimit:               2.5,
+				Retries:                 4,
+				RetryWait:               time.Second,
+				Timeout:                 10 * time.Second,
+				UserAgent:               "wtfutil",
+				VerifyServerCertificate: true,
+			},
+		},
+		{
+			name: "with module overrides",
+			module: `
+http:
+  caFile: ~/ca.pem
+  retries: 0
+  timeout: 5
+  verifyServerCertificate: false
+`,
+			expected: HTTPSettings{
+				CAFile:                  "~/ca.pem",
+				MaxRetryWait:            30 * time.Second,
+				Proxy:                   "http://proxy.example.com:3128",
+				RateLimit:               2.5,
+				Retries:                 0,
+				RetryWait:               time.Second,
+				Timeout:                 5 * time.Second,
+				UserAgent:               "wtfutil",
+				VerifyServerCertificate: false,
+			},
+		},
+		{
+			name: "with the module's own verifyServerCertificate",
+			module: `
+verifyServerCertificate: false
+http:
+  verifyServerCertificate: true
+`,
+			expected: HTTPSettings{
+				MaxRetryWait:            30 * time.Second,
+				Proxy:                   "http://proxy.example.com:3128",
+				RateLimit:               2.5,
+				Retries:                 4,
+				RetryWait:               time.Second,
+				Timeout:                 10 * time.Second,
+				UserAgent:               "wtfutil",
+				VerifyServerCertificate: false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moduleConfig, _ := config.ParseYaml(tt.module)
+
+			assert.Equal(t, &tt.expected, NewHTTPSettingsFromYAML(moduleConfig, globalConfig))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package cfg
+
+import (
+	"fmt"
+
+	"github.com/olebedev/config"
+)
+
+// NewKeyBindingsFromYAML reads the keyboard command remappings from the `wtf.keys`
+// setting and the module's `keys` setting, with the module's taking precedence. Each
+// remapping is keyed by the key a command is bound to by default, and its value is the
+// key to bind it to instead, or an empty string to disable it:
+//
+//	keys:
+//	  j: k          # swap the next and previous commands
+//	  k: j
+//	  Ctrl-D: x     # keys are named as they are in the help text
+//	  o: ""         # disable the command bound to "o"
+func NewKeyBindingsFromYAML(moduleConfig *config.Config, globalConfig *config.Config) map[string]string {
+	bindings := map[string]string{}
+
+	for _, keysConfig := range []*config.Config{globalConfig, moduleConfig} {
+		if keysConfig == nil {
+			continue
+		}
+
+		path := "keys"
+		if keysConfig == globalConfig {
+			path = "wtf.keys"
+		}
+
+		keys, err := keysConfig.Map(path)
+		if err != nil {
+			continue
+		}
+
+		for from, to := range keys {
+			switch val := to.(type) {
+			case nil:
+				bindings[from] = ""
+			case bool:
+				// `false` disables the command, `true` leaves it where it is
+				if !val {
+					bindings[from] = ""
+				}
+			default:
+				bindings[from] = fmt.Sprint(val)
+			}
+		}
+	}
+
+	return bindings
+}
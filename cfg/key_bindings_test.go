@@ -0,0 +1,42 @@
+package cfg
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewKeyBindingsFromYAML(t *testing.T) {
+	globalConfig, _ := config.ParseYaml(`
+wtf:
+  keys:
+    j: k
+    k: j
+    o: x
+`)
+
+	moduleConfig, _ := config.ParseYaml(`
+keys:
+  o: ""
+  p: false
+  r: true
+  Ctrl-D: d
+`)
+
+	expected := map[string]string{
+		"j":      "k",
+		"k":      "j",
+		"o":      "",
+		"p":      "",
+		"Ctrl-D": "d",
+	}
+
+	assert.Equal(t, expected, NewKeyBindingsFromYAML(moduleConfig, globalConfig))
+}
+
+func Test_NewKeyBindingsFromYAML_WithoutKeys(t *testing.T) {
+	emptyConfig, _ := config.ParseYaml("")
+
+	assert.Equal(t, map[string]string{}, NewKeyBindingsFromYAML(emptyConfig, emptyConfig))
+}
@@ -0,0 +1,140 @@
+package cfg
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/olebedev/config"
+)
+
+const (
+	layoutPath = "wtf.layout"
+
+	// AutoLayout flows modules into columns that fit the terminal
+	AutoLayout = "auto"
+
+	// GridLayout places modules at the coordinates in their position settings
+	GridLayout = "grid"
+
+	defaultMaxColumns     = 3
+	defaultMinColumnWidth = 40
+)
+
+// Breakpoint sets the number of columns the auto layout uses when the terminal is
+// narrower than a width
+type Breakpoint struct {
+	Below   int
+	Columns int
+}
+
+// LayoutSettings defines how modules are arranged onscreen. The default grid layout
+// uses wtf.grid and every module's top, left, width and height. The auto layout only
+// needs the modules' order, minimum size and weight:
+//
+//	wtf:
+//	  layout:
+//	    type: auto
+//	    columns: 3
+//	    minColumnWidth: 40
+//	    breakpoints:
+//	      - below: 120
+//	        columns: 1
+//	  mods:
+//	    github:
+//	      position:
+//	        order: 1
+//	        minHeight: 10
+//	        weight: 2
+type LayoutSettings struct {
+	Type string
+
+	// Breakpoints are sorted from the narrowest to the widest
+	Breakpoints    []Breakpoint
+	MaxColumns     int
+	MinColumnWidth int
+}
+
+// NewLayoutSettingsFromYAML creates and returns the layout settings defined in wtf.layout
+func NewLayoutSettingsFromYAML(globalConfig *config.Config) *LayoutSettings {
+	layout := &LayoutSettings{
+		Type:           globalConfig.UString(layoutPath+".type", GridLayout),
+		Breakpoints:    []Breakpoint{},
+		MaxColumns:     globalConfig.UInt(layoutPath+".columns", defaultMaxColumns),
+		MinColumnWidth: globalConfig.UInt(layoutPath+".minColumnWidth", defaultMinColumnWidth),
+	}
+
+	for idx := range globalConfig.UList(layoutPath + ".breakpoints") {
+		path := fmt.Sprintf("%s.breakpoints.%d", layoutPath, idx)
+
+		layout.Breakpoints = append(layout.Breakpoints, Breakpoint{
+			Below:   globalConfig.UInt(path+".below", 0),
+			Columns: atLeastOne(globalConfig.UInt(path+".columns", 1)),
+		})
+	}
+
+	sort.SliceStable(layout.Breakpoints, func(i, j int) bool {
+		return layout.Breakpoints[i].Below < layout.Breakpoints[j].Below
+	})
+
+	return layout
+}
+
+// InvalidLayoutColumns returns the paths of the column counts in wtf.layout that are less
+// than one. The layout uses a single column in their place
+func InvalidLayoutColumns(globalConfig *config.Config) []string {
+	paths := []string{}
+
+	if globalConfig.UInt(layoutPath+".columns", defaultMaxColumns) < 1 {
+		paths = append(paths, layoutPath+".columns")
+	}
+
+	for idx := range globalConfig.UList(layoutPath + ".breakpoints") {
+		path := fmt.Sprintf("%s.breakpoints.%d.columns", layoutPath, idx)
+
+		if globalConfig.UInt(path, 1) < 1 {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// IsAuto returns TRUE if modules are laid out automatically, FALSE if they are placed
+// on the grid
+func (layout *LayoutSettings) IsAuto() bool {
+	return layout.Type == AutoLayout
+}
+
+// Columns returns the number of columns the auto layout uses for a terminal of the given
+// width: the columns of the narrowest breakpoint it is below, or as many columns of the
+// minimum width as fit, up to the maximum
+func (layout *LayoutSettings) Columns(width int) int {
+	for _, breakpoint := range layout.Breakpoints {
+		if width < breakpoint.Below {
+			return breakpoint.Columns
+		}
+	}
+
+	columns := layout.MaxColumns
+	if layout.MinColumnWidth > 0 && width/layout.MinColumnWidth < columns {
+		columns = width / layout.MinColumnWidth
+	}
+
+	if columns < 1 {
+		return 1
+	}
+
+	return columns
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func atLeastOne(val int) int {
+	if val < 1 {
+		return 1
+	}
+
+	return val
+}
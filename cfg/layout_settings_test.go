@@ -0,0 +1,101 @@
+package cfg
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewLayoutSettingsFromYAML(t *testing.T) {
+	globalConfig, _ := config.ParseYaml(`
+wtf:
+  layout:
+    type: auto
+    columns: 4
+    minColumnWidth: 50
+    breakpoints:
+      - below: 200
+        columns: 2
+      - below: 120
+        columns: 1
+`)
+
+	layout := NewLayoutSettingsFromYAML(globalConfig)
+
+	assert.True(t, layout.IsAuto())
+	assert.Equal(t, []Breakpoint{{Below: 120, Columns: 1}, {Below: 200, Columns: 2}}, layout.Breakpoints)
+
+	zeroConfig, _ := config.ParseYaml(`
+wtf:
+  layout:
+    columns: 0
+    breakpoints:
+      - below: 100
+        columns: 0
+      - below: 200
+        columns: -2
+`)
+
+	zero := NewLayoutSettingsFromYAML(zeroConfig)
+
+	assert.Equal(t, []Breakpoint{{Below: 100, Columns: 1}, {Below: 200, Columns: 1}}, zero.Breakpoints)
+	assert.Equal(t, 1, zero.Columns(300))
+	assert.Equal(t,
+		[]string{"wtf.layout.columns", "wtf.layout.breakpoints.0.columns", "wtf.layout.breakpoints.1.columns"},
+		InvalidLayoutColumns(zeroConfig),
+	)
+	assert.Empty(t, InvalidLayoutColumns(globalConfig))
+
+	defaults := NewLayoutSettingsFromYAML(&config.Config{Root: map[string]interface{}{}})
+
+	assert.False(t, defaults.IsAuto())
+	assert.Equal(t, defaultMaxColumns, defaults.MaxColumns)
+	assert.Equal(t, defaultMinColumnWidth, defaults.MinColumnWidth)
+}
+
+func Test_LayoutSettings_Columns(t *testing.T) {
+	layout := &LayoutSettings{
+		Type:           AutoLayout,
+		Breakpoints:    []Breakpoint{{Below: 120, Columns: 1}},
+		MaxColumns:     4,
+		MinColumnWidth: 50,
+	}
+
+	tests := []struct {
+		name     string
+		width    int
+		expected int
+	}{
+		{name: "below the breakpoint", width: 119, expected: 1},
+		{name: "at the breakpoint", width: 120, expected: 2},
+		{name: "room for three", width: 160, expected: 3},
+		{name: "capped", width: 400, expected: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, layout.Columns(tt.width))
+		})
+	}
+
+	narrow := &LayoutSettings{MaxColumns: 3, MinColumnWidth: 50}
+	assert.Equal(t, 1, narrow.Columns(20))
+}
+
+func Test_NewPositionSettingsFromYAML_AutoLayout(t *testing.T) {
+	globalConfig, _ := config.ParseYaml("wtf:\n  layout:\n    type: auto\n")
+	moduleConfig, _ := config.ParseYaml("position:\n  order: 2\n  minHeight: 5\n  weight: 3\n")
+
+	pos := NewPositionSettingsFromYAML(moduleConfig, globalConfig)
+
+	assert.Empty(t, pos.Validations.validations)
+	assert.Equal(t, 2, pos.Order)
+	assert.Equal(t, 5, pos.MinHeight)
+	assert.Equal(t, 3, pos.Weight)
+
+	gridConfig, _ := config.ParseYaml("wtf:\n  grid:\n    columns: [10]\n")
+	pos = NewPositionSettingsFromYAML(moduleConfig, gridConfig)
+
+	assert.True(t, pos.Validations.validations["top"].HasError())
+}
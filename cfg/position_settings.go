@@ -16,15 +16,35 @@ type PositionSettings struct {
 	Left   int
 	Top    int
 	Width  int
+
+	// These are used by the auto layout instead of the grid coordinates
+	MinHeight int
+	MinWidth  int
+	Order     int
+	Weight    int
 }
 
-// NewPositionSettingsFromYAML creates and returns a new instance of cfg.Position
-func NewPositionSettingsFromYAML(moduleConfig *config.Config) PositionSettings {
+// NewPositionSettingsFromYAML creates and returns a new instance of cfg.Position. The
+// grid coordinates are only validated when the grid layout is used
+func NewPositionSettingsFromYAML(moduleConfig *config.Config, globalConfig *config.Config) PositionSettings {
 	var currVal int
 	var err error
 
 	validations := NewValidations()
 
+	autoPos := PositionSettings{
+		Validations: validations,
+
+		MinHeight: moduleConfig.UInt(positionPath+".minHeight", 0),
+		MinWidth:  moduleConfig.UInt(positionPath+".minWidth", 0),
+		Order:     moduleConfig.UInt(positionPath+".order", 0),
+		Weight:    moduleConfig.UInt(positionPath+".weight", 1),
+	}
+
+	if NewLayoutSettingsFromYAML(globalConfig).IsAuto() {
+		return autoPos
+	}
+
 	// Parse the positional data from the config data
 	currVal, err = moduleConfig.Int(positionPath + ".top")
 	validations.append("top", newPositionValidation("top", currVal, err))
@@ -45,6 +65,11 @@ func NewPositionSettingsFromYAML(moduleConfig *config.Config) PositionSettings {
 		Left:   validations.intValueFor("left"),
 		Width:  validations.intValueFor("width"),
 		Height: validations.intValueFor("height"),
+
+		MinHeight: autoPos.MinHeight,
+		MinWidth:  autoPos.MinWidth,
+		Order:     autoPos.Order,
+		Weight:    autoPos.Weight,
 	}
 
 	return pos
@@ -19,6 +19,7 @@ func Test_Attributes(t *testing.T) {
 	assert.EqualError(t, posVal.Error(), "Busted")
 	assert.Equal(t, true, posVal.HasError())
 	assert.Equal(t, -3, posVal.IntValue())
+	assert.Equal(t, "top", posVal.Name())
 
 	assert.Contains(t, posVal.String(), "Invalid")
 	assert.Contains(t, posVal.String(), "top")
@@ -0,0 +1,287 @@
+package cfg
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// secretsFileName is the name of the file store's file in the config directory
+	secretsFileName = "secrets.enc"
+
+	// SecretPassphraseEnv is the environment variable the file store reads its passphrase from
+	SecretPassphraseEnv = "WTF_SECRET_PASSPHRASE"
+
+	saltLength = 16
+	keyLength  = 32
+)
+
+var (
+	secretPassphrase      string
+	secretPassphraseMutex sync.Mutex
+)
+
+// The key that was last derived, and the passphrase and salt it was derived from. scrypt
+// is slow by design, so the key is only derived again if either of them changes
+var (
+	derivedKey      []byte
+	derivedKeyPass  string
+	derivedKeySalt  []byte
+	derivedKeyMutex sync.Mutex
+)
+
+// SetSecretPassphrase sets the passphrase that the file secret store encrypts its file
+// with, for when it is not set in the WTF_SECRET_PASSPHRASE environment variable
+func SetSecretPassphrase(passphrase string) {
+	secretPassphraseMutex.Lock()
+	defer secretPassphraseMutex.Unlock()
+
+	secretPassphrase = passphrase
+}
+
+// SecretPassphraseRequired returns TRUE if the store needs a passphrase and none has
+// been set, so that commands can prompt for one before using it
+func SecretPassphraseRequired(store SecretStore) bool {
+	if _, ok := store.(*fileSecretStore); !ok {
+		return false
+	}
+
+	return passphrase() == ""
+}
+
+// fileSecretStore keeps secrets in a file in the config directory, encrypted with
+// AES-256-GCM under a key derived from a passphrase with scrypt. The file is the salt,
+// followed by the nonce, followed by the encrypted JSON map of services to secrets
+type fileSecretStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+func newFileSecretStore(path string) *fileSecretStore {
+	return &fileSecretStore{path: path}
+}
+
+func (store *fileSecretStore) Delete(service string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	secrets, salt, err := store.read()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := secrets[service]; !ok {
+		return ErrSecretNotFound
+	}
+	delete(secrets, service)
+
+	return store.write(secrets, salt)
+}
+
+func (store *fileSecretStore) Get(service string) (*Secret, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	secrets, _, err := store.read()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, ok := secrets[service]
+	if !ok {
+		return nil, ErrSecretNotFound
+	}
+
+	secret.Service = service
+	secret.Store = store.Name()
+
+	return &secret, nil
+}
+
+func (store *fileSecretStore) List() ([]string, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	secrets, _, err := store.read()
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]string, 0, len(secrets))
+	for service := range secrets {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	return services, nil
+}
+
+func (store *fileSecretStore) Name() string {
+	return "file"
+}
+
+func (store *fileSecretStore) Store(secret *Secret) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	secrets, salt, err := store.read()
+	if err != nil {
+		return err
+	}
+
+	secrets[secret.Service] = Secret{Secret: secret.Secret, Username: secret.Username}
+
+	return store.write(secrets, salt)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// read decrypts the store's file, and returns its secrets and the salt it was encrypted
+// with. A file that does not exist yet holds no secrets, and has no salt
+func (store *fileSecretStore) read() (map[string]Secret, []byte, error) {
+	secrets := map[string]Secret{}
+
+	data, err := os.ReadFile(store.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return secrets, nil, nil
+		}
+
+		return nil, nil, err
+	}
+
+	if len(data) < saltLength {
+		return nil, nil, fmt.Errorf("%s is not a secrets file", store.path)
+	}
+
+	salt := data[:saltLength]
+
+	gcm, err := newCipher(salt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data = data[saltLength:]
+	if len(data) < gcm.NonceSize() {
+		return nil, nil, fmt.Errorf("%s is not a secrets file", store.path)
+	}
+
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decrypting %s: wrong passphrase or corrupt file", store.path)
+	}
+
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, nil, fmt.Errorf("decoding %s: %w", store.path, err)
+	}
+
+	return secrets, salt, nil
+}
+
+// write encrypts the secrets with a new nonce and replaces the store's file. The salt
+// that the file was encrypted with is kept, so that the key doesn't have to be derived
+// again. Without one, a new salt is made
+func (store *fileSecretStore) write(secrets map[string]Secret, salt []byte) error {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+
+	if len(salt) != saltLength {
+		salt = make([]byte, saltLength)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return err
+		}
+	}
+
+	gcm, err := newCipher(salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	data := append(append([]byte{}, salt...), nonce...)
+	data = gcm.Seal(data, nonce, plaintext, nil)
+
+	if err := os.MkdirAll(filepath.Dir(store.path), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmpPath := store.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, store.path)
+}
+
+// newCipher derives the encryption key from the passphrase and salt
+func newCipher(salt []byte) (cipher.AEAD, error) {
+	pass := passphrase()
+	if pass == "" {
+		return nil, errors.New("the file secret store requires a passphrase, set " + SecretPassphraseEnv)
+	}
+
+	key, err := deriveKey(pass, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// deriveKey returns the key derived from the passphrase and salt with scrypt, reusing the
+// last key if they're the same
+func deriveKey(pass string, salt []byte) ([]byte, error) {
+	derivedKeyMutex.Lock()
+	defer derivedKeyMutex.Unlock()
+
+	if derivedKey != nil && pass == derivedKeyPass && bytes.Equal(salt, derivedKeySalt) {
+		return derivedKey, nil
+	}
+
+	key, err := scrypt.Key([]byte(pass), salt, 1<<15, 8, 1, keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey = key
+	derivedKeyPass = pass
+	derivedKeySalt = append([]byte{}, salt...)
+
+	return key, nil
+}
+
+// passphrase returns the passphrase set with SetSecretPassphrase, falling back to the
+// WTF_SECRET_PASSPHRASE environment variable
+func passphrase() string {
+	secretPassphraseMutex.Lock()
+	defer secretPassphraseMutex.Unlock()
+
+	if secretPassphrase != "" {
+		return secretPassphrase
+	}
+
+	return os.Getenv(SecretPassphraseEnv)
+}
@@ -0,0 +1,394 @@
+package cfg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker-credential-helpers/client"
+	"github.com/docker/docker-credential-helpers/credentials"
+	"github.com/olebedev/config"
+)
+
+const (
+	// envSecretPrefix is the prefix of the environment variables the env store reads
+	envSecretPrefix = "WTF_SECRET_"
+
+	// passSecretDir is the folder in the password store that the pass store keeps secrets in
+	passSecretDir = "wtf"
+)
+
+// ErrSecretNotFound is returned when a secret store has no secret for a service
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore is a backend that secrets are saved to and loaded from. It is chosen by
+// the `secretStore` setting, which can be set globally in `wtf.secretStore` or for a
+// single module in its own `secretStore`:
+//
+//	env             environment variables, i.e.: WTF_SECRET_GITHUB for the github service
+//	file            a passphrase-encrypted file in the config directory
+//	pass            the pass password manager, under wtf/<service>
+//	exec            the command in `secretCommand`, run with the service appended
+//	anything else   the docker-credential-<secretStore> credential helper
+type SecretStore interface {
+	// Delete removes the secret for the service from the store
+	Delete(service string) error
+
+	// Get returns the secret for the service, or ErrSecretNotFound if there isn't one
+	Get(service string) (*Secret, error)
+
+	// List returns the services that the store has secrets for
+	List() ([]string, error)
+
+	// Name returns the name of the store, as it is set in the `secretStore` setting
+	Name() string
+
+	// Store saves the secret in the store, replacing any existing secret for its service
+	Store(secret *Secret) error
+}
+
+// NewSecretStore returns the secret store configured for the named module, falling back
+// to the global secret store, or nil if no secret store is configured
+func NewSecretStore(globalConfig *config.Config, moduleName string) SecretStore {
+	storePath := "wtf.secretStore"
+	commandPath := "wtf.secretCommand"
+
+	if moduleName != "" {
+		modulePath := "wtf.mods." + moduleName
+		if _, err := globalConfig.String(modulePath + ".secretStore"); err == nil {
+			storePath = modulePath + ".secretStore"
+		}
+		if _, err := globalConfig.Get(modulePath + ".secretCommand"); err == nil {
+			commandPath = modulePath + ".secretCommand"
+		}
+	}
+
+	secretStore := globalConfig.UString(storePath, "(none)")
+
+	switch secretStore {
+	case "(none)":
+		return nil
+	case "env":
+		return &envSecretStore{}
+	case "exec":
+		return &execSecretStore{command: secretCommand(globalConfig, commandPath)}
+	case "file":
+		configDir, _ := WtfConfigDir()
+		return newFileSecretStore(filepath.Join(configDir, secretsFileName))
+	case "pass":
+		return &passSecretStore{}
+	case "":
+		switch runtime.GOOS {
+		case "windows":
+			secretStore = "winrt"
+		case "darwin":
+			secretStore = "osxkeychain"
+		default:
+			secretStore = "secretservice"
+		}
+	}
+
+	return &credentialHelperStore{
+		store:  secretStore,
+		runner: client.NewShellProgramFunc("docker-credential-" + secretStore),
+	}
+}
+
+// secretCommand reads the exec store's command, which can be written as a list of
+// arguments or as a single string
+func secretCommand(globalConfig *config.Config, path string) []string {
+	if args, err := globalConfig.List(path); err == nil {
+		command := []string{}
+		for _, arg := range args {
+			command = append(command, fmt.Sprint(arg))
+		}
+
+		return command
+	}
+
+	return strings.Fields(globalConfig.UString(path, ""))
+}
+
+/* -------------------- Credential helpers -------------------- */
+
+// credentialHelperStore keeps secrets with a docker-credential-helpers program
+type credentialHelperStore struct {
+	store  string
+	runner client.ProgramFunc
+}
+
+func (helper *credentialHelperStore) Delete(service string) error {
+	if err := client.Erase(helper.runner, service); err != nil {
+		return fmt.Errorf("delete %v from %v: %w", service, helper.store, err)
+	}
+
+	return nil
+}
+
+func (helper *credentialHelperStore) Get(service string) (*Secret, error) {
+	cred, err := client.Get(helper.runner, service)
+	if err != nil {
+		if credentials.IsErrCredentialsNotFound(err) {
+			return nil, ErrSecretNotFound
+		}
+
+		return nil, fmt.Errorf("get %v from %v: %w", service, helper.store, err)
+	}
+
+	return &Secret{
+		Service:  cred.ServerURL,
+		Secret:   cred.Secret,
+		Username: cred.Username,
+		Store:    helper.store,
+	}, nil
+}
+
+func (helper *credentialHelperStore) List() ([]string, error) {
+	creds, err := client.List(helper.runner)
+	if err != nil {
+		return nil, fmt.Errorf("list %v: %w", helper.store, err)
+	}
+
+	return sortedKeys(creds), nil
+}
+
+func (helper *credentialHelperStore) Name() string {
+	return helper.store
+}
+
+func (helper *credentialHelperStore) Store(secret *Secret) error {
+	cred := &credentials.Credentials{
+		ServerURL: secret.Service,
+		Username:  secret.Username,
+		Secret:    secret.Secret,
+	}
+
+	// docker-credential requires a username, but it isn't necessary for
+	// all services. Use a default if a username was not set.
+	if cred.Username == "" {
+		cred.Username = "default"
+	}
+
+	if err := client.Store(helper.runner, cred); err != nil {
+		return fmt.Errorf("store %v: %w", helper.store, err)
+	}
+
+	return nil
+}
+
+/* -------------------- Environment variables -------------------- */
+
+var envUnsafeChars = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// envSecretStore reads secrets from environment variables. The variable for a service is
+// its name, upper-cased, with every run of other characters replaced by an underscore,
+// i.e.: WTF_SECRET_HTTPS_GITHUB_COM for https://github.com. It is read-only
+type envSecretStore struct{}
+
+func (store *envSecretStore) Delete(_ string) error {
+	return errors.New("the env secret store is read-only, unset the environment variable instead")
+}
+
+func (store *envSecretStore) Get(service string) (*Secret, error) {
+	secret, ok := os.LookupEnv(envSecretName(service))
+	if !ok {
+		return nil, ErrSecretNotFound
+	}
+
+	return &Secret{Service: service, Secret: secret, Store: store.Name()}, nil
+}
+
+// List returns the services of the WTF_SECRET_ variables, lower-cased, i.e.: github for
+// WTF_SECRET_GITHUB. The variables' names can't hold every character a service can, so
+// https://github.com is listed as https_github_com, which Get finds the same secret by
+func (store *envSecretStore) List() ([]string, error) {
+	services := []string{}
+
+	for _, env := range os.Environ() {
+		name := strings.SplitN(env, "=", 2)[0]
+		if !strings.HasPrefix(name, envSecretPrefix) || name == SecretPassphraseEnv {
+			continue
+		}
+
+		if service := strings.ToLower(strings.TrimPrefix(name, envSecretPrefix)); service != "" {
+			services = append(services, service)
+		}
+	}
+	sort.Strings(services)
+
+	return services, nil
+}
+
+func (store *envSecretStore) Name() string {
+	return "env"
+}
+
+func (store *envSecretStore) Store(secret *Secret) error {
+	return fmt.Errorf("the env secret store is read-only, set %s instead", envSecretName(secret.Service))
+}
+
+func envSecretName(service string) string {
+	return envSecretPrefix + strings.Trim(envUnsafeChars.ReplaceAllString(strings.ToUpper(service), "_"), "_")
+}
+
+/* -------------------- Exec -------------------- */
+
+// execSecretStore reads secrets by running a command with the service appended to its
+// arguments, and using what it writes to stdout as the secret. It is read-only
+type execSecretStore struct {
+	command []string
+}
+
+func (store *execSecretStore) Delete(_ string) error {
+	return errors.New("the exec secret store is read-only")
+}
+
+func (store *execSecretStore) Get(service string) (*Secret, error) {
+	if len(store.command) == 0 {
+		return nil, errors.New("the exec secret store requires secretCommand to be configured")
+	}
+
+	args := append(append([]string{}, store.command[1:]...), service)
+
+	// #nosec G204 -- the command comes from the user's own configuration
+	cmd := exec.Command(store.command[0], args...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("get %v from %v: %w: %s", service, store.command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	secret := strings.TrimRight(string(out), "\r\n")
+	if secret == "" {
+		return nil, ErrSecretNotFound
+	}
+
+	return &Secret{Service: service, Secret: secret, Store: store.Name()}, nil
+}
+
+func (store *execSecretStore) List() ([]string, error) {
+	return nil, errors.New("the exec secret store can't list its secrets")
+}
+
+func (store *execSecretStore) Name() string {
+	return "exec"
+}
+
+func (store *execSecretStore) Store(_ *Secret) error {
+	return errors.New("the exec secret store is read-only")
+}
+
+/* -------------------- pass -------------------- */
+
+// passSecretStore keeps secrets in the pass password manager, one entry per service
+// under the wtf folder. Services are path-escaped, so https://github.com is saved as
+// wtf/https:%2F%2Fgithub.com
+type passSecretStore struct{}
+
+func (store *passSecretStore) Delete(service string) error {
+	_, err := store.run(nil, "rm", "--force", store.entry(service))
+	return err
+}
+
+func (store *passSecretStore) Get(service string) (*Secret, error) {
+	out, err := store.run(nil, "show", store.entry(service))
+	if err != nil {
+		if strings.Contains(err.Error(), "is not in the password store") {
+			return nil, ErrSecretNotFound
+		}
+
+		return nil, err
+	}
+
+	// By convention the password is the first line, and anything after it is metadata
+	secret := strings.SplitN(out, "\n", 2)[0]
+
+	return &Secret{Service: service, Secret: secret, Store: store.Name()}, nil
+}
+
+func (store *passSecretStore) List() ([]string, error) {
+	storeDir := os.Getenv("PASSWORD_STORE_DIR")
+	if storeDir == "" {
+		storeDir = "~/.password-store"
+	}
+
+	storeDir, err := expandHomeDir(storeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(storeDir, passSecretDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+
+		return nil, err
+	}
+
+	services := []string{}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".gpg")
+		if entry.IsDir() || name == entry.Name() {
+			continue
+		}
+
+		if service, err := url.PathUnescape(name); err == nil {
+			services = append(services, service)
+		}
+	}
+	sort.Strings(services)
+
+	return services, nil
+}
+
+func (store *passSecretStore) Name() string {
+	return "pass"
+}
+
+func (store *passSecretStore) Store(secret *Secret) error {
+	_, err := store.run(strings.NewReader(secret.Secret+"\n"), "insert", "--multiline", "--force", store.entry(secret.Service))
+	return err
+}
+
+func (store *passSecretStore) entry(service string) string {
+	return passSecretDir + "/" + url.PathEscape(service)
+}
+
+func (store *passSecretStore) run(stdin *strings.Reader, args ...string) (string, error) {
+	cmd := exec.Command("pass", args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("pass %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return string(out), nil
+}
+
+func sortedKeys(items map[string]string) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
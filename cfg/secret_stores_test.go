@@ -0,0 +1,129 @@
+package cfg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewSecretStore(t *testing.T) {
+	globalConfig, _ := config.ParseYaml(`
+wtf:
+  secretStore: env
+  mods:
+    github:
+      secretStore: exec
+      secretCommand: ["echo", "token"]
+    jira:
+      secretStore: pass
+    todo:
+      enabled: true
+`)
+
+	tests := []struct {
+		name       string
+		moduleName string
+		expected   string
+	}{
+		{name: "global store", moduleName: "", expected: "env"},
+		{name: "module without a store", moduleName: "todo", expected: "env"},
+		{name: "module with a store", moduleName: "jira", expected: "pass"},
+		{name: "module with a command", moduleName: "github", expected: "exec"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewSecretStore(globalConfig, tt.moduleName).Name())
+		})
+	}
+
+	assert.Equal(t, []string{"echo", "token"}, NewSecretStore(globalConfig, "github").(*execSecretStore).command)
+
+	noStore, _ := config.ParseYaml("wtf:\n  refreshInterval: 1")
+	assert.Nil(t, NewSecretStore(noStore, "todo"))
+
+	helper, _ := config.ParseYaml("wtf:\n  secretStore: gopass")
+	assert.Equal(t, "gopass", NewSecretStore(helper, "").Name())
+}
+
+func Test_envSecretStore(t *testing.T) {
+	t.Setenv("WTF_SECRET_HTTPS_GITHUB_COM_API", "sekrit")
+	t.Setenv(SecretPassphraseEnv, "correct horse")
+
+	store := &envSecretStore{}
+
+	secret, err := store.Get("https://github.com/api")
+	assert.NoError(t, err)
+	assert.Equal(t, "sekrit", secret.Secret)
+
+	_, err = store.Get("gitlab")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	services, err := store.List()
+	assert.NoError(t, err)
+	assert.Contains(t, services, "https_github_com_api")
+	assert.NotContains(t, services, "passphrase")
+
+	// Listed services find the same secrets
+	secret, err = store.Get("https_github_com_api")
+	assert.NoError(t, err)
+	assert.Equal(t, "sekrit", secret.Secret)
+
+	assert.Error(t, store.Store(&Secret{Service: "gitlab", Secret: "x"}))
+}
+
+func Test_execSecretStore(t *testing.T) {
+	store := &execSecretStore{command: []string{"echo", "token for"}}
+
+	secret, err := store.Get("github")
+	assert.NoError(t, err)
+	assert.Equal(t, "token for github", secret.Secret)
+	assert.Equal(t, "exec", secret.Store)
+
+	_, err = (&execSecretStore{}).Get("github")
+	assert.Error(t, err)
+}
+
+func Test_fileSecretStore(t *testing.T) {
+	t.Setenv(SecretPassphraseEnv, "correct horse")
+
+	store := newFileSecretStore(filepath.Join(t.TempDir(), secretsFileName))
+
+	services, err := store.List()
+	assert.NoError(t, err)
+	assert.Empty(t, services)
+
+	assert.NoError(t, store.Store(&Secret{Service: "github", Secret: "abc", Username: "me"}))
+	data, err := os.ReadFile(store.path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Store(&Secret{Service: "circleci", Secret: "def"}))
+
+	// The file keeps its salt, so the key is only derived once
+	rewritten, err := os.ReadFile(store.path)
+	assert.NoError(t, err)
+	assert.Equal(t, data[:saltLength], rewritten[:saltLength])
+	assert.Equal(t, data[:saltLength], derivedKeySalt)
+
+	secret, err := store.Get("github")
+	assert.NoError(t, err)
+	assert.Equal(t, &Secret{Service: "github", Secret: "abc", Username: "me", Store: "file"}, secret)
+
+	services, err = store.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"circleci", "github"}, services)
+
+	assert.NoError(t, store.Delete("github"))
+	assert.ErrorIs(t, store.Delete("github"), ErrSecretNotFound)
+
+	_, err = store.Get("github")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	t.Setenv(SecretPassphraseEnv, "wrong")
+
+	_, err = store.Get("circleci")
+	assert.Error(t, err)
+}
@@ -2,11 +2,7 @@ package cfg
 
 import (
 	"errors"
-	"fmt"
-	"runtime"
 
-	"github.com/docker/docker-credential-helpers/client"
-	"github.com/docker/docker-credential-helpers/credentials"
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/logger"
 )
@@ -73,6 +69,9 @@ type SecretLoadParams struct {
 //
 //	Ideally, the individual module documentation would describe the
 //	SERVICE name to use to save the secret.
+//
+// The secret is loaded from the module's own `secretStore` if it has one,
+// and from the global wtf.secretStore if it doesn't. See SecretStore.
 func ModuleSecret(name string, globalConfig *config.Config, secret *string) *SecretLoadParams {
 	return &SecretLoadParams{
 		name:         name,
@@ -92,6 +91,7 @@ func (slp *SecretLoadParams) Service(service string) *SecretLoadParams {
 func (slp *SecretLoadParams) Load() {
 	configureSecret(
 		slp.globalConfig,
+		slp.name,
 		slp.service,
 		slp.secret,
 	)
@@ -106,6 +106,7 @@ type Secret struct {
 
 func configureSecret(
 	globalConfig *config.Config,
+	moduleName string,
 	service string,
 	secret *string,
 ) {
@@ -122,15 +123,15 @@ func configureSecret(
 		return
 	}
 
-	cred, err := FetchSecret(globalConfig, service)
+	cred, err := fetchSecret(NewSecretStore(globalConfig, moduleName), service)
 
 	if err != nil {
-		logger.Log(fmt.Sprintf("Loading secret failed: %s", err.Error()))
+		logger.ForModule(moduleName, "").Warn("loading secret failed", "service", service, "error", err)
 		return
 	}
 
 	if cred == nil {
-		// No secret store configued.
+		// No secret store configued, or no secret for the service.
 		return
 	}
 
@@ -144,81 +145,30 @@ func configureSecret(
 // of the module.  nil is returned if the secretStore global property is not
 // present or the secret is not found in that store.
 func FetchSecret(globalConfig *config.Config, service string) (*Secret, error) {
-	prog := newProgram(globalConfig)
-
-	if prog == nil {
-		// No secret store configured.
-		return nil, nil
-	}
-
-	cred, err := client.Get(prog.runner, service)
-
-	if err != nil {
-		return nil, fmt.Errorf("get %v from %v: %w", service, prog.store, err)
-	}
-
-	return &Secret{
-		Service:  cred.ServerURL,
-		Secret:   cred.Secret,
-		Username: cred.Username,
-		Store:    prog.store,
-	}, nil
+	return fetchSecret(NewSecretStore(globalConfig, ""), service)
 }
 
+// StoreSecret saves the secret in the store configured by the secretStore global property
 func StoreSecret(globalConfig *config.Config, secret *Secret) error {
-	prog := newProgram(globalConfig)
+	store := NewSecretStore(globalConfig, "")
 
-	if prog == nil {
+	if store == nil {
 		return errors.New("cannot store secrets: wtf.secretStore is not configured")
 	}
 
-	cred := &credentials.Credentials{
-		ServerURL: secret.Service,
-		Username:  secret.Username,
-		Secret:    secret.Secret,
-	}
-
-	// docker-credential requires a username, but it isn't necessary for
-	// all services. Use a default if a username was not set.
-	if cred.Username == "" {
-		cred.Username = "default"
-	}
-
-	err := client.Store(prog.runner, cred)
-
-	if err != nil {
-		return fmt.Errorf("store %v: %w", prog.store, err)
-	}
-
-	return nil
-}
-
-type program struct {
-	store  string
-	runner client.ProgramFunc
+	return store.Store(secret)
 }
 
-func newProgram(globalConfig *config.Config) *program {
-	secretStore := globalConfig.UString("wtf.secretStore", "(none)")
-
-	if secretStore == "(none)" {
-		return nil
+func fetchSecret(store SecretStore, service string) (*Secret, error) {
+	if store == nil {
+		// No secret store configured.
+		return nil, nil
 	}
 
-	if secretStore == "" {
-		switch runtime.GOOS {
-		case "windows":
-			secretStore = "winrt"
-		case "darwin":
-			secretStore = "osxkeychain"
-		default:
-			secretStore = "secretservice"
-		}
-
+	cred, err := store.Get(service)
+	if errors.Is(err, ErrSecretNotFound) {
+		return nil, nil
 	}
 
-	return &program{
-		secretStore,
-		client.NewShellProgramFunc("docker-credential-" + secretStore),
-	}
+	return cred, err
 }
@@ -0,0 +1,329 @@
+package cfg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/olebedev/config"
+)
+
+const (
+	// DefaultThemeName is the name of the theme used when wtf.theme is not set
+	DefaultThemeName = "default"
+
+	// MonochromeThemeName is the name of the theme forced by the NO_COLOR environment variable
+	MonochromeThemeName = "monochrome"
+
+	// ThemesDirName is the name of the directory in the config directory that theme files
+	// are read from
+	ThemesDirName = "themes"
+
+	themeFileExt = ".yml"
+)
+
+// builtinThemes are the themes that are always available, even without theme files
+var builtinThemes = map[string]func() ColorTheme{
+	DefaultThemeName:    NewDefaultColorTheme,
+	"high-contrast":     newHighContrastColorTheme,
+	"light":             newLightColorTheme,
+	MonochromeThemeName: newMonochromeColorTheme,
+}
+
+var (
+	themeOverride      string
+	themeOverrideMutex sync.Mutex
+)
+
+// NoColor returns TRUE if the NO_COLOR environment variable is set, in which case
+// wtfutil should not display any colors. See https://no-color.org
+func NoColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// SetTheme sets the theme that modules are colored with, in place of wtf.theme. It is
+// used to switch themes while wtfutil is running
+func SetTheme(name string) {
+	themeOverrideMutex.Lock()
+	defer themeOverrideMutex.Unlock()
+
+	themeOverride = name
+}
+
+// ThemeName returns the name of the theme that modules are colored with: the monochrome
+// theme if NO_COLOR is set, otherwise the theme set with SetTheme or in wtf.theme
+func ThemeName(globalConfig *config.Config) string {
+	if NoColor() {
+		return MonochromeThemeName
+	}
+
+	themeOverrideMutex.Lock()
+	defer themeOverrideMutex.Unlock()
+
+	if themeOverride != "" {
+		return themeOverride
+	}
+
+	return globalConfig.UString("wtf.theme", DefaultThemeName)
+}
+
+// ThemeNames returns the names of the built-in themes and the theme files in the themes
+// directory, sorted, with the default theme first
+func ThemeNames() []string {
+	names := map[string]bool{}
+	for name := range builtinThemes {
+		names[name] = true
+	}
+
+	if themesDir, err := ThemesDir(); err == nil {
+		files, _ := filepath.Glob(filepath.Join(themesDir, "*"+themeFileExt))
+		for _, file := range files {
+			names[strings.TrimSuffix(filepath.Base(file), themeFileExt)] = true
+		}
+	}
+
+	sorted := []string{}
+	for name := range names {
+		if name != DefaultThemeName {
+			sorted = append(sorted, name)
+		}
+	}
+	sort.Strings(sorted)
+
+	return append([]string{DefaultThemeName}, sorted...)
+}
+
+// ThemesDir returns the absolute path to the directory theme files are read from
+func ThemesDir() (string, error) {
+	configDir, err := WtfConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, ThemesDirName), nil
+}
+
+// LoadColorTheme returns the named theme. A theme file in the themes directory, i.e.:
+// ~/.config/wtf/themes/solarized.yml, takes precedence over a built-in theme of the same
+// name. Theme files use the same keys as wtf.colors:
+//
+//	colors:
+//	  background: transparent
+//	  border:
+//	    error: red
+//	    focusable: blue
+//	    focused: orange
+//	    normal: gray
+//	  checked: gray
+//	  highlight:
+//	    back: green
+//	    fore: black
+//	  label: lightblue
+//	  rows:
+//	    even: white
+//	    evenBackground: transparent
+//	    odd: lightblue
+//	    oddBackground: transparent
+//	  subheading: red
+//	  text: white
+//	  title: green
+//
+// Any colors a theme file leaves out are taken from the default theme
+func LoadColorTheme(name string) (ColorTheme, error) {
+	if themesDir, err := ThemesDir(); err == nil {
+		path := filepath.Join(themesDir, name+themeFileExt)
+
+		if _, err := os.Stat(path); err == nil {
+			themeConfig, err := config.ParseYamlFile(path)
+			if err != nil {
+				return NewDefaultColorTheme(), fmt.Errorf("loading theme %q: %w", name, err)
+			}
+
+			colors, err := themeConfig.Get("colors")
+			if err != nil {
+				return NewDefaultColorTheme(), fmt.Errorf("loading theme %q: %s has no colors", name, path)
+			}
+
+			return colorThemeFromConfig(colors, NewDefaultColorTheme()), nil
+		}
+	}
+
+	if builtin, ok := builtinThemes[name]; ok {
+		return builtin(), nil
+	}
+
+	return NewDefaultColorTheme(), fmt.Errorf("there is no theme named %q", name)
+}
+
+// NewColorThemeFromYAML returns the colors a module is displayed with: the theme's colors,
+// overridden by any set in wtf.colors, overridden by any set in the module's own colors
+func NewColorThemeFromYAML(moduleConfig *config.Config, globalConfig *config.Config) ColorTheme {
+	// An unknown theme falls back to the default theme. `wtfutil validate` reports it
+	theme, _ := LoadColorTheme(ThemeName(globalConfig))
+
+	if NoColor() {
+		return theme
+	}
+
+	if colorsConfig, err := globalConfig.Get("wtf.colors"); err == nil {
+		theme = colorThemeFromConfig(colorsConfig, theme)
+	}
+
+	if colorsConfig, err := moduleConfig.Get("colors"); err == nil {
+		theme = colorThemeFromConfig(colorsConfig, theme)
+	}
+
+	return theme
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// colorThemeFromConfig returns the base theme with the colors that are set in the
+// colors config replacing its own
+func colorThemeFromConfig(colors *config.Config, base ColorTheme) ColorTheme {
+	theme := base
+
+	theme.BorderTheme.Error = colors.UString("border.error", base.BorderTheme.Error)
+	theme.BorderTheme.Focusable = colors.UString("border.focusable", base.BorderTheme.Focusable)
+	theme.BorderTheme.Focused = colors.UString("border.focused", base.BorderTheme.Focused)
+	theme.BorderTheme.Unfocusable = colors.UString("border.normal", base.BorderTheme.Unfocusable)
+
+	theme.CheckboxTheme.Checked = colors.UString("checked", base.CheckboxTheme.Checked)
+
+	theme.RowTheme.EvenBackground = colors.UString("rows.evenBackground", base.RowTheme.EvenBackground)
+	theme.RowTheme.EvenForeground = colors.UString("rows.even", base.RowTheme.EvenForeground)
+	theme.RowTheme.OddBackground = colors.UString("rows.oddBackground", base.RowTheme.OddBackground)
+	theme.RowTheme.OddForeground = colors.UString("rows.odd", base.RowTheme.OddForeground)
+	theme.RowTheme.HighlightedBackground = colors.UString("highlight.back", base.RowTheme.HighlightedBackground)
+	theme.RowTheme.HighlightedForeground = colors.UString("highlight.fore", base.RowTheme.HighlightedForeground)
+
+	theme.TextTheme.Label = colors.UString("label", base.TextTheme.Label)
+	theme.TextTheme.Subheading = colors.UString("subheading", base.TextTheme.Subheading)
+	theme.TextTheme.Text = colors.UString("text", base.TextTheme.Text)
+	theme.TextTheme.Title = colors.UString("title", base.TextTheme.Title)
+
+	theme.WidgetTheme.Background = colors.UString("background", base.WidgetTheme.Background)
+
+	return theme
+}
+
+// newHighContrastColorTheme is a theme of bright colors on black, with the focused and
+// highlighted elements in reverse
+func newHighContrastColorTheme() ColorTheme {
+	return ColorTheme{
+		BorderTheme: BorderTheme{
+			Error:       "red",
+			Focusable:   "white",
+			Focused:     "yellow",
+			Unfocusable: "gray",
+		},
+
+		CheckboxTheme: CheckboxTheme{
+			Checked: "yellow",
+		},
+
+		RowTheme: RowTheme{
+			EvenBackground: "black",
+			EvenForeground: "white",
+
+			OddBackground: "black",
+			OddForeground: "yellow",
+
+			HighlightedForeground: "black",
+			HighlightedBackground: "yellow",
+		},
+
+		TextTheme: TextTheme{
+			Label:      "aqua",
+			Subheading: "yellow",
+			Text:       "white",
+			Title:      "white",
+		},
+
+		WidgetTheme: WidgetTheme{
+			Background: "black",
+		},
+	}
+}
+
+// newLightColorTheme is a theme for terminals with light backgrounds
+func newLightColorTheme() ColorTheme {
+	return ColorTheme{
+		BorderTheme: BorderTheme{
+			Error:       "darkred",
+			Focusable:   "navy",
+			Focused:     "darkorange",
+			Unfocusable: "silver",
+		},
+
+		CheckboxTheme: CheckboxTheme{
+			Checked: "gray",
+		},
+
+		RowTheme: RowTheme{
+			EvenBackground: "transparent",
+			EvenForeground: "black",
+
+			OddBackground: "transparent",
+			OddForeground: "navy",
+
+			HighlightedForeground: "white",
+			HighlightedBackground: "darkblue",
+		},
+
+		TextTheme: TextTheme{
+			Label:      "darkblue",
+			Subheading: "darkred",
+			Text:       "black",
+			Title:      "darkgreen",
+		},
+
+		WidgetTheme: WidgetTheme{
+			Background: "transparent",
+		},
+	}
+}
+
+// newMonochromeColorTheme uses the terminal's own colors for everything but the
+// selected row
+func newMonochromeColorTheme() ColorTheme {
+	return ColorTheme{
+		BorderTheme: BorderTheme{
+			Error:       "default",
+			Focusable:   "default",
+			Focused:     "default",
+			Unfocusable: "default",
+		},
+
+		CheckboxTheme: CheckboxTheme{
+			Checked: "default",
+		},
+
+		RowTheme: RowTheme{
+			EvenBackground: "default",
+			EvenForeground: "default",
+
+			OddBackground: "default",
+			OddForeground: "default",
+
+			// Without colors, cells with a background are drawn in reverse video, which
+			// keeps the selected row visible
+			HighlightedForeground: "black",
+			HighlightedBackground: "white",
+		},
+
+		TextTheme: TextTheme{
+			Label:      "default",
+			Subheading: "default",
+			Text:       "default",
+			Title:      "default",
+		},
+
+		WidgetTheme: WidgetTheme{
+			Background: "default",
+		},
+	}
+}
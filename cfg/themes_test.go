@@ -0,0 +1,90 @@
+package cfg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeThemeFile(t *testing.T, name, contents string) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	themesDir := filepath.Join(configHome, "wtf", ThemesDirName)
+	assert.NoError(t, os.MkdirAll(themesDir, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(themesDir, name+themeFileExt), []byte(contents), 0600))
+}
+
+func Test_LoadColorTheme(t *testing.T) {
+	writeThemeFile(t, "solarized", `
+colors:
+  background: "#002b36"
+  border:
+    focused: "#b58900"
+  highlight:
+    back: "#268bd2"
+  rows:
+    oddBackground: "#073642"
+`)
+
+	theme, err := LoadColorTheme("solarized")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "#002b36", theme.WidgetTheme.Background)
+	assert.Equal(t, "#b58900", theme.BorderTheme.Focused)
+	assert.Equal(t, "#268bd2", theme.RowTheme.HighlightedBackground)
+	assert.Equal(t, "#073642", theme.RowTheme.OddBackground)
+
+	// Colors the file leaves out come from the default theme
+	assert.Equal(t, "red", theme.BorderTheme.Error)
+
+	light, err := LoadColorTheme("light")
+	assert.NoError(t, err)
+	assert.Equal(t, "black", light.TextTheme.Text)
+
+	missing, err := LoadColorTheme("nope")
+	assert.EqualError(t, err, `there is no theme named "nope"`)
+	assert.Equal(t, NewDefaultColorTheme(), missing)
+
+	assert.Equal(t, []string{"default", "high-contrast", "light", "monochrome", "solarized"}, ThemeNames())
+}
+
+func Test_NewColorThemeFromYAML(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	globalConfig, _ := config.ParseYaml(`
+wtf:
+  theme: light
+  colors:
+    title: purple
+`)
+	moduleConfig, _ := config.ParseYaml("colors:\n  text: yellow\n")
+
+	theme := NewColorThemeFromYAML(moduleConfig, globalConfig)
+
+	assert.Equal(t, "darkblue", theme.TextTheme.Label)
+	assert.Equal(t, "purple", theme.TextTheme.Title)
+	assert.Equal(t, "yellow", theme.TextTheme.Text)
+
+	SetTheme("high-contrast")
+	defer SetTheme("")
+
+	assert.Equal(t, "high-contrast", ThemeName(globalConfig))
+	assert.Equal(t, "aqua", NewColorThemeFromYAML(moduleConfig, globalConfig).TextTheme.Label)
+}
+
+func Test_NewColorThemeFromYAML_NoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	globalConfig, _ := config.ParseYaml("wtf:\n  theme: light\n  colors:\n    title: purple\n")
+	moduleConfig, _ := config.ParseYaml("colors:\n  text: yellow\n")
+
+	theme := NewColorThemeFromYAML(moduleConfig, globalConfig)
+
+	assert.Equal(t, MonochromeThemeName, ThemeName(globalConfig))
+	assert.Equal(t, "default", theme.TextTheme.Title)
+	assert.Equal(t, "default", theme.TextTheme.Text)
+}
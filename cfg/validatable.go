@@ -4,6 +4,7 @@ package cfg
 type Validatable interface {
 	Error() error
 	HasError() bool
-	String() string
 	IntValue() int
+	Name() string
+	String() string
 }
@@ -5,21 +5,25 @@ import (
 	"os"
 	"path/filepath"
 	"runtime/debug"
-	"strings"
 
-	"github.com/chzyer/readline"
 	goFlags "github.com/jessevdk/go-flags"
 	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cache"
 	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/help"
+	"github.com/wtfutil/wtf/render"
+	"github.com/wtfutil/wtf/validate"
 )
 
 // Flags is the container for command line flag data
 type Flags struct {
-	Config  string `short:"c" long:"config" optional:"yes" description:"Path to config file"`
-	Module  string `short:"m" long:"module" optional:"yes" description:"Display info about a specific module, i.e.: 'wtfutil -m=todo'"`
-	Profile bool   `short:"p" long:"profile" optional:"yes" description:"Profile application memory usage"`
-	Version bool   `short:"v" long:"version" description:"Show version info"`
+	All         bool   `long:"all" optional:"yes" description:"Used with the render command to render every enabled module"`
+	Config      string `short:"c" long:"config" optional:"yes" description:"Path to config file"`
+	Format      string `long:"format" optional:"yes" description:"Used with the render command to set the output format: text or json" default:"text"`
+	ListModules bool   `long:"list-modules" optional:"yes" description:"List the module types in this build"`
+	Module      string `short:"m" long:"module" optional:"yes" description:"Display info about a specific module, i.e.: 'wtfutil -m=todo'. Used with the render and secret commands to choose the module"`
+	Profile     bool   `short:"p" long:"profile" optional:"yes" description:"Profile application memory usage"`
+	Version     bool   `short:"v" long:"version" description:"Show version info"`
 	// Work-around go-flags misfeatures. If any sub-command is defined
 	// then `wtf` (no sub-commands, the common usage), is warned about.
 	Opt struct {
@@ -32,12 +36,38 @@ type Flags struct {
 
 var EXTRA = `
 Commands:
-  save-secret <service>
+  save-secret [--module=<name>] <service>
     service      Service URL or module name of secret.
   Save a secret into the secret store. The secret will be prompted for.
   Requires wtf.secretStore to be configured.  See individual modules for
   information on what service and secret means for their configuration,
-  not all modules use secrets.
+  not all modules use secrets. With --module, the module's own secretStore
+  is used instead of wtf.secretStore.
+
+  Secret stores are: env (WTF_SECRET_<SERVICE> environment variables,
+  read-only), file (a file in the config directory encrypted with the
+  WTF_SECRET_PASSPHRASE passphrase, prompted for if unset), pass, exec
+  (runs secretCommand with the service appended, read-only), or the name
+  of any docker-credential-<name> helper.
+
+  list-secrets [--module=<name>]
+  List the services that the secret store has secrets for.
+
+  delete-secret [--module=<name>] <service>
+  Delete the secret for the service from the secret store.
+
+  cache clear
+  Delete the module data that is cached on disk and displayed on startup.
+
+  render [--module=<name> | --all] [--format=text|json]
+  Refresh the module, or every enabled module, once and write its content to
+  stdout without starting the dashboard.
+
+  validate
+  Check the config file for errors without starting the dashboard. Reports
+  unknown module types, unknown module settings, invalid and overlapping
+  positions with their line and column. Exits non-zero if there are errors,
+  but not for warnings alone.
 `
 
 // NewFlags creates an instance of Flags
@@ -56,11 +86,29 @@ func (flags *Flags) ConfigFilePath() string {
 // RenderIf displays special-case information based on the flags passed
 // in, if any flags were passed in
 func (flags *Flags) RenderIf(config *config.Config) {
+	// --module is an argument to these commands, not a request for the module's help
+	switch flags.Opt.Cmd {
+	case "render":
+		if len(flags.Opt.Args) > 0 {
+			fmt.Fprintf(os.Stderr, "render: too many arguments, see `%s --help`\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		os.Exit(render.Display(config, flags.ConfigFilePath(), flags.Module, flags.All, flags.Format))
+	case "delete-secret", "list-secrets", "save-secret":
+		os.Exit(flags.secretCommand(config))
+	}
+
 	if flags.HasModule() {
 		help.Display(flags.Module, config)
 		os.Exit(0)
 	}
 
+	if flags.ListModules {
+		listModules(os.Stdout)
+		os.Exit(0)
+	}
+
 	if flags.HasVersion() {
 		info, _ := debug.ReadBuildInfo()
 		version := "dev"
@@ -81,48 +129,27 @@ func (flags *Flags) RenderIf(config *config.Config) {
 	}
 
 	switch cmd := flags.Opt.Cmd; cmd {
-	case "save-secret":
-		var service, secret string
-		args := flags.Opt.Args
-
-		if len(args) < 1 || args[0] == "" {
-			fmt.Fprintf(os.Stderr, "save-secret: service required, see `%s --help`\n", os.Args[0])
+	case "cache":
+		if len(flags.Opt.Args) != 1 || flags.Opt.Args[0] != "clear" {
+			fmt.Fprintf(os.Stderr, "cache: unknown subcommand, see `%s --help`\n", os.Args[0])
 			os.Exit(1)
 		}
 
-		service = args[0]
-
-		if len(args) > 1 {
-			fmt.Fprintf(os.Stderr, "save-secret: too many arguments, see `%s --help`\n", os.Args[0])
-			os.Exit(1)
-		}
-
-		b, err := readline.Password("Secret: ")
+		dir, err := cache.Clear()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Clearing the cache: %s\n", err.Error())
 			os.Exit(1)
 		}
-		secret = string(b)
-		secret = strings.TrimSpace(secret)
 
-		if secret == "" {
-			fmt.Fprintf(os.Stderr, "save-secret: secret required, see `%s --help`\n", os.Args[0])
-			os.Exit(1)
-		}
-
-		err = cfg.StoreSecret(config, &cfg.Secret{
-			Service:  service,
-			Secret:   secret,
-			Username: "default",
-		})
-
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Saving secret for service %q: %s\n", service, err.Error())
+		fmt.Printf("Cleared the cache in %s\n", dir)
+		os.Exit(0)
+	case "validate":
+		if len(flags.Opt.Args) > 0 {
+			fmt.Fprintf(os.Stderr, "validate: too many arguments, see `%s --help`\n", os.Args[0])
 			os.Exit(1)
 		}
 
-		fmt.Printf("Saved secret for service %q\n", service)
-		os.Exit(0)
+		os.Exit(validate.Display(config, flags.ConfigFilePath()))
 	default:
 		fmt.Fprintf(os.Stderr, "Command `%s` is not supported, try `%s --help`\n", cmd, os.Args[0])
 		os.Exit(1)
@@ -0,0 +1,20 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/wtfutil/wtf/registry"
+)
+
+// listModules writes the module types registered in this build, with their descriptions
+func listModules(out io.Writer) {
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	for _, module := range registry.Modules() {
+		fmt.Fprintf(writer, "%s\t%s\n", module.Type, module.Description)
+	}
+
+	_ = writer.Flush()
+}
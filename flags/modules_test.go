@@ -0,0 +1,30 @@
+//go:build !slim
+
+package flags
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_listModules(t *testing.T) {
+	out := &bytes.Buffer{}
+	listModules(out)
+
+	descriptions := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) < 2 {
+			continue
+		}
+
+		descriptions[fields[0]] = strings.TrimSpace(fields[1])
+	}
+
+	assert.Equal(t, "Displays the time in one or more time zones", descriptions["clocks"])
+	assert.Contains(t, out.String(), "todoist ")
+	assert.NotContains(t, out.String(), "unknown")
+}
@@ -0,0 +1,113 @@
+package flags
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+// secretCommand runs the save-secret, list-secrets and delete-secret commands against
+// the secret store of the module named with --module, or the global secret store, and
+// returns the exit code
+func (flags *Flags) secretCommand(config *config.Config) int {
+	cmd := flags.Opt.Cmd
+	args := flags.Opt.Args
+
+	if cmd == "list-secrets" {
+		if len(args) > 0 {
+			fmt.Fprintf(os.Stderr, "%s: too many arguments, see `%s --help`\n", cmd, os.Args[0])
+			return 1
+		}
+	} else {
+		if len(args) < 1 || args[0] == "" {
+			fmt.Fprintf(os.Stderr, "%s: service required, see `%s --help`\n", cmd, os.Args[0])
+			return 1
+		}
+
+		if len(args) > 1 {
+			fmt.Fprintf(os.Stderr, "%s: too many arguments, see `%s --help`\n", cmd, os.Args[0])
+			return 1
+		}
+	}
+
+	store := cfg.NewSecretStore(config, flags.Module)
+	if store == nil {
+		fmt.Fprintf(os.Stderr, "%s: wtf.secretStore is not configured\n", cmd)
+		return 1
+	}
+
+	if cfg.SecretPassphraseRequired(store) {
+		b, err := readline.Password("Passphrase: ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+
+		cfg.SetSecretPassphrase(string(b))
+	}
+
+	switch cmd {
+	case "delete-secret":
+		return deleteSecret(store, args[0])
+	case "list-secrets":
+		return listSecrets(store)
+	default:
+		return saveSecret(store, args[0])
+	}
+}
+
+func deleteSecret(store cfg.SecretStore, service string) int {
+	if err := store.Delete(service); err != nil {
+		fmt.Fprintf(os.Stderr, "Deleting secret for service %q: %s\n", service, err.Error())
+		return 1
+	}
+
+	fmt.Printf("Deleted secret for service %q\n", service)
+	return 0
+}
+
+func listSecrets(store cfg.SecretStore) int {
+	services, err := store.List()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Listing secrets in %s: %s\n", store.Name(), err.Error())
+		return 1
+	}
+
+	for _, service := range services {
+		fmt.Println(service)
+	}
+
+	return 0
+}
+
+func saveSecret(store cfg.SecretStore, service string) int {
+	b, err := readline.Password("Secret: ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	secret := strings.TrimSpace(string(b))
+	if secret == "" {
+		fmt.Fprintf(os.Stderr, "save-secret: secret required, see `%s --help`\n", os.Args[0])
+		return 1
+	}
+
+	err = store.Store(&cfg.Secret{
+		Service:  service,
+		Secret:   secret,
+		Username: "default",
+	})
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Saving secret for service %q: %s\n", service, err.Error())
+		return 1
+	}
+
+	fmt.Printf("Saved secret for service %q\n", service)
+	return 0
+}
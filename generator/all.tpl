@@ -0,0 +1,5 @@
+//go:build (!slim || mod_{{.Package}}) && !nomod_{{.Package}}
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/{{.Package}}"
@@ -0,0 +1,76 @@
+package {{.Package}}
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/view"
+)
+
+// Widget is the container for your module's data
+type Widget struct {
+	view.BarGraph
+
+	client   Client
+	err      error
+	items    []Item
+	settings *Settings
+}
+
+// NewWidget creates and returns an instance of Widget
+func NewWidget(tviewApp *tview.Application, redrawChan chan bool, _ *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		BarGraph: view.NewBarGraph(tviewApp, redrawChan, settings.Title, settings.Common),
+
+		client:   NewClient(settings),
+		settings: settings,
+	}
+
+	widget.initializeKeyboardControls()
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Refresh fetches the module's data and updates the onscreen bars
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	widget.items, widget.err = widget.client.Items()
+	widget.SetError(widget.err)
+
+	widget.View.Clear()
+
+	if widget.err != nil {
+		widget.View.SetText(widget.err.Error())
+		return
+	}
+
+	widget.BuildBars(widget.bars())
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// bars returns a bar for each item. Values are percentages, so they're kept to 0-100
+func (widget *Widget) bars() []view.Bar {
+	bars := make([]view.Bar, len(widget.items))
+
+	for idx, item := range widget.items {
+		percent := item.Value
+		if percent < 0 {
+			percent = 0
+		}
+		if percent > 100 {
+			percent = 100
+		}
+
+		bars[idx] = view.Bar{
+			Label:      item.Name,
+			Percent:    percent,
+			LabelColor: widget.settings.Colors.TextTheme.Subheading,
+		}
+	}
+
+	return bars
+}
@@ -0,0 +1,65 @@
+package {{.Package}}
+
+import (
+	"errors"
+	"net/http"
+{{- if .MultiSource}}
+	"net/url"
+{{- end}}
+
+	"github.com/wtfutil/wtf/httpclient"
+	"github.com/wtfutil/wtf/utils"
+)
+
+// Item is a single thing that the module displays
+type Item struct {
+	Name  string `json:"name"`
+	URL   string `json:"url"`
+	Value int    `json:"value"`
+}
+
+// Client fetches the module's items. The widget talks to the API through this interface
+// so that its tests can give it a fake client
+type Client interface {
+	Items({{if .MultiSource}}source string{{end}}) ([]Item, error)
+}
+
+// apiClient fetches the module's items from the {{.Name}} API
+type apiClient struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates and returns a client for the API configured in the settings
+func NewClient(settings *Settings) Client {
+	return &apiClient{
+		apiKey:     settings.apiKey,
+		baseURL:    settings.baseURL,
+		httpClient: httpclient.New(settings.HTTP),
+	}
+}
+
+// Items returns the items{{if .MultiSource}} of the source{{end}}
+func (client *apiClient) Items({{if .MultiSource}}source string{{end}}) ([]Item, error) {
+	req, err := http.NewRequest(http.MethodGet, client.baseURL+"/items"{{if .MultiSource}}+"?source="+url.QueryEscape(source){{end}}, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+client.apiKey)
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.New(resp.Status)
+	}
+
+	items := []Item{}
+	err = utils.ParseJSON(&items, resp.Body)
+
+	return items, err
+}
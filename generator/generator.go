@@ -0,0 +1,180 @@
+//go:build ignore
+
+// This program generates the skeleton of a new module: its settings, widget, keyboard
+// controls, an API client behind an interface, a table-driven widget test that uses a
+// fake client, and its registration with the module registry. It's run from the root of
+// the repository:
+//
+//	go run generator/generator.go -name MyModule -kind scrollable -description "Displays my things"
+//
+// The kinds of widget it generates are text, scrollable, multisource and bargraph. The
+// name can also be set with the WTF_WIDGET_NAME environment variable, and the kind with
+// WTF_WIDGET_KIND, and 'make module NAME=MyModule KIND=scrollable' runs it too.
+// The module is created in modules/<name>, as the module type <name>, in lowercase,
+// imported into the build by modules/all/<name>.go and added to the known module types in
+// modules/all/types.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	defaultKind       = "text"
+	defaultWidgetName = "NewTextWidget"
+)
+
+// widgetTemplates are the widget templates for each kind of widget
+var widgetTemplates = map[string]string{
+	"bargraph":    "bargraph.tpl",
+	"multisource": "multisourcewidget.tpl",
+	"scrollable":  "scrollablewidget.tpl",
+	"text":        "textwidget.tpl",
+}
+
+var validName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+// typesPattern matches the list of known module types in modules/all/types.go
+var typesPattern = regexp.MustCompile(`(?s)(var types = \[\]string\{\n)(.*?)(\n\})`)
+
+// module is the data that the templates are executed with
+type module struct {
+	Description string
+	Kind        string
+	Name        string
+}
+
+// EnvName is the module's name as used in environment variables
+func (mod module) EnvName() string {
+	return strings.ToUpper(mod.Name)
+}
+
+// Focusable is whether the module's widget takes focus by default
+func (mod module) Focusable() bool {
+	return mod.Kind == "scrollable" || mod.Kind == "multisource"
+}
+
+// MultiSource is whether the module displays one of several sources at a time
+func (mod module) MultiSource() bool {
+	return mod.Kind == "multisource"
+}
+
+// Package is the module's package name, and the type it registers
+func (mod module) Package() string {
+	return strings.ToLower(mod.Name)
+}
+
+func main() {
+	mod := module{}
+
+	flag.StringVar(&mod.Name, "name", envOr("WTF_WIDGET_NAME", defaultWidgetName), "The name of the module")
+	flag.StringVar(&mod.Kind, "kind", envOr("WTF_WIDGET_KIND", defaultKind), "The kind of widget: text, scrollable, multisource or bargraph")
+	flag.StringVar(&mod.Description, "description", "", "A one-line description of the module, for wtfutil --list-modules")
+	flag.Parse()
+
+	if mod.Description == "" {
+		mod.Description = fmt.Sprintf("Displays items from %s", mod.Name)
+	}
+
+	if err := generate(mod); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("Created modules/%s, registered as the %q module type\n", mod.Package(), mod.Package())
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func generate(mod module) error {
+	widgetTemplate, ok := widgetTemplates[mod.Kind]
+	if !ok {
+		return fmt.Errorf("unknown kind %q, use text, scrollable, multisource or bargraph", mod.Kind)
+	}
+
+	if !validName.MatchString(mod.Name) {
+		return fmt.Errorf("invalid name %q, use only letters and numbers, starting with a letter", mod.Name)
+	}
+
+	moduleDir := filepath.Join("modules", mod.Package())
+	if _, err := os.Stat(moduleDir); err == nil {
+		return fmt.Errorf("%s already exists", moduleDir)
+	}
+
+	if err := os.MkdirAll(moduleDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		filepath.Join(moduleDir, "client.go"):                "client.tpl",
+		filepath.Join(moduleDir, "keyboard.go"):              "keyboard.tpl",
+		filepath.Join(moduleDir, "register.go"):              "register.tpl",
+		filepath.Join(moduleDir, "settings.go"):              "settings.tpl",
+		filepath.Join(moduleDir, "widget.go"):                widgetTemplate,
+		filepath.Join(moduleDir, "widget_test.go"):           "widget_test.tpl",
+		filepath.Join("modules", "all", mod.Package()+".go"): "all.tpl",
+	}
+
+	for path, templateName := range files {
+		if err := generateFile(path, templateName, mod); err != nil {
+			return err
+		}
+	}
+
+	return addKnownType(filepath.Join("modules", "all", "types.go"), mod.Package())
+}
+
+// addKnownType adds the module type to the sorted list of known types in the file
+func addKnownType(path, moduleType string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	match := typesPattern.FindSubmatchIndex(data)
+	if match == nil {
+		return fmt.Errorf("%s has no list of module types", path)
+	}
+
+	types := []string{fmt.Sprintf("%q,", moduleType)}
+	for _, line := range strings.Split(string(data[match[4]:match[5]]), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			types = append(types, line)
+		}
+	}
+	sort.Strings(types)
+
+	out := string(data[:match[4]]) + "\t" + strings.Join(types, "\n\t") + string(data[match[5]:])
+
+	return os.WriteFile(path, []byte(out), 0644)
+}
+
+func generateFile(path, templateName string, mod module) error {
+	tpl, err := template.ParseFiles(filepath.Join("generator", templateName))
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = out.Close() }()
+
+	return tpl.Execute(out, mod)
+}
+
+func envOr(key, defaultValue string) string {
+	if val, ok := os.LookupEnv(key); ok {
+		return val
+	}
+
+	return defaultValue
+}
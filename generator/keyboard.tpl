@@ -0,0 +1,28 @@
+package {{.Package}}
+{{if or (eq .Kind "scrollable") (eq .Kind "multisource")}}
+import "github.com/gdamore/tcell/v2"
+{{end}}
+func (widget *Widget) initializeKeyboardControls() {
+	widget.InitializeHelpTextKeyboardControl(widget.ShowHelp)
+	widget.InitializeRefreshKeyboardControl(widget.Refresh)
+{{- if eq .Kind "scrollable"}}
+
+	widget.SetKeyboardChar("j", widget.Next, "Select next item")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous item")
+	widget.SetKeyboardChar("o", widget.openItem, "Open item in browser")
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next item")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous item")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openItem, "Open item in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+{{- else if eq .Kind "multisource"}}
+
+	widget.SetKeyboardChar("l", widget.NextSource, "Select next source")
+	widget.SetKeyboardChar("h", widget.PrevSource, "Select previous source")
+
+	widget.SetKeyboardKey(tcell.KeyRight, widget.NextSource, "Select next source")
+	widget.SetKeyboardKey(tcell.KeyLeft, widget.PrevSource, "Select previous source")
+{{- end}}
+
+	// Add your module's keyboard commands here
+}
@@ -0,0 +1,78 @@
+package {{.Package}}
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/view"
+)
+
+// Widget is the container for your module's data. It displays one source at a time,
+// read from the source or sources settings
+type Widget struct {
+	view.MultiSourceWidget
+	view.TextWidget
+
+	client   Client
+	err      error
+	items    []Item
+	settings *Settings
+}
+
+// NewWidget creates and returns an instance of Widget
+func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		MultiSourceWidget: view.NewMultiSourceWidget(settings.Common, "source", "sources"),
+		TextWidget:        view.NewTextWidget(tviewApp, redrawChan, pages, settings.Common),
+
+		client:   NewClient(settings),
+		settings: settings,
+	}
+
+	widget.SetDisplayFunction(widget.Refresh)
+	widget.initializeKeyboardControls()
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Refresh fetches the current source's data and updates the onscreen contents of the widget
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	widget.items, widget.err = widget.client.Items(widget.CurrentSource())
+	widget.SetError(widget.err)
+
+	// The last call should always be to the display function
+	widget.display()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) content() (string, string, bool) {
+	title := fmt.Sprintf("%s - %s", widget.CommonSettings().Title, widget.CurrentSource())
+
+	_, _, width, _ := widget.View.GetRect()
+	str := widget.settings.PaginationMarker(len(widget.Sources), widget.Idx, width) + "\n"
+
+	if widget.err != nil {
+		return title, str + widget.err.Error(), true
+	}
+
+	if len(widget.items) == 0 {
+		return title, str + "No items", false
+	}
+
+	for _, item := range widget.items {
+		str += fmt.Sprintf("[%s]%s[white] %d\n", widget.settings.Colors.TextTheme.Subheading, tview.Escape(item.Name), item.Value)
+	}
+
+	return title, str, false
+}
+
+func (widget *Widget) display() {
+	widget.Redraw(widget.content)
+}
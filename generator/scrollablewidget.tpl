@@ -0,0 +1,90 @@
+package {{.Package}}
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/view"
+)
+
+// Widget is the container for your module's data
+type Widget struct {
+	view.ScrollableWidget
+
+	client   Client
+	err      error
+	items    []Item
+	settings *Settings
+}
+
+// NewWidget creates and returns an instance of Widget
+func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		ScrollableWidget: view.NewScrollableWidget(tviewApp, redrawChan, pages, settings.Common),
+
+		client:   NewClient(settings),
+		settings: settings,
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.initializeKeyboardControls()
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Refresh fetches the module's data and updates the onscreen contents of the widget
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	widget.items, widget.err = widget.client.Items()
+	widget.SetError(widget.err)
+	widget.SetItemCount(len(widget.items))
+
+	// The last call should always be to the display function
+	widget.Render()
+}
+
+// Render redraws the widget's rows, highlighting the selected one
+func (widget *Widget) Render() {
+	widget.Redraw(widget.content)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) content() (string, string, bool) {
+	title := widget.CommonSettings().Title
+
+	if widget.err != nil {
+		return title, widget.err.Error(), true
+	}
+
+	if len(widget.items) == 0 {
+		return title, "No items", false
+	}
+
+	str := ""
+	for idx, item := range widget.items {
+		row := fmt.Sprintf(
+			"[%s]%s %d",
+			widget.RowColor(idx),
+			tview.Escape(item.Name),
+			item.Value,
+		)
+
+		str += utils.HighlightableHelper(widget.View, row, idx, len(item.Name))
+	}
+
+	return title, str, false
+}
+
+func (widget *Widget) openItem() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.items) {
+		utils.OpenFile(widget.items[sel].URL)
+	}
+}
@@ -1,29 +1,37 @@
-package {{(Lower .Name)}}
+package {{.Package}}
 
 import (
+	"os"
+
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/cfg"
 )
 
 const (
-	defaultFocusable = false
-	defaultTitle     = "{{(.Name)}}"
+	defaultFocusable = {{.Focusable}}
+	defaultTitle     = "{{.Name}}"
 )
 
 // Settings defines the configuration properties for this module
 type Settings struct {
-	common *cfg.Common
+	*cfg.Common
 
-    // Define your settings attributes here
+	apiKey  string `help:"Your {{.Name}} API key."`
+	baseURL string `help:"The URL of the {{.Name}} API." optional:"true"`
 }
 
 // NewSettingsFromYAML creates a new settings instance from a YAML config block
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
 	settings := Settings{
-        common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
+		Common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
 
-        // Configure your settings attributes here. See http://github.com/olebedev/config for type details
+		// Configure your settings attributes here. See http://github.com/olebedev/config for type details
+		apiKey:  ymlConfig.UString("apiKey", os.Getenv("WTF_{{.EnvName}}_API_KEY")),
+		baseURL: ymlConfig.UString("baseURL", "https://api.example.com"),
 	}
 
+	cfg.ModuleSecret(name, globalConfig, &settings.apiKey).
+		Service(settings.baseURL).Load()
+
 	return &settings
-}
\ No newline at end of file
+}
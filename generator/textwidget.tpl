@@ -1,6 +1,8 @@
-package {{(Lower .Name)}}
+package {{.Package}}
 
 import (
+	"fmt"
+
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/view"
 )
@@ -9,37 +11,62 @@ import (
 type Widget struct {
 	view.TextWidget
 
+	client   Client
+	err      error
+	items    []Item
 	settings *Settings
 }
 
 // NewWidget creates and returns an instance of Widget
 func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
-		TextWidget: view.NewTextWidget(tviewApp, redrawChan, pages, settings.common),
+		TextWidget: view.NewTextWidget(tviewApp, redrawChan, pages, settings.Common),
 
+		client:   NewClient(settings),
 		settings: settings,
 	}
 
+	widget.initializeKeyboardControls()
+
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-// Refresh updates the onscreen contents of the widget
+// Refresh fetches the module's data and updates the onscreen contents of the widget
 func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
 
-    // The last call should always be to the display function
-    widget.display()
+	widget.items, widget.err = widget.client.Items()
+	widget.SetError(widget.err)
+
+	// The last call should always be to the display function
+	widget.display()
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) content() string {
-	return "This is my widget"
+func (widget *Widget) content() (string, string, bool) {
+	title := widget.CommonSettings().Title
+
+	if widget.err != nil {
+		return title, widget.err.Error(), true
+	}
+
+	if len(widget.items) == 0 {
+		return title, "No items", false
+	}
+
+	str := ""
+	for _, item := range widget.items {
+		str += fmt.Sprintf("[%s]%s[white] %d\n", widget.settings.Colors.TextTheme.Subheading, tview.Escape(item.Name), item.Value)
+	}
+
+	return title, str, false
 }
 
 func (widget *Widget) display() {
-	widget.Redraw(func() (string, string, bool) {
-		return widget.CommonSettings().Title, widget.content(), false
-	})
+	widget.Redraw(widget.content)
 }
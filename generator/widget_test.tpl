@@ -0,0 +1,134 @@
+package {{.Package}}
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+{{- if eq .Kind "bargraph"}}
+	"github.com/wtfutil/wtf/view"
+{{- end}}
+)
+
+// fakeClient returns canned items instead of calling the API
+type fakeClient struct {
+	err   error
+	items []Item
+{{- if .MultiSource}}
+
+	sources []string
+{{- end}}
+}
+
+func (client *fakeClient) Items({{if .MultiSource}}source string{{end}}) ([]Item, error) {
+{{- if .MultiSource}}
+	client.sources = append(client.sources, source)
+{{end}}
+	return client.items, client.err
+}
+
+func newTestWidget(client Client) *Widget {
+	moduleConfig, _ := config.ParseYaml("enabled: true{{if .MultiSource}}\nsources: [alpha, beta]{{end}}")
+	settings := NewSettingsFromYAML("{{.Package}}", moduleConfig, &config.Config{})
+
+	widget := NewWidget(nil, make(chan bool, 10), nil, settings)
+	widget.client = client
+
+	return widget
+}
+{{if eq .Kind "bargraph"}}
+func Test_Refresh(t *testing.T) {
+	tests := []struct {
+		name         string
+		client       *fakeClient
+		expectedBars []view.Bar
+		expectedErr  error
+	}{
+		{
+			name:         "with no items",
+			client:       &fakeClient{},
+			expectedBars: []view.Bar{},
+		},
+		{
+			name:   "with items",
+			client: &fakeClient{items: []Item{{"{{"}}Name: "cats", Value: 30}, {Name: "dogs", Value: 150}}},
+			expectedBars: []view.Bar{
+				{Label: "cats", Percent: 30, LabelColor: "red"},
+				{Label: "dogs", Percent: 100, LabelColor: "red"},
+			},
+		},
+		{
+			name:         "with an error",
+			client:       &fakeClient{err: errors.New("the API is down")},
+			expectedBars: []view.Bar{},
+			expectedErr:  errors.New("the API is down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			widget := newTestWidget(tt.client)
+			widget.settings.Colors.TextTheme.Subheading = "red"
+
+			widget.Refresh()
+
+			assert.Equal(t, tt.expectedErr, widget.err)
+			assert.Equal(t, tt.expectedBars, widget.bars())
+		})
+	}
+}
+{{- else}}
+func Test_Refresh(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   *fakeClient
+		expected []string
+	}{
+		{
+			name:     "with no items",
+			client:   &fakeClient{},
+			expected: []string{"No items"},
+		},
+		{
+			name:     "with items",
+			client:   &fakeClient{items: []Item{{"{{"}}Name: "cats", Value: 3}, {Name: "dogs", Value: 5}}},
+			expected: []string{"cats 3", "dogs 5"},
+		},
+		{
+			name:     "with an error",
+			client:   &fakeClient{err: errors.New("the API is down")},
+			expected: []string{"the API is down"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			widget := newTestWidget(tt.client)
+
+			widget.Refresh()
+
+			content := widget.Rendered().PlainContent()
+			for _, expected := range tt.expected {
+				assert.Contains(t, content, expected)
+			}
+{{- if .MultiSource}}
+
+			assert.Equal(t, []string{"alpha"}, tt.client.sources)
+{{- end}}
+		})
+	}
+}
+{{- end}}
+{{- if .MultiSource}}
+
+func Test_NextSource(t *testing.T) {
+	client := &fakeClient{}
+	widget := newTestWidget(client)
+
+	widget.NextSource()
+
+	assert.Equal(t, []string{"beta"}, client.sources)
+	assert.Contains(t, widget.Rendered().PlainTitle(), "beta")
+}
+{{- end}}
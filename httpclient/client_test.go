@@ -0,0 +1,217 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+// newTestClient returns a client that records how long it was asked to wait between
+// retries instead of waiting
+func newTestClient(settings *cfg.HTTPSettings, waits *[]time.Duration) *http.Client {
+	client := New(settings)
+
+	transport := client.Transport.(*userAgentTransport).next.(*retryTransport)
+	transport.sleep = func(_ context.Context, duration time.Duration) error {
+		*waits = append(*waits, duration)
+		return nil
+	}
+
+	return client
+}
+
+func Test_New_Retries(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		statuses      []int
+		retryAfter    string
+		expectedCalls int32
+		expectedCode  int
+		expectedWaits []time.Duration
+	}{
+		{
+			name:          "with a success",
+			method:        http.MethodGet,
+			statuses:      []int{200},
+			expectedCalls: 1,
+			expectedCode:  200,
+			expectedWaits: nil,
+		},
+		{
+			name:          "with server errors, backing off",
+			method:        http.MethodGet,
+			statuses:      []int{503, 502, 200},
+			expectedCalls: 3,
+			expectedCode:  200,
+			expectedWaits: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:          "with too many server errors",
+			method:        http.MethodGet,
+			statuses:      []int{500, 500, 500, 500},
+			expectedCalls: 3,
+			expectedCode:  500,
+			expectedWaits: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:          "with Retry-After",
+			method:        http.MethodGet,
+			statuses:      []int{429, 200},
+			retryAfter:    "7",
+			expectedCalls: 2,
+			expectedCode:  200,
+			expectedWaits: []time.Duration{7 * time.Second},
+		},
+		{
+			name:          "with Retry-After beyond the maximum wait",
+			method:        http.MethodGet,
+			statuses:      []int{429, 200},
+			retryAfter:    "3600",
+			expectedCalls: 1,
+			expectedCode:  429,
+			expectedWaits: nil,
+		},
+		{
+			name:          "with a POST and a server error",
+			method:        http.MethodPost,
+			statuses:      []int{500, 200},
+			expectedCalls: 1,
+			expectedCode:  500,
+			expectedWaits: nil,
+		},
+		{
+			name:          "with a POST and too many requests",
+			method:        http.MethodPost,
+			statuses:      []int{429, 200},
+			expectedCalls: 2,
+			expectedCode:  200,
+			expectedWaits: []time.Duration{time.Second},
+		},
+		{
+			name:          "with a client error",
+			method:        http.MethodGet,
+			statuses:      []int{404, 200},
+			expectedCalls: 1,
+			expectedCode:  404,
+			expectedWaits: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := int32(0)
+			bodies := []string{}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[call-1])
+			}))
+			defer server.Close()
+
+			waits := []time.Duration{}
+			client := newTestClient(&cfg.HTTPSettings{
+				MaxRetryWait: time.Minute,
+				Retries:      2,
+				RetryWait:    time.Second,
+				UserAgent:    "wtfutil",
+			}, &waits)
+
+			req, _ := http.NewRequest(tt.method, server.URL, strings.NewReader("payload"))
+			resp, err := client.Do(req)
+
+			assert.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+			assert.Equal(t, tt.expectedCalls, atomic.LoadInt32(&calls))
+			assert.Equal(t, len(tt.expectedWaits), len(waits))
+			if tt.expectedWaits != nil {
+				assert.Equal(t, tt.expectedWaits, waits)
+			}
+
+			for _, body := range bodies {
+				assert.Equal(t, "payload", body)
+			}
+		})
+	}
+}
+
+func Test_New_UserAgent(t *testing.T) {
+	agents := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, r.UserAgent())
+	}))
+	defer server.Close()
+
+	client := New(&cfg.HTTPSettings{UserAgent: "wtfutil"})
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+	req.Header.Set("User-Agent", "custom")
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, []string{"wtfutil", "custom"}, agents)
+}
+
+func Test_New_InvalidSettings(t *testing.T) {
+	client := New(&cfg.HTTPSettings{CAFile: "/does/not/exist.pem"})
+
+	_, err := client.Get("https://example.com")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "http.caFile")
+}
+
+func Test_retryAfter(t *testing.T) {
+	now := time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		header       string
+		expectedWait time.Duration
+		expectedOk   bool
+	}{
+		{name: "with nothing", header: "", expectedWait: 0, expectedOk: false},
+		{name: "with seconds", header: "120", expectedWait: 2 * time.Minute, expectedOk: true},
+		{name: "with a date", header: "Sun, 02 Jan 2022 15:05:05 GMT", expectedWait: time.Minute, expectedOk: true},
+		{name: "with a past date", header: "Sun, 02 Jan 2022 15:00:00 GMT", expectedWait: 0, expectedOk: true},
+		{name: "with garbage", header: "soon", expectedWait: 0, expectedOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := retryAfter(tt.header, now)
+
+			assert.Equal(t, tt.expectedWait, wait)
+			assert.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
+func Test_limiterFor(t *testing.T) {
+	assert.Same(t, limiterFor("example.com", 2), limiterFor("example.com", 2))
+	assert.NotSame(t, limiterFor("example.com", 2), limiterFor("example.org", 2))
+	assert.NotSame(t, limiterFor("example.com", 2), limiterFor("example.com", 3))
+}
@@ -0,0 +1,242 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	// errNotRewindable is returned when a request's body can't be sent a second time
+	errNotRewindable = errors.New("request body can't be rewound")
+
+	// limiters are shared by every client, so that modules talking to the same host share
+	// its rate limit
+	limiters     = map[string]*rate.Limiter{}
+	limiterMutex = &sync.Mutex{}
+)
+
+// errorTransport fails every request with the error from building the client
+type errorTransport struct {
+	err error
+}
+
+func (transport errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	return nil, transport.err
+}
+
+/* -------------------- User-Agent -------------------- */
+
+// userAgentTransport sets the User-Agent of requests that don't already have one
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (transport *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", transport.userAgent)
+	}
+
+	return transport.next.RoundTrip(req)
+}
+
+/* -------------------- Retries -------------------- */
+
+// retryTransport retries requests that fail with a 429 or 5xx response, waiting as long
+// as the response's Retry-After asks, or otherwise backing off exponentially. Requests
+// that might not be safe to repeat are only retried on a 429, which means the server
+// didn't act on them, and only if their body can be sent again
+type retryTransport struct {
+	maxWait time.Duration
+	next    http.RoundTripper
+	retries int
+	sleep   func(ctx context.Context, duration time.Duration) error
+	wait    time.Duration
+}
+
+func (transport *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+
+	for attempt := 0; ; attempt++ {
+		resp, err := transport.next.RoundTrip(attemptReq)
+
+		if attempt >= transport.retries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait, ok := transport.waitFor(resp, attempt)
+		if !ok {
+			return resp, err
+		}
+
+		nextReq, rewindErr := rewind(req)
+		if rewindErr != nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			_ = resp.Body.Close()
+		}
+
+		if err := transport.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		attemptReq = nextReq
+	}
+}
+
+// waitFor returns how long to wait before the next attempt. A Retry-After longer than the
+// maximum wait isn't worth waiting for, so the response is returned as it is instead
+func (transport *retryTransport) waitFor(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return wait, transport.maxWait <= 0 || wait <= transport.maxWait
+		}
+	}
+
+	wait := transport.wait << uint(attempt)
+	if transport.maxWait > 0 && (wait > transport.maxWait || wait < 0) {
+		wait = transport.maxWait
+	}
+
+	return wait, true
+}
+
+// retryable returns TRUE if the request is worth sending again after the response
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	idempotent := false
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		idempotent = true
+	}
+
+	if err != nil || resp == nil {
+		return idempotent
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	default:
+		return false
+	}
+}
+
+// rewind returns a copy of the request with a fresh body, to send it again
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errNotRewindable
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	next := req.Clone(req.Context())
+	next.Body = body
+
+	return next, nil
+}
+
+// retryAfter parses a Retry-After header, which is either a number of seconds or a date
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(header)
+	if err != nil {
+		return 0, false
+	}
+
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+
+	return 0, true
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+/* -------------------- Rate Limits -------------------- */
+
+// rateLimitTransport holds requests back so that no more than rate requests a second are
+// sent to each host
+type rateLimitTransport struct {
+	next http.RoundTripper
+	rate float64
+}
+
+func (transport *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := limiterFor(req.URL.Host, transport.rate).Wait(req.Context()); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+
+	return transport.next.RoundTrip(req)
+}
+
+// limiterFor returns the limiter shared by every client with the same rate for the host
+func limiterFor(host string, limit float64) *rate.Limiter {
+	key := host + "\x00" + strconv.FormatFloat(limit, 'f', -1, 64)
+
+	limiterMutex.Lock()
+	defer limiterMutex.Unlock()
+
+	limiter, ok := limiters[key]
+	if !ok {
+		burst := int(limit)
+		if burst < 1 {
+			burst = 1
+		}
+
+		limiter = rate.NewLimiter(rate.Limit(limit), burst)
+		limiters[key] = limiter
+	}
+
+	return limiter
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Level is the severity of a log entry
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+// Levels returns every level, from the least to the most severe
+func Levels() []Level {
+	return []Level{LevelDebug, LevelInfo, LevelWarn, LevelError}
+}
+
+// ParseLevel returns the level with the given name, ignoring case. "warning" is accepted
+// for LevelWarn
+func ParseLevel(name string) (Level, error) {
+	if strings.EqualFold(name, "warning") {
+		return LevelWarn, nil
+	}
+
+	for level, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+
+	return LevelInfo, fmt.Errorf("unknown log level %q", name)
+}
+
+// String returns the Stringer representation of the level
+func (level Level) String() string {
+	if name, ok := levelNames[level]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("level(%d)", int(level))
+}
+
+// MarshalText writes the level as its name
+func (level Level) MarshalText() ([]byte, error) {
+	return []byte(level.String()), nil
+}
+
+// UnmarshalText reads a level from its name
+func (level *Level) UnmarshalText(text []byte) error {
+	parsed, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*level = parsed
+	return nil
+}
+
+/* -------------------- Entry -------------------- */
+
+// Entry is a single line in the log file, written as JSON
+type Entry struct {
+	Time    time.Time              `json:"time"`
+	Level   Level                  `json:"level"`
+	Module  string                 `json:"module,omitempty"`
+	Type    string                 `json:"type,omitempty"`
+	Message string                 `json:"msg"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// ParseEntry reads an entry from a line of the log file. Lines written before the log
+// was structured are returned as info entries whose message is the whole line
+func ParseEntry(line string) Entry {
+	entry := Entry{}
+
+	if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.Time.IsZero() {
+		return Entry{Level: LevelInfo, Message: line}
+	}
+
+	return entry
+}
+
+// FieldsString returns the entry's fields as sorted key=value pairs
+func (entry Entry) FieldsString() string {
+	keys := make([]string, 0, len(entry.Fields))
+	for key := range entry.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for idx, key := range keys {
+		pairs[idx] = fmt.Sprintf("%s=%v", key, entry.Fields[key])
+	}
+
+	return strings.Join(pairs, " ")
+}
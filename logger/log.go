@@ -1,33 +1,240 @@
 package logger
 
 import (
-	"log"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+const (
+	defaultLevel      = LevelInfo
+	defaultMaxBackups = 3
+
+	// defaultMaxSizeMB is the default size, in megabytes, that the log file is rotated at
+	defaultMaxSizeMB = 10
+)
+
+var (
+	minLevel   = defaultLevel
+	output     io.Writer
+	outputPath = defaultPath()
+	stateMutex = &sync.Mutex{}
+
+	root = &Logger{}
+
+	// now returns the time that entries are stamped with
+	now = time.Now
 )
 
+// Logger writes entries tagged with a module's name and type, along with any fields
+// added to it with With
+type Logger struct {
+	fields     map[string]interface{}
+	module     string
+	moduleType string
+}
+
 /* -------------------- Exported Functions -------------------- */
 
-func Log(msg string) {
-	if LogFileMissing() {
-		return
+// Init configures the log from wtf.log. Entries below the level are dropped, and the file
+// is rotated once it grows beyond maxSize megabytes, keeping maxBackups old files:
+//
+//	wtf:
+//	  log:
+//	    path: ~/.config/wtf/log.txt
+//	    level: info                # debug, info, warn or error
+//	    maxSize: 10
+//	    maxBackups: 3
+func Init(config *config.Config) {
+	level, levelErr := ParseLevel(config.UString("wtf.log.level", defaultLevel.String()))
+
+	path := config.UString("wtf.log.path")
+	if path == "" {
+		path = defaultPath()
 	}
+	path = expandHomeDir(path)
 
-	f, err := os.OpenFile(LogFilePath(), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
-	if err != nil {
-		log.Fatalf("error opening file: %v", err)
+	maxSize := int64(config.UInt("wtf.log.maxSize", defaultMaxSizeMB)) * 1024 * 1024
+	maxBackups := config.UInt("wtf.log.maxBackups", defaultMaxBackups)
+
+	stateMutex.Lock()
+	closeOutput()
+	minLevel = level
+	outputPath = path
+	if path != "" {
+		output = newRotatingFile(path, maxSize, maxBackups)
 	}
-	defer func() { _ = f.Close() }()
+	stateMutex.Unlock()
+
+	if levelErr != nil {
+		Warn("invalid wtf.log.level, using "+level.String(), "error", levelErr)
+	}
+}
+
+// Log writes the message as an info entry
+func Log(msg string) {
+	root.Info(msg)
+}
+
+// Debug writes a debug entry with the given key/value pairs as fields
+func Debug(msg string, keyvals ...interface{}) {
+	root.write(LevelDebug, msg, keyvals)
+}
+
+// Info writes an info entry with the given key/value pairs as fields
+func Info(msg string, keyvals ...interface{}) {
+	root.write(LevelInfo, msg, keyvals)
+}
 
-	log.SetOutput(f)
-	log.Println(msg)
+// Warn writes a warn entry with the given key/value pairs as fields
+func Warn(msg string, keyvals ...interface{}) {
+	root.write(LevelWarn, msg, keyvals)
 }
 
+// Error writes an error entry with the given key/value pairs as fields
+func Error(msg string, keyvals ...interface{}) {
+	root.write(LevelError, msg, keyvals)
+}
+
+// ForModule returns a logger whose entries are tagged with the module's name and type
+func ForModule(name, moduleType string) *Logger {
+	return &Logger{module: name, moduleType: moduleType}
+}
+
+// LogFileMissing returns TRUE if there is no log file to write to
 func LogFileMissing() bool {
 	return LogFilePath() == ""
 }
 
+// LogFilePath returns the path to the log file, ~/.config/wtf/log.txt unless wtf.log.path
+// sets another
 func LogFilePath() string {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	return outputPath
+}
+
+/* -------------------- Logger -------------------- */
+
+// With returns a logger that adds the given key/value pairs to every entry's fields
+func (logger *Logger) With(keyvals ...interface{}) *Logger {
+	fields := make(map[string]interface{}, len(logger.fields)+len(keyvals)/2)
+	for key, val := range logger.fields {
+		fields[key] = val
+	}
+	addFields(fields, keyvals)
+
+	return &Logger{fields: fields, module: logger.module, moduleType: logger.moduleType}
+}
+
+// Debug writes a debug entry with the given key/value pairs as fields
+func (logger *Logger) Debug(msg string, keyvals ...interface{}) {
+	logger.write(LevelDebug, msg, keyvals)
+}
+
+// Info writes an info entry with the given key/value pairs as fields
+func (logger *Logger) Info(msg string, keyvals ...interface{}) {
+	logger.write(LevelInfo, msg, keyvals)
+}
+
+// Warn writes a warn entry with the given key/value pairs as fields
+func (logger *Logger) Warn(msg string, keyvals ...interface{}) {
+	logger.write(LevelWarn, msg, keyvals)
+}
+
+// Error writes an error entry with the given key/value pairs as fields
+func (logger *Logger) Error(msg string, keyvals ...interface{}) {
+	logger.write(LevelError, msg, keyvals)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// write appends the entry to the log file. Entries that can't be written are dropped:
+// the log must never take the UI down with it
+func (logger *Logger) write(level Level, msg string, keyvals []interface{}) {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	if level < minLevel || outputPath == "" {
+		return
+	}
+
+	if output == nil {
+		output = newRotatingFile(outputPath, defaultMaxSizeMB*1024*1024, defaultMaxBackups)
+	}
+
+	entry := Entry{
+		Time:    now(),
+		Level:   level,
+		Module:  logger.module,
+		Type:    logger.moduleType,
+		Message: msg,
+	}
+
+	if len(logger.fields) > 0 || len(keyvals) > 0 {
+		entry.Fields = make(map[string]interface{}, len(logger.fields)+len(keyvals)/2)
+		for key, val := range logger.fields {
+			entry.Fields[key] = val
+		}
+		addFields(entry.Fields, keyvals)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		// A field couldn't be encoded, so write them all as strings instead
+		for key, val := range entry.Fields {
+			entry.Fields[key] = fmt.Sprint(val)
+		}
+
+		line, err = json.Marshal(entry)
+		if err != nil {
+			return
+		}
+	}
+
+	_, _ = output.Write(append(line, '\n'))
+}
+
+// addFields adds the key/value pairs to the fields. Errors are written as their message,
+// since they don't encode to JSON, and a key without a value is kept under "extra"
+func addFields(fields map[string]interface{}, keyvals []interface{}) {
+	for idx := 0; idx < len(keyvals); idx += 2 {
+		if idx+1 == len(keyvals) {
+			fields["extra"] = fieldValue(keyvals[idx])
+			break
+		}
+
+		fields[fmt.Sprint(keyvals[idx])] = fieldValue(keyvals[idx+1])
+	}
+}
+
+func fieldValue(val interface{}) interface{} {
+	switch typed := val.(type) {
+	case error:
+		return typed.Error()
+	case fmt.Stringer:
+		return typed.String()
+	default:
+		return val
+	}
+}
+
+func closeOutput() {
+	if closer, ok := output.(io.Closer); ok {
+		_ = closer.Close()
+	}
+	output = nil
+}
+
+func defaultPath() string {
 	dir, err := os.UserHomeDir()
 	if err != nil {
 		return ""
@@ -35,3 +242,18 @@ func LogFilePath() string {
 
 	return filepath.Join(dir, ".config", "wtf", "log.txt")
 }
+
+// expandHomeDir expands a leading ~ in the path. The logger can't use utils.ExpandHomeDir
+// because utils, through cfg, logs with this package
+func expandHomeDir(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(dir, path[1:])
+}
@@ -0,0 +1,144 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// initTestLog points the log at a file in a temporary directory, and returns its path
+func initTestLog(t *testing.T, yaml string) string {
+	path := filepath.Join(t.TempDir(), "log.txt")
+
+	conf, err := config.ParseYaml("wtf:\n  log:\n    path: " + path + "\n" + yaml)
+	assert.NoError(t, err)
+
+	Init(conf)
+	t.Cleanup(func() {
+		stateMutex.Lock()
+		closeOutput()
+		stateMutex.Unlock()
+	})
+
+	return path
+}
+
+func readEntries(t *testing.T, path string) []Entry {
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	entries := []Entry{}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line != "" {
+			entries = append(entries, ParseEntry(line))
+		}
+	}
+
+	return entries
+}
+
+func Test_ParseLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Level
+		isErr    bool
+	}{
+		{name: "debug", expected: LevelDebug},
+		{name: "INFO", expected: LevelInfo},
+		{name: "warning", expected: LevelWarn},
+		{name: "error", expected: LevelError},
+		{name: "loud", expected: LevelInfo, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseLevel(tt.name)
+
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.isErr, err != nil)
+		})
+	}
+}
+
+func Test_Levels(t *testing.T) {
+	path := initTestLog(t, "    level: warn\n")
+
+	Debug("hidden")
+	Info("hidden")
+	Warn("shown")
+	Error("shown too")
+
+	entries := readEntries(t, path)
+
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, LevelWarn, entries[0].Level)
+	assert.Equal(t, LevelError, entries[1].Level)
+}
+
+func Test_ForModule(t *testing.T) {
+	path := initTestLog(t, "")
+
+	ForModule("ci", "jenkins").With("job", "deploy").Error("build failed", "error", errors.New("boom"), "number", 42)
+	Log("plain")
+
+	entries := readEntries(t, path)
+
+	assert.Equal(t, 2, len(entries))
+
+	assert.Equal(t, "ci", entries[0].Module)
+	assert.Equal(t, "jenkins", entries[0].Type)
+	assert.Equal(t, "build failed", entries[0].Message)
+	assert.Equal(t, "error=boom job=deploy number=42", entries[0].FieldsString())
+	assert.WithinDuration(t, time.Now(), entries[0].Time, time.Minute)
+
+	assert.Equal(t, "", entries[1].Module)
+	assert.Equal(t, LevelInfo, entries[1].Level)
+	assert.Equal(t, "plain", entries[1].Message)
+}
+
+func Test_ParseEntry_Unstructured(t *testing.T) {
+	entry := ParseEntry("2022/01/02 15:04:05 log.go:23 old line")
+
+	assert.True(t, entry.Time.IsZero())
+	assert.Equal(t, LevelInfo, entry.Level)
+	assert.Equal(t, "2022/01/02 15:04:05 log.go:23 old line", entry.Message)
+}
+
+func Test_UnwritableLog(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "file")
+	assert.NoError(t, os.WriteFile(blocker, []byte{}, 0600))
+
+	conf, err := config.ParseYaml("wtf:\n  log:\n    path: " + filepath.Join(blocker, "log.txt") + "\n")
+	assert.NoError(t, err)
+
+	Init(conf)
+
+	assert.NotPanics(t, func() { Error("dropped") })
+}
+
+func Test_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	rotating := newRotatingFile(path, 10, 2)
+	defer func() { _ = rotating.Close() }()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := rotating.Write([]byte(line))
+		assert.NoError(t, err)
+	}
+
+	for suffix, expected := range map[string]string{"": "fourth\n", ".1": "third\n", ".2": "second\n"} {
+		data, err := os.ReadFile(path + suffix)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+
+	_, err := os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
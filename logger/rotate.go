@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// rotatingFile is an io.Writer that appends to a file, and moves the file aside once it
+// grows beyond its maximum size. The file is moved to path.1, whatever was in path.1 is
+// moved to path.2, and so on, with the oldest backup being deleted
+type rotatingFile struct {
+	maxBackups int
+	maxSize    int64
+	path       string
+
+	file  *os.File
+	size  int64
+	mutex sync.Mutex
+}
+
+func newRotatingFile(path string, maxSize int64, maxBackups int) *rotatingFile {
+	return &rotatingFile{
+		maxBackups: maxBackups,
+		maxSize:    maxSize,
+		path:       path,
+	}
+}
+
+// Write appends the data to the file, rotating it first if the data would take it past
+// its maximum size. The file is opened on the first write
+func (rotating *rotatingFile) Write(data []byte) (int, error) {
+	rotating.mutex.Lock()
+	defer rotating.mutex.Unlock()
+
+	if rotating.file == nil {
+		if err := rotating.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if rotating.maxSize > 0 && rotating.size > 0 && rotating.size+int64(len(data)) > rotating.maxSize {
+		if err := rotating.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	written, err := rotating.file.Write(data)
+	rotating.size += int64(written)
+
+	return written, err
+}
+
+// Close closes the file. The next write opens it again
+func (rotating *rotatingFile) Close() error {
+	rotating.mutex.Lock()
+	defer rotating.mutex.Unlock()
+
+	if rotating.file == nil {
+		return nil
+	}
+
+	err := rotating.file.Close()
+	rotating.file = nil
+
+	return err
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (rotating *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(rotating.path), 0700); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(rotating.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	rotating.file = file
+	rotating.size = stat.Size()
+
+	return nil
+}
+
+func (rotating *rotatingFile) rotate() error {
+	if err := rotating.file.Close(); err != nil {
+		return err
+	}
+	rotating.file = nil
+
+	if rotating.maxBackups < 1 {
+		if err := os.Remove(rotating.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return rotating.open()
+	}
+
+	for idx := rotating.maxBackups - 1; idx >= 1; idx-- {
+		err := os.Rename(backupPath(rotating.path, idx), backupPath(rotating.path, idx+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := os.Rename(rotating.path, backupPath(rotating.path, 1)); err != nil {
+		return err
+	}
+
+	return rotating.open()
+}
+
+func backupPath(path string, idx int) string {
+	return fmt.Sprintf("%s.%d", path, idx)
+}
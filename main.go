@@ -15,8 +15,11 @@ import (
 	"github.com/pkg/profile"
 
 	"github.com/wtfutil/wtf/app"
+	"github.com/wtfutil/wtf/cache"
 	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/flags"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
@@ -45,10 +48,19 @@ func main() {
 	openFileUtil := config.UString("wtf.openFileUtil", "open")
 	openURLUtil := utils.ToStrs(config.UList("wtf.openUrlUtil", []interface{}{}))
 	utils.Init(openFileUtil, openURLUtil)
+	logger.Init(config)
+	cache.Init(config)
+	notify.Init(config)
 
 	/* Initialize the App Manager */
 	appMan := app.NewAppManager()
-	appMan.MakeNewWtfApp(config, flags.Config)
+	appMan.MakeNewWtfApps(config, flags.Config)
+
+	err := appMan.ServeAPI(config)
+	if err != nil {
+		fmt.Printf("\n%s %v\n", aurora.Red("ERROR"), err)
+		os.Exit(1)
+	}
 
 	currentApp, err := appMan.Current()
 	if err != nil {
@@ -0,0 +1,18 @@
+package airbrake
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/registry"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	registry.Register(
+		"airbrake",
+		"Displays the most recent groups of errors in an Airbrake project",
+		NewSettingsFromYAML,
+		func(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings *Settings) wtf.Wtfable {
+			return NewWidget(tviewApp, redrawChan, pages, settings)
+		},
+	)
+}
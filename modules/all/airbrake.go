@@ -0,0 +1,5 @@
+//go:build (!slim || mod_airbrake) && !nomod_airbrake
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/airbrake"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_arpansagovau) && !nomod_arpansagovau
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/weatherservices/arpansagovau"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_asana) && !nomod_asana
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/asana"
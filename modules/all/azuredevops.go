@@ -0,0 +1,5 @@
+//go:build (!slim || mod_azuredevops) && !nomod_azuredevops
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/azuredevops"
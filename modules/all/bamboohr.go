@@ -0,0 +1,5 @@
+//go:build (!slim || mod_bamboohr) && !nomod_bamboohr
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/bamboohr"
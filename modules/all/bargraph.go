@@ -0,0 +1,5 @@
+//go:build (!slim || mod_bargraph) && !nomod_bargraph
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/bargraph"
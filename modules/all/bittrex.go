@@ -0,0 +1,5 @@
+//go:build (!slim || mod_bittrex) && !nomod_bittrex
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/cryptocurrency/bittrex"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_blockfolio) && !nomod_blockfolio
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/cryptocurrency/blockfolio"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_buildkite) && !nomod_buildkite
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/buildkite"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_cdsFavorites) && !nomod_cdsFavorites
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/cds/favorites"
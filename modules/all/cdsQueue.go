@@ -0,0 +1,5 @@
+//go:build (!slim || mod_cdsQueue) && !nomod_cdsQueue
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/cds/queue"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_cdsStatus) && !nomod_cdsStatus
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/cds/status"
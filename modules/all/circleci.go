@@ -0,0 +1,5 @@
+//go:build (!slim || mod_circleci) && !nomod_circleci
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/circleci"
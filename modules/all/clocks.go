@@ -0,0 +1,5 @@
+//go:build (!slim || mod_clocks) && !nomod_clocks
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/clocks"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_cmdrunner) && !nomod_cmdrunner
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/cmdrunner"
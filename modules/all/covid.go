@@ -0,0 +1,5 @@
+//go:build (!slim || mod_covid) && !nomod_covid
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/covid"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_cryptolive) && !nomod_cryptolive
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/cryptocurrency/cryptolive"
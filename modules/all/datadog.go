@@ -0,0 +1,5 @@
+//go:build (!slim || mod_datadog) && !nomod_datadog
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/datadog"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_devto) && !nomod_devto
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/devto"
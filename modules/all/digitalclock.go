@@ -0,0 +1,5 @@
+//go:build (!slim || mod_digitalclock) && !nomod_digitalclock
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/digitalclock"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_digitalocean) && !nomod_digitalocean
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/digitalocean"
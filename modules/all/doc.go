@@ -0,0 +1,14 @@
+// Package all imports every module, so that they register their types with the registry.
+// Each module is imported from its own file, guarded by build tags, so that a build can
+// leave out modules, and the dependencies that only they need:
+//
+//	# Every module except kubernetes and gcal
+//	go build -tags nomod_kubernetes,nomod_gcal
+//
+//	# Only the clocks, git and jenkins modules
+//	go build -tags slim,mod_clocks,mod_git,mod_jenkins
+//
+// Each tag is named after the module type the file imports, except mod_todo_plus, which
+// imports the todo_plus, todoist and trello types. `wtfutil --list-modules` prints the
+// types that a build contains
+package all
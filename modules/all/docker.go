@@ -0,0 +1,5 @@
+//go:build (!slim || mod_docker) && !nomod_docker
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/docker"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_external) && !nomod_external
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/external"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_feedreader) && !nomod_feedreader
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/feedreader"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_finnhub) && !nomod_finnhub
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/stocks/finnhub"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_football) && !nomod_football
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/football"
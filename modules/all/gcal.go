@@ -0,0 +1,5 @@
+//go:build (!slim || mod_gcal) && !nomod_gcal
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/gcal"
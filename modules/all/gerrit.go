@@ -0,0 +1,5 @@
+//go:build (!slim || mod_gerrit) && !nomod_gerrit
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/gerrit"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_git) && !nomod_git
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/git"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_github) && !nomod_github
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/github"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_gitlab) && !nomod_gitlab
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/gitlab"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_gitlabtodo) && !nomod_gitlabtodo
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/gitlabtodo"
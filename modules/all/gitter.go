@@ -0,0 +1,5 @@
+//go:build (!slim || mod_gitter) && !nomod_gitter
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/gitter"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_googleanalytics) && !nomod_googleanalytics
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/googleanalytics"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_grafana) && !nomod_grafana
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/grafana"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_gspreadsheets) && !nomod_gspreadsheets
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/gspreadsheets"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_hackernews) && !nomod_hackernews
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/hackernews"
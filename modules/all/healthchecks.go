@@ -0,0 +1,5 @@
+//go:build (!slim || mod_healthchecks) && !nomod_healthchecks
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/healthchecks"
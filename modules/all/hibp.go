@@ -0,0 +1,5 @@
+//go:build (!slim || mod_hibp) && !nomod_hibp
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/hibp"
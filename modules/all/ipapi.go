@@ -0,0 +1,5 @@
+//go:build (!slim || mod_ipapi) && !nomod_ipapi
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/ipaddresses/ipapi"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_ipinfo) && !nomod_ipinfo
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/ipaddresses/ipinfo"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_jenkins) && !nomod_jenkins
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/jenkins"
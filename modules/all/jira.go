@@ -0,0 +1,5 @@
+//go:build (!slim || mod_jira) && !nomod_jira
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/jira"
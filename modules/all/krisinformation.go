@@ -0,0 +1,5 @@
+//go:build (!slim || mod_krisinformation) && !nomod_krisinformation
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/krisinformation"
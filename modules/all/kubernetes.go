@@ -0,0 +1,5 @@
+//go:build (!slim || mod_kubernetes) && !nomod_kubernetes
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/kubernetes"
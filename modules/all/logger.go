@@ -0,0 +1,5 @@
+//go:build (!slim || mod_logger) && !nomod_logger
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/logger"
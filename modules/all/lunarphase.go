@@ -0,0 +1,5 @@
+//go:build (!slim || mod_lunarphase) && !nomod_lunarphase
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/lunarphase"
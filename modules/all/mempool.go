@@ -0,0 +1,5 @@
+//go:build (!slim || mod_mempool) && !nomod_mempool
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/cryptocurrency/mempool"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_mercurial) && !nomod_mercurial
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/mercurial"
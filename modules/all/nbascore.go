@@ -0,0 +1,5 @@
+//go:build (!slim || mod_nbascore) && !nomod_nbascore
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/nbascore"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_newrelic) && !nomod_newrelic
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/newrelic"
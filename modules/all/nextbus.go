@@ -0,0 +1,5 @@
+//go:build (!slim || mod_nextbus) && !nomod_nextbus
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/nextbus"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_opsgenie) && !nomod_opsgenie
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/opsgenie"
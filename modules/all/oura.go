@@ -0,0 +1,5 @@
+//go:build (!slim || mod_oura) && !nomod_oura
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/oura"
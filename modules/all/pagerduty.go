@@ -0,0 +1,5 @@
+//go:build (!slim || mod_pagerduty) && !nomod_pagerduty
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/pagerduty"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_pihole) && !nomod_pihole
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/pihole"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_pocket) && !nomod_pocket
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/pocket"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_power) && !nomod_power
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/power"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_prettyweather) && !nomod_prettyweather
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/weatherservices/prettyweather"
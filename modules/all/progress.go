@@ -0,0 +1,5 @@
+//go:build (!slim || mod_progress) && !nomod_progress
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/progress"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_resourceusage) && !nomod_resourceusage
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/resourceusage"
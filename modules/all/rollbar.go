@@ -0,0 +1,5 @@
+//go:build (!slim || mod_rollbar) && !nomod_rollbar
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/rollbar"
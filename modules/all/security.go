@@ -0,0 +1,5 @@
+//go:build (!slim || mod_security) && !nomod_security
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/security"
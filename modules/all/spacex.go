@@ -0,0 +1,5 @@
+//go:build (!slim || mod_spacex) && !nomod_spacex
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/spacex"
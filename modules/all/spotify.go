@@ -0,0 +1,5 @@
+//go:build (!slim || mod_spotify) && !nomod_spotify
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/spotify"
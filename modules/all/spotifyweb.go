@@ -0,0 +1,5 @@
+//go:build (!slim || mod_spotifyweb) && !nomod_spotifyweb
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/spotifyweb"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_status) && !nomod_status
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/status"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_steam) && !nomod_steam
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/steam"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_subreddit) && !nomod_subreddit
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/subreddit"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_textfile) && !nomod_textfile
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/textfile"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_todo) && !nomod_todo
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/todo"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_todo_plus) && !nomod_todo_plus
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/todo_plus"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_transmission) && !nomod_transmission
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/transmission"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_travisci) && !nomod_travisci
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/travisci"
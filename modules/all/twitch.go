@@ -0,0 +1,5 @@
+//go:build (!slim || mod_twitch) && !nomod_twitch
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/twitch"
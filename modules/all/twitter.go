@@ -0,0 +1,5 @@
+//go:build (!slim || mod_twitter) && !nomod_twitter
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/twitter"
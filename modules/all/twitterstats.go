@@ -0,0 +1,5 @@
+//go:build (!slim || mod_twitterstats) && !nomod_twitterstats
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/twitterstats"
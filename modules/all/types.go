@@ -0,0 +1,98 @@
+package all
+
+import "github.com/wtfutil/wtf/registry"
+
+// types are every module type that wtfutil has, including those that build tags leave
+// out of this build. New modules are added by the generator
+var types = []string{
+	"airbrake",
+	"arpansagovau",
+	"asana",
+	"azuredevops",
+	"bamboohr",
+	"bargraph",
+	"bittrex",
+	"blockfolio",
+	"buildkite",
+	"cdsFavorites",
+	"cdsQueue",
+	"cdsStatus",
+	"circleci",
+	"clocks",
+	"cmdrunner",
+	"covid",
+	"cryptolive",
+	"datadog",
+	"devto",
+	"digitalclock",
+	"digitalocean",
+	"docker",
+	"external",
+	"feedreader",
+	"finnhub",
+	"football",
+	"gcal",
+	"gerrit",
+	"git",
+	"github",
+	"gitlab",
+	"gitlabtodo",
+	"gitter",
+	"googleanalytics",
+	"grafana",
+	"gspreadsheets",
+	"hackernews",
+	"healthchecks",
+	"hibp",
+	"ipapi",
+	"ipinfo",
+	"jenkins",
+	"jira",
+	"krisinformation",
+	"kubernetes",
+	"logger",
+	"lunarphase",
+	"mempool",
+	"mercurial",
+	"nbascore",
+	"newrelic",
+	"nextbus",
+	"opsgenie",
+	"oura",
+	"pagerduty",
+	"pihole",
+	"pocket",
+	"power",
+	"prettyweather",
+	"progress",
+	"resourceusage",
+	"rollbar",
+	"security",
+	"spacex",
+	"spotify",
+	"spotifyweb",
+	"status",
+	"steam",
+	"subreddit",
+	"textfile",
+	"todo",
+	"todo_plus",
+	"todoist",
+	"transmission",
+	"travisci",
+	"trello",
+	"twitch",
+	"twitter",
+	"twitterstats",
+	"updown",
+	"uptimerobot",
+	"urlcheck",
+	"victorops",
+	"weather",
+	"yfinance",
+	"zendesk",
+}
+
+func init() {
+	registry.AddKnownTypes(types...)
+}
@@ -0,0 +1,18 @@
+package all
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/registry"
+)
+
+func Test_types(t *testing.T) {
+	registered := []string{}
+	for _, module := range registry.Modules() {
+		registered = append(registered, module.Type)
+	}
+
+	// Every module compiled in must be known, or leaving it out would make it unknown
+	assert.Subset(t, types, registered)
+}
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_updown) && !nomod_updown
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/updown"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_uptimerobot) && !nomod_uptimerobot
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/uptimerobot"
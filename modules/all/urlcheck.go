@@ -0,0 +1,5 @@
+//go:build (!slim || mod_urlcheck) && !nomod_urlcheck
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/urlcheck"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_victorops) && !nomod_victorops
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/victorops"
@@ -0,0 +1,5 @@
+//go:build (!slim || mod_weather) && !nomod_weather
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/weatherservices/weather"
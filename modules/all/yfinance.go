@@ -0,0 +1,5 @@
+//go:build (!slim || mod_yfinance) && !nomod_yfinance
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/stocks/yfinance"
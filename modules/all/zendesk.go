@@ -0,0 +1,5 @@
+//go:build (!slim || mod_zendesk) && !nomod_zendesk
+
+package all
+
+import _ "github.com/wtfutil/wtf/modules/zendesk"
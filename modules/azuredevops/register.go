@@ -0,0 +1,18 @@
+package azuredevops
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/registry"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	registry.Register(
+		"azuredevops",
+		"Displays the recent builds of an Azure DevOps project",
+		NewSettingsFromYAML,
+		func(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings *Settings) wtf.Wtfable {
+			return NewWidget(tviewApp, redrawChan, pages, settings)
+		},
+	)
+}
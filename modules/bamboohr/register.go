@@ -0,0 +1,18 @@
+package bamboohr
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/registry"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	registry.Register(
+		"bamboohr",
+		"Displays who is away today, from BambooHR",
+		NewSettingsFromYAML,
+		func(tviewApp *tview.Application, redrawChan chan bool, _ *tview.Pages, settings *Settings) wtf.Wtfable {
+			return NewWidget(tviewApp, redrawChan, settings)
+		},
+	)
+}
@@ -0,0 +1,18 @@
+package bargraph
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/registry"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	registry.Register(
+		"bargraph",
+		"Displays sample data as a bar graph",
+		NewSettingsFromYAML,
+		func(tviewApp *tview.Application, redrawChan chan bool, _ *tview.Pages, settings *Settings) wtf.Wtfable {
+			return NewWidget(tviewApp, redrawChan, settings)
+		},
+	)
+}
@@ -0,0 +1,18 @@
+package circleci
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/registry"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	registry.Register(
+		"circleci",
+		"Displays the recent builds of a CircleCI account",
+		NewSettingsFromYAML,
+		func(tviewApp *tview.Application, redrawChan chan bool, _ *tview.Pages, settings *Settings) wtf.Wtfable {
+			return NewWidget(tviewApp, redrawChan, settings)
+		},
+	)
+}
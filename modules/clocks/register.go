@@ -0,0 +1,18 @@
+package clocks
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/registry"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	registry.Register(
+		"clocks",
+		"Displays the time in one or more time zones",
+		NewSettingsFromYAML,
+		func(tviewApp *tview.Application, redrawChan chan bool, _ *tview.Pages, settings *Settings) wtf.Wtfable {
+			return NewWidget(tviewApp, redrawChan, settings)
+		},
+	)
+}
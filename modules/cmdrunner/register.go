@@ -0,0 +1,18 @@
+package cmdrunner
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/registry"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	registry.Register(
+		"cmdrunner",
+		"Runs a terminal command and displays its output",
+		NewSettingsFromYAML,
+		func(tviewApp *tview.Application, redrawChan chan bool, _ *tview.Pages, settings *Settings) wtf.Wtfable {
+			return NewWidget(tviewApp, redrawChan, settings)
+		},
+	)
+}
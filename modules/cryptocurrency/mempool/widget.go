@@ -51,7 +51,7 @@ func getBTCTxFees() string {
 	url := "https://mempool.space/api/v1/fees/recommended"
 	resp, err := http.Get(url)
 	if err != nil {
-		logger.Log(fmt.Sprintf("[mempool] Error: Failed to make request to mempool. Reason: %s", err))
+		logger.ForModule("", "mempool").Error("failed to make request to mempool", "error", err)
 		return "[mempool] error callng mempool API"
 	}
 	defer resp.Body.Close()
@@ -59,7 +59,7 @@ func getBTCTxFees() string {
 	parsed := feeStruct{}
 	err = utils.ParseJSON(&parsed, resp.Body)
 	if err != nil {
-		logger.Log(fmt.Sprintf("[mempool] Error: Failed to decode JSON data from mempool. Reason: %s", err))
+		logger.ForModule("", "mempool").Error("failed to decode JSON data from mempool", "error", err)
 		return "[mempool] error parsing JSON from mempool API"
 	}
 
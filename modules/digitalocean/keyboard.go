@@ -5,12 +5,13 @@ import "github.com/gdamore/tcell/v2"
 func (widget *Widget) initializeKeyboardControls() {
 	widget.InitializeHelpTextKeyboardControl(widget.ShowHelp)
 	widget.InitializeRefreshKeyboardControl(widget.Refresh)
+	widget.InitializeTableKeyboardControls()
 
 	widget.SetKeyboardChar("?", widget.showInfo, "Show info about the selected droplet")
 
 	widget.SetKeyboardChar("b", widget.dropletRestart, "Reboot the selected droplet")
-	widget.SetKeyboardChar("j", widget.Prev, "Select previous item")
-	widget.SetKeyboardChar("k", widget.Next, "Select next item")
+	widget.SetKeyboardChar("j", widget.Next, "Select next item")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous item")
 	widget.SetKeyboardChar("p", widget.dropletEnabledPrivateNetworking, "Enable private networking for the selected drople")
 	widget.SetKeyboardChar("s", widget.dropletShutDown, "Shut down the selected droplet")
 	widget.SetKeyboardChar("u", widget.Unselect, "Clear selection")
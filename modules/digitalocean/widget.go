@@ -30,7 +30,7 @@ func (t *tokenSource) Token() (*oauth2.Token, error) {
 
 // Widget is the container for droplet data
 type Widget struct {
-	view.ScrollableWidget
+	view.TableWidget[*Droplet]
 
 	app      *tview.Application
 	client   *godo.Client
@@ -44,13 +44,14 @@ type Widget struct {
 // NewWidget creates a new instance of a widget
 func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
-		ScrollableWidget: view.NewScrollableWidget(tviewApp, redrawChan, pages, settings.Common),
+		TableWidget: view.NewTableWidget[*Droplet](tviewApp, redrawChan, pages, settings.Common),
 
 		app:      tviewApp,
 		pages:    pages,
 		settings: settings,
 	}
 
+	widget.Table.SetColumns(widget.columns()...)
 	widget.initializeKeyboardControls()
 
 	widget.View.SetScrollable(true)
@@ -72,17 +73,18 @@ func (widget *Widget) Fetch() error {
 
 	var err error
 	widget.droplets, err = widget.dropletsFetch()
+	widget.SetItems(widget.droplets)
 	return err
 }
 
 // Next selects the next item in the list
 func (widget *Widget) Next() {
-	widget.ScrollableWidget.Next()
+	widget.TableWidget.Next()
 }
 
 // Prev selects the previous item in the list
 func (widget *Widget) Prev() {
-	widget.ScrollableWidget.Prev()
+	widget.TableWidget.Prev()
 }
 
 // Refresh updates the data for this widget and displays it onscreen
@@ -90,10 +92,9 @@ func (widget *Widget) Refresh() {
 	err := widget.Fetch()
 	if err != nil {
 		widget.err = err
-		widget.SetItemCount(0)
+		widget.SetItems(nil)
 	} else {
 		widget.err = nil
-		widget.SetItemCount(len(widget.droplets))
 	}
 
 	widget.display()
@@ -101,7 +102,7 @@ func (widget *Widget) Refresh() {
 
 // Unselect clears the selection of list items
 func (widget *Widget) Unselect() {
-	widget.ScrollableWidget.Unselect()
+	widget.TableWidget.Unselect()
 	widget.RenderFunction()
 }
 
@@ -120,15 +121,12 @@ func (widget *Widget) createClient() {
 // currentDroplet returns the currently-selected droplet, if there is one
 // Returns nil if no droplet is selected
 func (widget *Widget) currentDroplet() *Droplet {
-	if len(widget.droplets) == 0 {
+	droplet, ok := widget.SelectedItem()
+	if !ok {
 		return nil
 	}
 
-	if len(widget.droplets) <= widget.Selected {
-		return nil
-	}
-
-	return widget.droplets[widget.Selected]
+	return droplet
 }
 
 // dropletsFetch uses the DigitalOcean API to fetch information about all the available droplets
@@ -199,10 +197,19 @@ func (widget *Widget) dropletEnabledPrivateNetworking() {
 // dropletRemoveSelected removes the currently-selected droplet from the internal list of droplets
 func (widget *Widget) dropletRemoveSelected() {
 	currDroplet := widget.currentDroplet()
-	if currDroplet != nil {
-		widget.droplets[len(widget.droplets)-1], widget.droplets[widget.Selected] = widget.droplets[widget.Selected], widget.droplets[len(widget.droplets)-1]
-		widget.droplets = widget.droplets[:len(widget.droplets)-1]
+	if currDroplet == nil {
+		return
 	}
+
+	droplets := []*Droplet{}
+	for _, droplet := range widget.droplets {
+		if droplet != currDroplet {
+			droplets = append(droplets, droplet)
+		}
+	}
+
+	widget.droplets = droplets
+	widget.SetItems(widget.droplets)
 }
 
 // dropletRestart restarts the selected droplet
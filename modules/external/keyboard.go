@@ -0,0 +1,57 @@
+package external
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/wtfutil/wtf/view"
+)
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.InitializeHelpTextKeyboardControl(widget.ShowHelp)
+	widget.InitializeRefreshKeyboardControl(widget.Refresh)
+
+	widget.SetKeyboardChar("j", widget.Next, "Select next item")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous item")
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next item")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous item")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+
+	for _, key := range []tcell.Key{tcell.KeyDown, tcell.KeyUp, tcell.KeyEsc} {
+		widget.boundKeys[strings.ToLower(tcell.KeyNames[key])] = true
+	}
+}
+
+// bindProcessKey binds a key that the process registered, so that pressing it sends
+// the process a key request. Keys that are already bound, either by the widget or by an
+// earlier registration, are left alone
+func (widget *Widget) bindProcessKey(binding keyBinding) {
+	name := binding.Key
+	pressed := func() { widget.pressKey(name) }
+
+	if utf8.RuneCountInString(name) == 1 {
+		for _, char := range widget.AssignedChars() {
+			if char == name {
+				return
+			}
+		}
+
+		widget.SetKeyboardChar(name, pressed, binding.Help)
+		return
+	}
+
+	if widget.boundKeys[strings.ToLower(name)] {
+		return
+	}
+
+	key, ok := view.KeyFromName(name)
+	if !ok {
+		widget.Logger().Warn("unknown key", "key", name)
+		return
+	}
+
+	widget.boundKeys[strings.ToLower(name)] = true
+	widget.SetKeyboardKey(key, pressed, binding.Help)
+}
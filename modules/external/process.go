@@ -0,0 +1,230 @@
+package external
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// maxMessageSize is the longest line the process can send. Messages longer than this
+// stop the process
+const maxMessageSize = 1024 * 1024
+
+// maxPendingRequests is how many requests can wait to be written to the process. A
+// process that doesn't read its stdin misses any requests after these
+const maxPendingRequests = 64
+
+// maxStderrSize is how much of the end of the process's stderr is kept, to explain why
+// it exited
+const maxStderrSize = 1024
+
+// message is a single line of JSON sent by the process. Every field is optional, and
+// only the fields that are present replace what the widget is displaying. Example:
+//
+//	{"title": "Deploys", "status": "1 failing", "rows": [{"text": "api", "color": "red"}]}
+type message struct {
+	Error  *string      `json:"error"`
+	Keys   []keyBinding `json:"keys"`
+	Rows   *[]row       `json:"rows"`
+	Status *string      `json:"status"`
+	Title  *string      `json:"title"`
+}
+
+// row is a single selectable line of text in the widget
+type row struct {
+	Color string `json:"color,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Text  string `json:"text"`
+}
+
+// keyBinding is a key that the process wants to be told about when it is pressed. Key
+// is either a single character or the name of a key, i.e.: "Enter", "Ctrl-D"
+type keyBinding struct {
+	Help string `json:"help"`
+	Key  string `json:"key"`
+}
+
+// request is a single line of JSON sent to the process. Type is one of "refresh",
+// "select" or "key"
+type request struct {
+	Index int    `json:"index"`
+	Key   string `json:"key,omitempty"`
+	Row   *row   `json:"row,omitempty"`
+	Type  string `json:"type"`
+}
+
+// process is a running child process that speaks the line-delimited JSON protocol.
+// Requests are written to its stdin by a single writer goroutine, so sending one never
+// waits on the process
+type process struct {
+	cmd      *exec.Cmd
+	done     chan struct{}
+	requests chan []byte
+	stderr   *tailBuffer
+	stdin    io.WriteCloser
+	stopOnce sync.Once
+}
+
+// startProcess launches the command and reads messages from its stdout until it exits.
+// Each message, or the error from a line that isn't valid JSON, is passed to onMessage.
+// When the process exits onExit is called with the reason
+func startProcess(cmd *exec.Cmd, onMessage func(*process, message, error), onExit func(*process, error)) (*process, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	proc := &process{
+		cmd:      cmd,
+		done:     make(chan struct{}),
+		requests: make(chan []byte, maxPendingRequests),
+		stderr:   &tailBuffer{max: maxStderrSize},
+		stdin:    stdin,
+	}
+	cmd.Stderr = proc.stderr
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	go proc.write()
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+
+			msg, err := parseMessage(line)
+			onMessage(proc, msg, err)
+		}
+
+		scanErr := scanner.Err()
+		if scanErr != nil {
+			// Stop the process so that Wait doesn't block on a process that is still writing
+			_ = cmd.Process.Kill()
+		}
+
+		onExit(proc, proc.exitError(cmd.Wait(), scanErr))
+	}()
+
+	return proc, nil
+}
+
+// send queues the request to be written to the process's stdin as a single line of JSON
+func (proc *process) send(req request) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-proc.done:
+		return errors.New("process has stopped")
+	case proc.requests <- append(data, '\n'):
+		return nil
+	default:
+		return errors.New("process isn't reading its input")
+	}
+}
+
+// stop closes the process's stdin and kills it
+func (proc *process) stop() {
+	proc.stopOnce.Do(func() {
+		close(proc.done)
+
+		_ = proc.stdin.Close()
+
+		if proc.cmd.Process != nil {
+			_ = proc.cmd.Process.Kill()
+		}
+	})
+}
+
+// write writes the queued requests to the process's stdin, in order, until it's stopped
+func (proc *process) write() {
+	for {
+		select {
+		case <-proc.done:
+			return
+		case data := <-proc.requests:
+			// A failed write means the process has exited, which onExit reports
+			if _, err := proc.stdin.Write(data); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// exitError describes why the process exited, including the end of anything it wrote
+// to stderr
+func (proc *process) exitError(waitErr, scanErr error) error {
+	reason := "exited"
+	switch {
+	case scanErr != nil:
+		reason = scanErr.Error()
+	case waitErr != nil:
+		reason = waitErr.Error()
+	}
+
+	stderr := strings.TrimSpace(proc.stderr.String())
+	if stderr != "" {
+		return fmt.Errorf("%s %s: %s", proc.cmd.Path, reason, stderr)
+	}
+
+	return fmt.Errorf("%s %s", proc.cmd.Path, reason)
+}
+
+func parseMessage(line string) (message, error) {
+	msg := message{}
+
+	err := json.Unmarshal([]byte(line), &msg)
+	if err != nil {
+		return message{}, fmt.Errorf("invalid message %q: %w", line, err)
+	}
+
+	return msg, nil
+}
+
+/* -------------------- Tail Buffer -------------------- */
+
+// tailBuffer is an io.Writer that keeps only the last max bytes written to it
+type tailBuffer struct {
+	data  []byte
+	max   int
+	mutex sync.Mutex
+}
+
+func (buf *tailBuffer) Write(p []byte) (int, error) {
+	buf.mutex.Lock()
+	defer buf.mutex.Unlock()
+
+	buf.data = append(buf.data, p...)
+	if len(buf.data) > buf.max {
+		buf.data = buf.data[len(buf.data)-buf.max:]
+	}
+
+	return len(p), nil
+}
+
+func (buf *tailBuffer) String() string {
+	buf.mutex.Lock()
+	defer buf.mutex.Unlock()
+
+	return string(buf.data)
+}
@@ -0,0 +1,110 @@
+package external
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseMessage(t *testing.T) {
+	title := "Deploys"
+	empty := ""
+
+	tests := []struct {
+		name        string
+		line        string
+		expected    message
+		expectedErr bool
+	}{
+		{
+			name:     "with an empty message",
+			line:     `{}`,
+			expected: message{},
+		},
+		{
+			name: "with a full message",
+			line: `{"title": "Deploys", "rows": [{"text": "api", "color": "red", "id": "1"}], "keys": [{"key": "d", "help": "Deploy"}], "error": ""}`,
+			expected: message{
+				Error: &empty,
+				Keys:  []keyBinding{{Help: "Deploy", Key: "d"}},
+				Rows:  &[]row{{Color: "red", ID: "1", Text: "api"}},
+				Title: &title,
+			},
+		},
+		{
+			name:        "with invalid JSON",
+			line:        `{"title":`,
+			expected:    message{},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseMessage(tt.line)
+
+			assert.Equal(t, tt.expectedErr, err != nil)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func Test_startProcess(t *testing.T) {
+	script := `
+echo '{"title": "Hello"}'
+echo
+read line
+case "$line" in
+  '{"index":2,"type":"refresh"}') echo '{"status": "refreshed"}' ;;
+esac
+echo 'not json'
+echo 'failed' >&2
+exit 3
+`
+
+	messages := make(chan message, 10)
+	errs := make(chan error, 10)
+	exited := make(chan error, 1)
+
+	proc, err := startProcess(
+		exec.Command("sh", "-c", script),
+		func(_ *process, msg message, err error) {
+			if err != nil {
+				errs <- err
+				return
+			}
+			messages <- msg
+		},
+		func(_ *process, err error) { exited <- err },
+	)
+	assert.NoError(t, err)
+
+	msg := <-messages
+	assert.Equal(t, "Hello", *msg.Title)
+
+	assert.NoError(t, proc.send(request{Type: "refresh", Index: 2}))
+
+	msg = <-messages
+	assert.Equal(t, "refreshed", *msg.Status)
+
+	assert.Contains(t, (<-errs).Error(), `invalid message "not json"`)
+
+	select {
+	case err := <-exited:
+		assert.Contains(t, err.Error(), "exit status 3: failed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("process did not exit")
+	}
+}
+
+func Test_tailBuffer(t *testing.T) {
+	buf := &tailBuffer{max: 5}
+
+	_, _ = buf.Write([]byte("abc"))
+	assert.Equal(t, "abc", buf.String())
+
+	_, _ = buf.Write([]byte("defg"))
+	assert.Equal(t, "cdefg", buf.String())
+}
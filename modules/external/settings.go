@@ -0,0 +1,43 @@
+package external
+
+import (
+	"fmt"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/utils"
+)
+
+const (
+	defaultFocusable = true
+	defaultTitle     = "External"
+)
+
+// Settings for the external widget
+type Settings struct {
+	*cfg.Common
+
+	args       []string          `help:"The arguments to the command, with each item as an element in an array." optional:"true"`
+	cmd        string            `help:"The command that is launched and kept running. It must speak the wtfutil line-delimited JSON protocol on its stdin and stdout."`
+	env        map[string]string `help:"Additional environment variables to set for the command." optional:"true"`
+	workingDir string            `help:"Working directory for the command to run in." optional:"true"`
+}
+
+// NewSettingsFromYAML loads the external portion of the WTF config
+func NewSettingsFromYAML(name string, moduleConfig *config.Config, globalConfig *config.Config) *Settings {
+	settings := Settings{
+		Common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, moduleConfig, globalConfig),
+
+		args:       utils.ToStrs(moduleConfig.UList("args")),
+		cmd:        moduleConfig.UString("cmd"),
+		env:        map[string]string{},
+		workingDir: moduleConfig.UString("workingDir", "."),
+	}
+
+	envMap, _ := moduleConfig.Map("env")
+	for key, val := range envMap {
+		settings.env[key] = fmt.Sprint(val)
+	}
+
+	return &settings
+}
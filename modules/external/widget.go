@@ -0,0 +1,324 @@
+package external
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/view"
+)
+
+// Widget is a module whose content comes from a long-running external process. The
+// process sends it the title, rows and status to display as lines of JSON on stdout,
+// and is sent refresh requests, selection changes and key presses on stdin
+type Widget struct {
+	view.ScrollableWidget
+
+	app      *tview.Application
+	settings *Settings
+
+	boundKeys    map[string]bool
+	mutex        sync.Mutex
+	proc         *process
+	procErr      error
+	rows         []row
+	selectedSent int
+	status       string
+	title        string
+}
+
+// NewWidget creates and returns an instance of the external widget
+func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings *Settings) *Widget {
+	widget := &Widget{
+		ScrollableWidget: view.NewScrollableWidget(tviewApp, redrawChan, pages, settings.Common),
+
+		app:      tviewApp,
+		settings: settings,
+
+		boundKeys:    map[string]bool{},
+		selectedSent: -1,
+		title:        settings.Title,
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.initializeKeyboardControls()
+
+	return widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Refresh starts the process if it isn't running, or asks it to send fresh data if it is
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	widget.queueUpdate(func() {
+		widget.mutex.Lock()
+		proc := widget.proc
+		widget.mutex.Unlock()
+
+		if proc == nil {
+			widget.start()
+		} else {
+			widget.send(request{Type: "refresh", Index: widget.GetSelected()})
+		}
+
+		widget.Render()
+	})
+}
+
+// Render sets up the widget data for redrawing to the screen, and tells the process when
+// the selected row has changed
+func (widget *Widget) Render() {
+	widget.Redraw(widget.content)
+	widget.sendSelection()
+}
+
+// Stop stops the process along with the widget
+func (widget *Widget) Stop() {
+	widget.mutex.Lock()
+	proc := widget.proc
+	widget.proc = nil
+	widget.mutex.Unlock()
+
+	if proc != nil {
+		proc.stop()
+	}
+
+	widget.ScrollableWidget.Stop()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) content() (string, string, bool) {
+	widget.mutex.Lock()
+	defer widget.mutex.Unlock()
+
+	title := widget.title
+	if widget.status != "" {
+		title = fmt.Sprintf("%s - %s", title, widget.status)
+	}
+
+	if widget.procErr != nil && len(widget.rows) == 0 {
+		return title, widget.procErr.Error(), true
+	}
+
+	if len(widget.rows) == 0 {
+		return title, "", false
+	}
+
+	str := ""
+	for idx, row := range widget.rows {
+		text := tview.Escape(row.Text)
+
+		color := widget.RowColor(idx)
+		if row.Color != "" && idx != widget.Selected {
+			color = row.Color
+		}
+
+		str += utils.HighlightableHelper(widget.View, fmt.Sprintf("[%s]%s", color, text), idx, len(row.Text))
+	}
+
+	return title, str, false
+}
+
+// environment returns the environment the process runs in: wtfutil's own, the widget's
+// name and the variables from the `env` setting
+func (widget *Widget) environment() []string {
+	envs := os.Environ()
+	envs = append(envs, fmt.Sprintf("WTF_WIDGET_NAME=%s", widget.Name()))
+
+	for key, val := range widget.settings.env {
+		envs = append(envs, fmt.Sprintf("%s=%s", key, val))
+	}
+
+	return envs
+}
+
+// handleExit records why the process exited. The next refresh starts it again. It's
+// called on the goroutine that reads from the process
+func (widget *Widget) handleExit(proc *process, err error) {
+	widget.queueUpdate(func() { widget.applyExit(proc, err) })
+}
+
+// handleMessage applies a message from the process to the widget. It's called on the
+// goroutine that reads from the process, which has already decoded the message
+func (widget *Widget) handleMessage(proc *process, msg message, err error) {
+	widget.queueUpdate(func() { widget.applyMessage(proc, msg, err) })
+}
+
+// applyExit records why the process exited. It must be called on the UI goroutine
+func (widget *Widget) applyExit(proc *process, err error) {
+	widget.mutex.Lock()
+	if widget.proc != proc {
+		// The widget was stopped or the process was replaced, so this is expected
+		widget.mutex.Unlock()
+		return
+	}
+
+	widget.proc = nil
+	widget.procErr = err
+	widget.mutex.Unlock()
+
+	widget.SetError(err)
+	widget.Render()
+}
+
+// applyMessage applies a message from the process to the widget. It must be called on
+// the UI goroutine, as it binds keys and changes the selection
+func (widget *Widget) applyMessage(proc *process, msg message, err error) {
+	widget.mutex.Lock()
+
+	if widget.proc != proc {
+		widget.mutex.Unlock()
+		return
+	}
+
+	if err != nil {
+		widget.procErr = err
+		widget.mutex.Unlock()
+
+		widget.SetError(err)
+		widget.Render()
+		return
+	}
+
+	if msg.Title != nil {
+		widget.title = *msg.Title
+	}
+
+	if msg.Status != nil {
+		widget.status = *msg.Status
+	}
+
+	if msg.Rows != nil {
+		widget.rows = *msg.Rows
+	}
+	rowCount := len(widget.rows)
+
+	if msg.Error != nil {
+		widget.procErr = nil
+		if *msg.Error != "" {
+			widget.procErr = errors.New(*msg.Error)
+		}
+	}
+	procErr := widget.procErr
+
+	widget.mutex.Unlock()
+
+	for _, binding := range msg.Keys {
+		widget.bindProcessKey(binding)
+	}
+
+	if msg.Rows != nil {
+		widget.SetItemCount(rowCount)
+		if widget.Selected >= rowCount {
+			widget.Selected = rowCount - 1
+		}
+	}
+
+	if msg.Error != nil || msg.Rows != nil {
+		widget.SetError(procErr)
+	}
+
+	widget.Render()
+}
+
+// pressKey tells the process that one of the keys it registered was pressed
+func (widget *Widget) pressKey(key string) {
+	widget.send(request{Type: "key", Key: key, Index: widget.GetSelected(), Row: widget.selectedRow()})
+}
+
+// queueUpdate changes the widget's state on the UI goroutine, which is the only one
+// that's allowed to, and then redraws the screen. Without an app, i.e.: in tests, the
+// change is made straight away
+func (widget *Widget) queueUpdate(update func()) {
+	if widget.app == nil {
+		update()
+		return
+	}
+
+	widget.app.QueueUpdateDraw(update)
+}
+
+func (widget *Widget) selectedRow() *row {
+	widget.mutex.Lock()
+	defer widget.mutex.Unlock()
+
+	idx := widget.GetSelected()
+	if idx < 0 || idx >= len(widget.rows) {
+		return nil
+	}
+
+	selected := widget.rows[idx]
+	return &selected
+}
+
+// send writes the request to the process, if it's running
+func (widget *Widget) send(req request) {
+	widget.mutex.Lock()
+	proc := widget.proc
+	widget.mutex.Unlock()
+
+	if proc == nil {
+		return
+	}
+
+	err := proc.send(req)
+	if err != nil {
+		widget.Logger().Warn("request not sent", "type", req.Type, "error", err)
+	}
+}
+
+// sendSelection tells the process which row is selected, if it has changed since the
+// last time it was told
+func (widget *Widget) sendSelection() {
+	selected := widget.GetSelected()
+
+	widget.mutex.Lock()
+	changed := selected != widget.selectedSent
+	widget.selectedSent = selected
+	widget.mutex.Unlock()
+
+	if changed {
+		widget.send(request{Type: "select", Index: selected, Row: widget.selectedRow()})
+	}
+}
+
+// start launches the process
+func (widget *Widget) start() {
+	if strings.TrimSpace(widget.settings.cmd) == "" {
+		err := errors.New("no command configured: set `cmd` to the command to run")
+
+		widget.mutex.Lock()
+		widget.procErr = err
+		widget.mutex.Unlock()
+
+		widget.SetError(err)
+		return
+	}
+
+	cmd := exec.Command(widget.settings.cmd, widget.settings.args...)
+	cmd.Dir = widget.settings.workingDir
+	cmd.Env = widget.environment()
+
+	// Hold the lock until the process is recorded, so that its messages and exit aren't
+	// handled before then
+	widget.mutex.Lock()
+
+	proc, err := startProcess(cmd, widget.handleMessage, widget.handleExit)
+
+	widget.proc = proc
+	widget.procErr = err
+	widget.selectedSent = -1
+	widget.mutex.Unlock()
+
+	widget.SetError(err)
+}
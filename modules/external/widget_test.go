@@ -0,0 +1,109 @@
+package external
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWidget(script string) *Widget {
+	return newTestAppWidget(nil, script)
+}
+
+// newTestApp runs an app on a simulated screen, so that widget updates are made on its
+// UI goroutine as they are in wtfutil
+func newTestApp(t *testing.T) *tview.Application {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	assert.NoError(t, screen.Init())
+
+	tviewApp := tview.NewApplication().SetScreen(screen)
+	go func() { _ = tviewApp.Run() }()
+
+	return tviewApp
+}
+
+// onUI calls the function on the app's UI goroutine and waits for it to finish
+func onUI(tviewApp *tview.Application, fn func()) {
+	done := make(chan bool)
+	tviewApp.QueueUpdate(func() {
+		fn()
+		close(done)
+	})
+	<-done
+}
+
+func newTestAppWidget(tviewApp *tview.Application, script string) *Widget {
+	moduleConfig, _ := config.ParseYaml("type: external\nenabled: true\ncmd: sh\n")
+	globalConfig, _ := config.ParseYaml("wtf:\n  mods: {}\n")
+
+	settings := NewSettingsFromYAML("external", moduleConfig, globalConfig)
+	settings.args = []string{"-c", script}
+
+	redrawChan := make(chan bool, 100)
+	go func() {
+		for range redrawChan {
+		}
+	}()
+
+	return NewWidget(tviewApp, redrawChan, nil, settings)
+}
+
+func Test_Widget(t *testing.T) {
+	tviewApp := newTestApp(t)
+	defer tviewApp.Stop()
+
+	widget := newTestAppWidget(tviewApp, `
+echo '{"title": "Deploys", "rows": [{"text": "api"}, {"text": "web"}], "keys": [{"key": "d", "help": "Deploy"}, {"key": "Enter", "help": "Open"}]}'
+while read line; do
+  case "$line" in
+    *'"key":"d","row":{"text":"web"},"type":"key"'*) echo '{"status": "deploying web"}' ;;
+    *'"type":"refresh"'*) echo '{"error": "cannot reach server"}' ;;
+  esac
+done
+`)
+	defer onUI(tviewApp, widget.Stop)
+
+	widget.Refresh()
+
+	assert.Eventually(t, func() bool { return widget.Rendered().Title == "Deploys" }, 5*time.Second, 10*time.Millisecond)
+
+	onUI(tviewApp, func() {
+		assert.Contains(t, widget.AssignedChars(), "d")
+		assert.Contains(t, widget.HelpText(), "Enter")
+
+		widget.Next()
+		widget.Next()
+		widget.pressKey("d")
+	})
+
+	assert.Eventually(t, func() bool { return widget.Rendered().Title == "Deploys - deploying web" }, 5*time.Second, 10*time.Millisecond)
+
+	widget.Refresh()
+
+	assert.Eventually(t, func() bool { return widget.LastError() != nil }, 5*time.Second, 10*time.Millisecond)
+	assert.EqualError(t, widget.LastError(), "cannot reach server")
+}
+
+func Test_Widget_WhenProcessExits(t *testing.T) {
+	widget := newTestWidget(`echo 'out of cheese' >&2; exit 1`)
+	defer widget.Stop()
+
+	widget.Refresh()
+
+	assert.Eventually(t, func() bool { return widget.LastError() != nil }, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, widget.LastError().Error(), "exit status 1: out of cheese")
+	assert.Contains(t, widget.Rendered().Content, "out of cheese")
+}
+
+func Test_Widget_WithoutCommand(t *testing.T) {
+	widget := newTestWidget("")
+	widget.settings.cmd = ""
+
+	widget.Refresh()
+
+	assert.EqualError(t, widget.LastError(), "no command configured: set `cmd` to the command to run")
+}
@@ -34,13 +34,15 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings := Settings{
 		Common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
 
-		domain:                  ymlConfig.UString("domain", ""),
-		password:                ymlConfig.UString("password", os.Getenv("WTF_GERRIT_PASSWORD")),
-		projects:                ymlConfig.UList("projects"),
-		username:                ymlConfig.UString("username", ""),
-		verifyServerCertificate: ymlConfig.UBool("verifyServerCertificate", true),
+		domain:   ymlConfig.UString("domain", ""),
+		password: ymlConfig.UString("password", os.Getenv("WTF_GERRIT_PASSWORD")),
+		projects: ymlConfig.UList("projects"),
+		username: ymlConfig.UString("username", ""),
 	}
 
+	// Shown in the help, and taken from the HTTP settings that the client is made from
+	settings.verifyServerCertificate = settings.HTTP.VerifyServerCertificate
+
 	cfg.ModuleSecret(name, globalConfig, &settings.password).
 		Service(settings.domain).Load()
 
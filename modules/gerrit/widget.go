@@ -1,13 +1,13 @@
 package gerrit
 
 import (
-	"crypto/tls"
 	"fmt"
 	"net/http"
 	"regexp"
 
 	glb "github.com/andygrunwald/go-gerrit"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/httpclient"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
@@ -15,7 +15,8 @@ import (
 type Widget struct {
 	view.TextWidget
 
-	gerrit *glb.Client
+	gerrit     *glb.Client
+	httpClient *http.Client
 
 	GerritProjects []*GerritProject
 	Idx            int
@@ -35,7 +36,8 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, _ *tview.Pages
 
 		Idx: 0,
 
-		settings: settings,
+		httpClient: httpclient.New(settings.HTTP),
+		settings:   settings,
 	}
 
 	widget.initializeKeyboardControls()
@@ -48,15 +50,6 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, _ *tview.Pages
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Refresh() {
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: !widget.settings.verifyServerCertificate,
-			},
-			Proxy: http.ProxyFromEnvironment,
-		},
-	}
-
 	gerritUrl := widget.settings.domain
 	submatches := GerritURLPattern.FindAllStringSubmatch(widget.settings.domain, -1)
 
@@ -70,7 +63,7 @@ func (widget *Widget) Refresh() {
 			submatch[2],
 		)
 	}
-	gerrit, err := glb.NewClient(gerritUrl, httpClient)
+	gerrit, err := glb.NewClient(gerritUrl, widget.httpClient)
 	if err != nil {
 		widget.err = err
 		widget.gerrit = nil
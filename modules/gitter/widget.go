@@ -1,6 +1,7 @@
 package gitter
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/rivo/tview"
@@ -24,7 +25,7 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.P
 		settings: settings,
 	}
 
-	widget.SetRenderFunction(widget.Refresh)
+	widget.SetRenderFunction(widget.display)
 	widget.initializeKeyboardControls()
 
 	return &widget
@@ -32,42 +33,51 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.P
 
 /* -------------------- Exported Functions -------------------- */
 
+// Refresh fetches the room's latest messages and displays them. If fetching them fails,
+// the messages fetched last are displayed
 func (widget *Widget) Refresh() {
 	if widget.Disabled() {
 		return
 	}
 
-	room, err := GetRoom(widget.settings.roomURI, widget.settings.apiToken)
-	if err != nil {
-		widget.Redraw(func() (string, string, bool) { return widget.CommonSettings().Title, err.Error(), true })
-		return
-	}
+	messages, err := widget.fetch()
+	widget.SetError(err)
 
-	if room == nil {
-		widget.Redraw(func() (string, string, bool) { return widget.CommonSettings().Title, "No room", true })
-		return
+	if err == nil {
+		widget.messages = messages
+		widget.SetItemCount(len(messages))
 	}
 
-	messages, err := GetMessages(room.ID, widget.settings.numberOfMessages, widget.settings.apiToken)
+	widget.display()
+}
+
+/* -------------------- Unexported Functions -------------------- */
 
+func (widget *Widget) fetch() ([]Message, error) {
+	room, err := GetRoom(widget.settings.roomURI, widget.settings.apiToken)
 	if err != nil {
-		widget.Redraw(func() (string, string, bool) { return widget.CommonSettings().Title, err.Error(), true })
-		return
+		return nil, err
 	}
-	widget.messages = messages
-	widget.SetItemCount(len(messages))
 
-	widget.display()
-}
+	if room == nil {
+		return nil, errors.New("No room")
+	}
 
-/* -------------------- Unexported Functions -------------------- */
+	return GetMessages(room.ID, widget.settings.numberOfMessages, widget.settings.apiToken)
+}
 
+// display draws the messages that were fetched last. It doesn't fetch them, so it can be
+// called on every key press
 func (widget *Widget) display() {
 	widget.Redraw(widget.content)
 }
 
 func (widget *Widget) content() (string, string, bool) {
 	title := fmt.Sprintf("%s - %s", widget.CommonSettings().Title, widget.settings.roomURI)
+	if widget.messages == nil && widget.LastError() != nil {
+		return widget.CommonSettings().Title, widget.LastError().Error(), true
+	}
+
 	if widget.messages == nil || len(widget.messages) == 0 {
 		return title, "No Messages To Display", false
 	}
@@ -8,7 +8,8 @@ import (
 type Widget struct {
 	view.TextWidget
 
-	settings *Settings
+	settings       *Settings
+	websiteReports []websiteReport
 }
 
 func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Settings) *Widget {
@@ -18,12 +19,32 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Sett
 		settings: settings,
 	}
 
+	// Reports are slow to fetch, so show the last ones fetched until then
+	if widget.LoadCache(&widget.websiteReports) {
+		widget.Draw(widget.content)
+	}
+
 	return &widget
 }
 
 func (widget *Widget) Refresh() {
-	websiteReports := widget.fetch()
-	contentTable := widget.createTable(websiteReports)
+	websiteReports, err := widget.fetch()
+	widget.SetError(err)
+
+	// Keep showing the last reports fetched if this refresh failed
+	if err == nil {
+		widget.websiteReports = websiteReports
+		widget.SaveCache(websiteReports)
+	}
+
+	widget.Redraw(widget.content)
+}
+
+func (widget *Widget) content() (string, string, bool) {
+	title := widget.CommonSettings().Title
+	if widget.websiteReports == nil && widget.LastError() != nil {
+		return title, widget.LastError().Error(), true
+	}
 
-	widget.Redraw(func() (string, string, bool) { return widget.CommonSettings().Title, contentTable, false })
+	return title, widget.createTable(widget.websiteReports), false
 }
@@ -0,0 +1,32 @@
+package googleanalytics
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+	gaV4 "google.golang.org/api/analyticsreporting/v4"
+)
+
+func Test_Refresh_WhenFetchFails(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "missing.json")
+
+	moduleConfig, _ := config.ParseYaml("enabled: true\nsecretFile: " + secretFile + "\n")
+	globalConfig, _ := config.ParseYaml("wtf:\n  mods: {}\n")
+
+	widget := NewWidget(nil, make(chan bool, 10), NewSettingsFromYAML("googleanalytics", moduleConfig, globalConfig))
+
+	widget.Refresh()
+
+	assert.Error(t, widget.LastError())
+	assert.Contains(t, widget.Rendered().Content, "unable to read secretFile")
+
+	// The last reports fetched are kept, rather than replaced by nothing
+	reports := []websiteReport{{Name: "blog", Report: &gaV4.GetReportsResponse{}}}
+	widget.websiteReports = reports
+	widget.Refresh()
+
+	assert.Error(t, widget.LastError())
+	assert.Equal(t, reports, widget.websiteReports)
+}
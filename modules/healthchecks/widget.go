@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
@@ -19,7 +20,7 @@ type Widget struct {
 	view.ScrollableWidget
 	checks   []Checks
 	settings *Settings
-	err      error
+	tracker  *notify.Tracker
 }
 
 type Health struct {
@@ -50,6 +51,7 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.P
 	widget := &Widget{
 		ScrollableWidget: view.NewScrollableWidget(tviewApp, redrawChan, pages, settings.Common),
 		settings:         settings,
+		tracker:          notify.NewTracker(settings.Module),
 	}
 
 	widget.SetRenderFunction(widget.Render)
@@ -66,9 +68,15 @@ func (widget *Widget) Refresh() {
 	}
 
 	checks, err := widget.getExistingChecks()
-	widget.checks = checks
-	widget.err = err
-	widget.SetItemCount(len(checks))
+	widget.SetError(err)
+
+	// Keep showing the last checks fetched if this refresh failed
+	if err == nil {
+		widget.checks = checks
+		widget.SetItemCount(len(checks))
+		widget.trackChecks(checks)
+	}
+
 	widget.Render()
 }
 
@@ -89,8 +97,8 @@ func (widget *Widget) content() (string, string, bool) {
 
 	title := fmt.Sprintf("Healthchecks (%d/%d)", numUp, len(widget.checks))
 
-	if widget.err != nil {
-		return title, widget.err.Error(), true
+	if widget.checks == nil && widget.LastError() != nil {
+		return title, widget.LastError().Error(), true
 	}
 
 	if widget.checks == nil {
@@ -129,6 +137,24 @@ func (widget *Widget) contentFrom(checks []Checks) string {
 	return str
 }
 
+// trackChecks notifies on checks that are added, removed or change status, and on the
+// number of checks that are down
+func (widget *Widget) trackChecks(checks []Checks) {
+	down := 0
+	items := make([]notify.Item, len(checks))
+
+	for idx, check := range checks {
+		items[idx] = notify.Item{ID: check.UpdateURL, Name: check.Name, Status: check.Status}
+
+		if check.Status == "down" {
+			down++
+		}
+	}
+
+	widget.tracker.Track(items)
+	widget.tracker.Measure("down", float64(down))
+}
+
 func timeSincePing(ts time.Time) string {
 	dur := time.Since(ts)
 	return dur.Truncate(time.Second).String()
@@ -13,7 +13,6 @@ type Widget struct {
 
 	settings *Settings
 	statuses []*Status
-	err      error
 }
 
 // NewWidget creates a new instance of a widget
@@ -24,6 +23,11 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Sett
 		settings: settings,
 	}
 
+	// Breaches are checked infrequently, so show the last known statuses until then
+	if widget.LoadCache(&widget.statuses) {
+		widget.Draw(widget.content)
+	}
+
 	return widget
 }
 
@@ -48,13 +52,12 @@ func (widget *Widget) Fetch(accounts []string) ([]*Status, error) {
 // Refresh updates the data for this widget and displays it onscreen
 func (widget *Widget) Refresh() {
 	statuses, err := widget.Fetch(widget.settings.accounts)
+	widget.SetError(err)
 
-	if err != nil {
-		widget.err = err
-		widget.statuses = nil
-	} else {
-		widget.err = nil
+	// Keep showing the last statuses fetched if this refresh failed
+	if err == nil {
 		widget.statuses = statuses
+		widget.SaveCache(statuses)
 	}
 
 	widget.Redraw(widget.content)
@@ -64,8 +67,8 @@ func (widget *Widget) Refresh() {
 
 func (widget *Widget) content() (string, string, bool) {
 	title := widget.CommonSettings().Title
-	if widget.err != nil {
-		return title, widget.err.Error(), true
+	if widget.statuses == nil && widget.LastError() != nil {
+		return title, widget.LastError().Error(), true
 	}
 
 	title += widget.sinceDateForTitle()
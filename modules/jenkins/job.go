@@ -1,7 +1,40 @@
 package jenkins
 
+import (
+	"net/url"
+	"strings"
+)
+
 type Job struct {
 	Name  string `json:"name"`
 	Url   string `json:"url"`
 	Color string `json:"color"`
 }
+
+// DisplayName returns the job's name, unescaped
+func (job Job) DisplayName() string {
+	name, _ := url.QueryUnescape(job.Name)
+	return name
+}
+
+// Status returns the state of the job's last build, from the color Jenkins gives it:
+// "success", "failed", "unstable", "aborted", "disabled" or "notBuilt", or "building"
+// while a build is running
+func (job Job) Status() string {
+	if strings.HasSuffix(job.Color, "_anime") {
+		return "building"
+	}
+
+	switch job.Color {
+	case "blue":
+		return "success"
+	case "red":
+		return "failed"
+	case "yellow":
+		return "unstable"
+	case "notbuilt":
+		return "notBuilt"
+	default:
+		return job.Color
+	}
+}
@@ -0,0 +1,28 @@
+package jenkins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Job_Status(t *testing.T) {
+	tests := []struct {
+		color    string
+		expected string
+	}{
+		{color: "blue", expected: "success"},
+		{color: "red", expected: "failed"},
+		{color: "yellow", expected: "unstable"},
+		{color: "aborted", expected: "aborted"},
+		{color: "disabled", expected: "disabled"},
+		{color: "notbuilt", expected: "notBuilt"},
+		{color: "red_anime", expected: "building"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Job{Color: tt.color}.Status())
+		})
+	}
+}
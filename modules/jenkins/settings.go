@@ -28,14 +28,16 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings := Settings{
 		Common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
 
-		apiKey:                  ymlConfig.UString("apiKey", ymlConfig.UString("apikey", os.Getenv("WTF_JENKINS_API_KEY"))),
-		jobNameRegex:            ymlConfig.UString("jobNameRegex", ".*"),
-		successBallColor:        ymlConfig.UString("successBallColor", "blue"),
-		url:                     ymlConfig.UString("url"),
-		user:                    ymlConfig.UString("user"),
-		verifyServerCertificate: ymlConfig.UBool("verifyServerCertificate", true),
+		apiKey:           ymlConfig.UString("apiKey", ymlConfig.UString("apikey", os.Getenv("WTF_JENKINS_API_KEY"))),
+		jobNameRegex:     ymlConfig.UString("jobNameRegex", ".*"),
+		successBallColor: ymlConfig.UString("successBallColor", "blue"),
+		url:              ymlConfig.UString("url"),
+		user:             ymlConfig.UString("user"),
 	}
 
+	// Set from settings.HTTP, which is what the HTTP client actually uses
+	settings.verifyServerCertificate = settings.HTTP.VerifyServerCertificate
+
 	cfg.ModuleSecret(name, globalConfig, &settings.apiKey).
 		Service(settings.url).Load()
 
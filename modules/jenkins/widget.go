@@ -2,28 +2,39 @@ package jenkins
 
 import (
 	"fmt"
-	"net/url"
+	"net/http"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/httpclient"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
 
 type Widget struct {
-	view.ScrollableWidget
+	view.TableWidget[Job]
 
-	settings *Settings
-	view     *View
-	err      error
+	httpClient *http.Client
+	settings   *Settings
+	tracker    *notify.Tracker
+	view       *View
+	err        error
 }
 
 func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
-		ScrollableWidget: view.NewScrollableWidget(tviewApp, redrawChan, pages, settings.Common),
+		TableWidget: view.NewTableWidget[Job](tviewApp, redrawChan, pages, settings.Common),
 
-		settings: settings,
+		httpClient: httpclient.New(settings.HTTP),
+		settings:   settings,
+		tracker:    notify.NewTracker(settings.Module),
 	}
 
+	widget.Table.SetColumns(
+		view.Column[Job]{Key: "name", Title: "Name", Value: func(job Job) interface{} { return job.DisplayName() }, Color: widget.jobColor},
+		view.Column[Job]{Key: "status", Title: "Status", Value: func(job Job) interface{} { return job.Status() }},
+	)
+
 	widget.SetRenderFunction(widget.Render)
 	widget.initializeKeyboardControls()
 
@@ -46,9 +57,11 @@ func (widget *Widget) Refresh() {
 
 	if err != nil {
 		widget.err = err
-		widget.SetItemCount(0)
+		widget.SetItems(nil)
 	} else {
-		widget.SetItemCount(len(widget.view.Jobs))
+		widget.err = nil
+		widget.SetItems(widget.view.Jobs)
+		widget.trackJobs(widget.view.Jobs)
 	}
 
 	widget.Render()
@@ -61,7 +74,11 @@ func (widget *Widget) Render() {
 }
 
 func (widget *Widget) content() (string, string, bool) {
-	title := fmt.Sprintf("%s: [red]%s", widget.CommonSettings().Title, widget.view.Name)
+	title := widget.CommonSettings().Title
+	if widget.view != nil {
+		title = fmt.Sprintf("%s: [red]%s", title, widget.view.Name)
+	}
+
 	if widget.err != nil {
 		return title, widget.err.Error(), true
 	}
@@ -69,22 +86,25 @@ func (widget *Widget) content() (string, string, bool) {
 		return title, "No content to display", false
 	}
 
-	var str string
-	jobs := widget.view.Jobs
-	for idx, job := range jobs {
-		jobName, _ := url.QueryUnescape(job.Name)
+	return title, widget.TableContent(), false
+}
 
-		row := fmt.Sprintf(
-			`[%s] [%s]%-6s[white]`,
-			widget.RowColor(idx),
-			widget.jobColor(job),
-			jobName,
-		)
+// trackJobs notifies on jobs that are added, removed or change status, and on the number
+// of failed jobs
+func (widget *Widget) trackJobs(jobs []Job) {
+	failed := 0
+	items := make([]notify.Item, len(jobs))
+
+	for idx, job := range jobs {
+		items[idx] = notify.Item{ID: job.Url, Name: job.DisplayName(), Status: job.Status()}
 
-		str += utils.HighlightableHelper(widget.View, row, idx, len(job.Name))
+		if job.Status() == "failed" {
+			failed++
+		}
 	}
 
-	return title, str, false
+	widget.tracker.Track(items)
+	widget.tracker.Measure("failed", float64(failed))
 }
 
 func (widget *Widget) jobColor(job Job) string {
@@ -100,9 +120,7 @@ func (widget *Widget) jobColor(job Job) string {
 }
 
 func (widget *Widget) openJob() {
-	sel := widget.GetSelected()
-	if sel >= 0 && widget.view != nil && sel < len(widget.view.Jobs) {
-		job := &widget.view.Jobs[sel]
+	if job, ok := widget.SelectedItem(); ok {
 		utils.OpenFile(job.Url)
 	}
 }
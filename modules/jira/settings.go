@@ -38,15 +38,18 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings := Settings{
 		Common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
 
-		apiKey:                  ymlConfig.UString("apiKey", ymlConfig.UString("apikey", os.Getenv("WTF_JIRA_API_KEY"))),
-		personalAccessToken:     ymlConfig.UString("personalAccessToken"),
-		domain:                  ymlConfig.UString("domain"),
-		email:                   ymlConfig.UString("email"),
-		jql:                     ymlConfig.UString("jql"),
-		username:                ymlConfig.UString("username"),
-		verifyServerCertificate: ymlConfig.UBool("verifyServerCertificate", true),
+		apiKey:              ymlConfig.UString("apiKey", ymlConfig.UString("apikey", os.Getenv("WTF_JIRA_API_KEY"))),
+		personalAccessToken: ymlConfig.UString("personalAccessToken"),
+		domain:              ymlConfig.UString("domain"),
+		email:               ymlConfig.UString("email"),
+		jql:                 ymlConfig.UString("jql"),
+		username:            ymlConfig.UString("username"),
 	}
 
+	// The HTTP client reads this through the shared HTTP settings, which also accept it in
+	// the module's http block
+	settings.verifyServerCertificate = settings.HTTP.VerifyServerCertificate
+
 	cfg.ModuleSecret(name, globalConfig, &settings.apiKey).
 		Service(settings.domain).Load()
 
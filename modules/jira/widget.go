@@ -2,8 +2,10 @@ package jira
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/httpclient"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
@@ -11,16 +13,18 @@ import (
 type Widget struct {
 	view.ScrollableWidget
 
-	result   *SearchResult
-	settings *Settings
-	err      error
+	httpClient *http.Client
+	result     *SearchResult
+	settings   *Settings
+	err        error
 }
 
 func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
 		ScrollableWidget: view.NewScrollableWidget(tviewApp, redrawChan, pages, settings.Common),
 
-		settings: settings,
+		httpClient: httpclient.New(settings.HTTP),
+		settings:   settings,
 	}
 
 	widget.SetRenderFunction(widget.Render)
@@ -1,7 +1,6 @@
 package krisinformation
 
 import (
-	"fmt"
 	"math"
 	"net/http"
 	"strconv"
@@ -143,7 +142,7 @@ func (c *Client) getKrisinformation() (items []Item, err error) {
 				}
 
 				distance := DistanceInMeters(kris_latitude, kris_longitude, c.latitude, c.longitude)
-				logger.Log(fmt.Sprintf("Distance: %f", distance/1000)) // KM
+				logger.ForModule("", "krisinformation").Debug("distance to message", "km", distance/1000)
 				if distance < float64(c.radius) {
 					item := Item{
 						PushMessage: data[i].PushMessage,
@@ -51,6 +51,7 @@ func (widget *Widget) Refresh() {
 	client, err := widget.getInstance()
 
 	if err != nil {
+		widget.SetError(err)
 		widget.Redraw(func() (string, string, bool) { return title, err.Error(), true })
 		return
 	}
@@ -60,6 +61,7 @@ func (widget *Widget) Refresh() {
 	if utils.Includes(widget.objects, "nodes") {
 		nodeList, nodeError := client.getNodes()
 		if nodeError != nil {
+			widget.SetError(fmt.Errorf("getting node data: %w", nodeError))
 			widget.Redraw(func() (string, string, bool) { return title, "[red] Error getting node data [white]\n", true })
 			return
 		}
@@ -73,6 +75,7 @@ func (widget *Widget) Refresh() {
 	if utils.Includes(widget.objects, "deployments") {
 		deploymentList, deploymentError := client.getDeployments(widget.namespaces)
 		if deploymentError != nil {
+			widget.SetError(fmt.Errorf("getting deployment data: %w", deploymentError))
 			widget.Redraw(func() (string, string, bool) { return title, "[red] Error getting deployment data [white]\n", true })
 			return
 		}
@@ -86,6 +89,7 @@ func (widget *Widget) Refresh() {
 	if utils.Includes(widget.objects, "pods") {
 		podList, podError := client.getPods(widget.namespaces)
 		if podError != nil {
+			widget.SetError(fmt.Errorf("getting pod data: %w", podError))
 			widget.Redraw(func() (string, string, bool) { return title, "[red] Error getting pod data [white]\n", false })
 			return
 		}
@@ -96,6 +100,7 @@ func (widget *Widget) Refresh() {
 		content += "\n"
 	}
 
+	widget.SetError(nil)
 	widget.Redraw(func() (string, string, bool) { return title, content, false })
 }
 
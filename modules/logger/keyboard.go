@@ -0,0 +1,9 @@
+package logger
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.InitializeHelpTextKeyboardControl(widget.ShowHelp)
+	widget.InitializeRefreshKeyboardControl(widget.Refresh)
+
+	widget.SetKeyboardChar("l", widget.nextLevel, "Show entries of the next, more severe, level and above")
+	widget.SetKeyboardChar("L", widget.prevLevel, "Show entries of the previous, less severe, level and above")
+}
@@ -3,6 +3,8 @@ package logger
 import (
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/cfg"
+	log "github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
 )
 
 const (
@@ -12,12 +14,24 @@ const (
 
 type Settings struct {
 	*cfg.Common
+
+	level   log.Level `help:"The least severe level of the entries to show." values:"debug, info, warn, error" optional:"true"`
+	modules []string  `help:"Only show entries from these modules, by name or type. Entries from wtf itself are shown as 'wtf'." optional:"true"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
 	settings := Settings{
 		Common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
+
+		modules: utils.ToStrs(ymlConfig.UList("modules")),
+	}
+
+	// An unknown level shows everything rather than hiding entries that might explain why
+	level, err := log.ParseLevel(ymlConfig.UString("level", log.LevelDebug.String()))
+	if err != nil {
+		level = log.LevelDebug
 	}
+	settings.level = level
 
 	return &settings
 }
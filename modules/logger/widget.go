@@ -11,24 +11,37 @@ import (
 )
 
 const (
-	maxBufferSize int64 = 1024
+	maxBufferSize int64 = 64 * 1024
+
+	// wtfModule is the name that entries logged by wtf itself, rather than by a module,
+	// are filtered by
+	wtfModule = "wtf"
 )
 
+var levelColors = map[log.Level]string{
+	log.LevelDebug: "gray",
+	log.LevelInfo:  "white",
+	log.LevelWarn:  "yellow",
+	log.LevelError: "red",
+}
+
 type Widget struct {
 	view.TextWidget
 
-	filePath string
+	level    log.Level
 	settings *Settings
 }
 
-func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Settings) *Widget {
+func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
-		TextWidget: view.NewTextWidget(tviewApp, redrawChan, nil, settings.Common),
+		TextWidget: view.NewTextWidget(tviewApp, redrawChan, pages, settings.Common),
 
-		filePath: log.LogFilePath(),
+		level:    settings.level,
 		settings: settings,
 	}
 
+	widget.initializeKeyboardControls()
+
 	return &widget
 }
 
@@ -40,31 +53,103 @@ func (widget *Widget) Refresh() {
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) content() (string, string, bool) {
+	title := fmt.Sprintf("%s (%s+)", widget.CommonSettings().Title, widget.level)
+
 	if log.LogFileMissing() {
-		return widget.CommonSettings().Title, "File missing", false
+		return title, "File missing", false
 	}
 
-	logLines := widget.tailFile()
 	str := ""
 
-	for _, line := range logLines {
-		chunks := strings.Split(line, " ")
+	for _, line := range tailFile(log.LogFilePath()) {
+		if line == "" {
+			continue
+		}
 
-		if len(chunks) >= 4 {
-			str += fmt.Sprintf(
-				"[green]%s[white] [yellow]%s[white] %s\n",
-				chunks[0],
-				chunks[1],
-				strings.Join(chunks[3:], " "),
-			)
+		entry := log.ParseEntry(line)
+		if !widget.shows(entry) {
+			continue
 		}
+
+		str += formatEntry(entry)
 	}
 
-	return widget.CommonSettings().Title, str, false
+	return title, str, false
 }
 
-func (widget *Widget) tailFile() []string {
-	file, err := os.Open(widget.filePath)
+// shows returns TRUE if the entry is at or above the level being shown, and comes from
+// one of the modules being shown
+func (widget *Widget) shows(entry log.Entry) bool {
+	if entry.Level < widget.level {
+		return false
+	}
+
+	if len(widget.settings.modules) == 0 {
+		return true
+	}
+
+	for _, module := range widget.settings.modules {
+		switch {
+		case entry.Module == "" && entry.Type == "":
+			if strings.EqualFold(module, wtfModule) {
+				return true
+			}
+		case strings.EqualFold(module, entry.Module), strings.EqualFold(module, entry.Type):
+			return true
+		}
+	}
+
+	return false
+}
+
+func (widget *Widget) nextLevel() {
+	if widget.level < log.LevelError {
+		widget.level++
+	}
+	widget.Refresh()
+}
+
+func (widget *Widget) prevLevel() {
+	if widget.level > log.LevelDebug {
+		widget.level--
+	}
+	widget.Refresh()
+}
+
+// formatEntry returns the entry as a line of colored text. Lines from before the log was
+// structured only have a message, and are shown as they are
+func formatEntry(entry log.Entry) string {
+	if entry.Time.IsZero() {
+		return tview.Escape(entry.Message) + "\n"
+	}
+
+	source := entry.Module
+	if source == "" {
+		source = entry.Type
+	}
+	if source == "" {
+		source = wtfModule
+	}
+
+	str := fmt.Sprintf(
+		"[green]%s[white] [%s]%-5s[white] [blue]%s[white] %s",
+		entry.Time.Format("2006-01-02 15:04:05"),
+		levelColors[entry.Level],
+		strings.ToUpper(entry.Level.String()),
+		tview.Escape(source),
+		tview.Escape(entry.Message),
+	)
+
+	if fields := entry.FieldsString(); fields != "" {
+		str += " [gray]" + tview.Escape(fields) + "[white]"
+	}
+
+	return str + "\n"
+}
+
+// tailFile returns the lines at the end of the file, newest first
+func tailFile(filePath string) []string {
+	file, err := os.Open(filePath)
 	if err != nil {
 		return []string{}
 	}
@@ -88,13 +173,16 @@ func (widget *Widget) tailFile() []string {
 		return []string{}
 	}
 
-	logLines := strings.Split(string(buff), "\n")
+	logLines := strings.Split(strings.TrimRight(string(buff), "\n"), "\n")
+
+	// The first line is likely cut off part way through, unless the whole file was read
+	if startPos > 0 && len(logLines) > 1 {
+		logLines = logLines[1:]
+	}
 
 	// Reverse the array of lines
-	// Offset by two to account for the blank line at the end
-	last := len(logLines) - 2
-	for i := 0; i < len(logLines)/2; i++ {
-		logLines[i], logLines[last-i] = logLines[last-i], logLines[i]
+	for i, j := 0, len(logLines)-1; i < j; i, j = i+1, j-1 {
+		logLines[i], logLines[j] = logLines[j], logLines[i]
 	}
 
 	return logLines
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	log "github.com/wtfutil/wtf/logger"
+)
+
+func Test_shows(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    log.Level
+		modules  []string
+		entry    log.Entry
+		expected bool
+	}{
+		{
+			name:     "below the level",
+			level:    log.LevelWarn,
+			entry:    log.Entry{Level: log.LevelInfo, Module: "ci"},
+			expected: false,
+		},
+		{
+			name:     "at the level with no module filter",
+			level:    log.LevelWarn,
+			entry:    log.Entry{Level: log.LevelWarn, Module: "ci"},
+			expected: true,
+		},
+		{
+			name:     "matching the module name",
+			modules:  []string{"CI"},
+			entry:    log.Entry{Module: "ci", Type: "jenkins"},
+			expected: true,
+		},
+		{
+			name:     "matching the module type",
+			modules:  []string{"jenkins"},
+			entry:    log.Entry{Module: "ci", Type: "jenkins"},
+			expected: true,
+		},
+		{
+			name:     "from another module",
+			modules:  []string{"jenkins"},
+			entry:    log.Entry{Module: "pager", Type: "pagerduty"},
+			expected: false,
+		},
+		{
+			name:     "from wtf itself",
+			modules:  []string{"wtf"},
+			entry:    log.Entry{Message: "config watcher failed"},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			widget := &Widget{
+				level:    tt.level,
+				settings: &Settings{modules: tt.modules},
+			}
+
+			assert.Equal(t, tt.expected, widget.shows(tt.entry))
+		})
+	}
+}
+
+func Test_formatEntry(t *testing.T) {
+	entry := log.Entry{
+		Time:    time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC),
+		Level:   log.LevelError,
+		Type:    "jenkins",
+		Message: "build failed",
+		Fields:  map[string]interface{}{"job": "deploy"},
+	}
+
+	assert.Equal(
+		t,
+		"[green]2022-01-02 15:04:05[white] [red]ERROR[white] [blue]jenkins[white] build failed [gray]job=deploy[white]\n",
+		formatEntry(entry),
+	)
+
+	assert.Equal(t, "old [line[]\n", formatEntry(log.Entry{Message: "old [line]"}))
+}
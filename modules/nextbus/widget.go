@@ -79,13 +79,13 @@ func getNextBus(agency string, route string, stopID string) string {
 	url := fmt.Sprintf("https://webservices.umoiq.com/service/publicJSONFeed?command=predictions&a=%s&r=%s&stopId=%s", agency, route, stopID)
 	resp, err := http.Get(url)
 	if err != nil {
-		logger.Log(fmt.Sprintf("[nextbus] Error: Failed to make requests to umoiq for next bus predictions. Reason: %s", err))
+		logger.ForModule("", "nextbus").Error("failed to request next bus predictions from umoiq", "error", err)
 		return "[nextbus] error calling umoiq"
 	}
 	body, readErr := io.ReadAll(resp.Body)
 
 	if (readErr) != nil {
-		logger.Log(fmt.Sprintf("[nextbus] Error: Failed to parse response body from umoiq. Reason: %s", err))
+		logger.ForModule("", "nextbus").Error("failed to read the response body from umoiq", "error", readErr)
 		return "[nextbus] error parsing response body"
 	}
 
@@ -96,7 +96,7 @@ func getNextBus(agency string, route string, stopID string) string {
 	// partial unmarshal, we don't have r.Predictions.Direction.PredictionRaw <- YET
 	unmarshalError := json.Unmarshal(body, &parsedResponse)
 	if unmarshalError != nil {
-		logger.Log(fmt.Sprintf("[nextbus] Error: Failed to unmarshal body from umoiq. Reason: %s", err))
+		logger.ForModule("", "nextbus").Error("failed to unmarshal the response body from umoiq", "error", unmarshalError)
 		return "[nextbus] error unmarshalling response body"
 	}
 
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
@@ -12,7 +13,10 @@ import (
 type Widget struct {
 	view.TextWidget
 
-	settings *Settings
+	err             error
+	onCallResponses []*OnCallResponse
+	settings        *Settings
+	tracker         *notify.Tracker
 }
 
 func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Settings) *Widget {
@@ -20,6 +24,7 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Sett
 		TextWidget: view.NewTextWidget(tviewApp, redrawChan, nil, settings.Common),
 
 		settings: settings,
+		tracker:  notify.NewTracker(settings.Module),
 	}
 
 	return &widget
@@ -28,26 +33,33 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Sett
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Refresh() {
+	onCallResponses, err := widget.Fetch(
+		widget.settings.scheduleIdentifierType,
+		widget.settings.schedule,
+	)
+
+	widget.err = err
+	widget.onCallResponses = onCallResponses
+
+	if err == nil {
+		widget.trackOnCalls(onCallResponses)
+	}
+
 	widget.Redraw(widget.content)
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) content() (string, string, bool) {
-	onCallResponses, err := widget.Fetch(
-		widget.settings.scheduleIdentifierType,
-		widget.settings.schedule,
-	)
 	title := widget.CommonSettings().Title
 
 	var content string
 	wrap := false
-	if err != nil {
+	if widget.err != nil {
 		wrap = true
-		content = err.Error()
+		content = widget.err.Error()
 	} else {
-
-		for _, data := range onCallResponses {
+		for _, data := range widget.onCallResponses {
 			if (len(data.OnCallData.Recipients) == 0) && !widget.settings.displayEmpty {
 				continue
 			}
@@ -71,3 +83,23 @@ func (widget *Widget) cleanScheduleName(schedule string) string {
 	cleanedName := strings.ReplaceAll(schedule, "_", " ")
 	return fmt.Sprintf(" [green]%s[white]\n", cleanedName)
 }
+
+// trackOnCalls notifies on schedules whose on-call recipients change. The status of each
+// schedule is who is on call
+func (widget *Widget) trackOnCalls(onCallResponses []*OnCallResponse) {
+	items := make([]notify.Item, len(onCallResponses))
+	for idx, data := range onCallResponses {
+		onCall := "no one"
+		if len(data.OnCallData.Recipients) > 0 {
+			onCall = strings.Join(utils.NamesFromEmails(data.OnCallData.Recipients), ", ")
+		}
+
+		items[idx] = notify.Item{
+			ID:     data.OnCallData.Parent.ID,
+			Name:   data.OnCallData.Parent.Name,
+			Status: onCall,
+		}
+	}
+
+	widget.tracker.Track(items)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/PagerDuty/go-pagerduty"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
@@ -20,6 +21,7 @@ type Widget struct {
 	view.TextWidget
 
 	settings *Settings
+	tracker  *notify.Tracker
 }
 
 // NewWidget creates and returns an instance of PagerDuty widget
@@ -28,6 +30,7 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Sett
 		TextWidget: view.NewTextWidget(tviewApp, redrawChan, nil, settings.Common),
 
 		settings: settings,
+		tracker:  notify.NewTracker(settings.Module),
 	}
 
 	return &widget
@@ -46,6 +49,10 @@ func (widget *Widget) Refresh() {
 		teamIDs := utils.ToStrs(widget.settings.teamIDs)
 		userIDs := utils.ToStrs(widget.settings.userIDs)
 		incidents, err2 = GetIncidents(widget.settings.apiKey, teamIDs, userIDs)
+
+		if err2 == nil {
+			widget.trackIncidents(incidents)
+		}
 	}
 
 	if widget.settings.showSchedules {
@@ -174,6 +181,18 @@ func (widget *Widget) onCallEndSummary(onCall *pagerduty.OnCall) string {
 	return end.Format(onCallTimeDisplayLayout)
 }
 
+// trackIncidents notifies on incidents that are opened, resolved or change status, and
+// on the number of open incidents
+func (widget *Widget) trackIncidents(incidents []pagerduty.Incident) {
+	items := make([]notify.Item, len(incidents))
+	for idx, incident := range incidents {
+		items[idx] = notify.Item{ID: incident.ID, Name: incident.Summary, Status: incident.Status}
+	}
+
+	widget.tracker.Track(items)
+	widget.tracker.Measure("incidents", float64(len(incidents)))
+}
+
 // userSummary returns the name of the person assigned to the specified onCall schedule
 func (widget *Widget) userSummary(onCall *pagerduty.OnCall) string {
 	summary := onCall.User.Summary
@@ -6,7 +6,6 @@ import (
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
-	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 	"gopkg.in/yaml.v2"
@@ -131,7 +130,7 @@ func (widget *Widget) authorizeWorkFlow() (string, string, bool) {
 		requestToken, err := widget.client.ObtainRequestToken()
 
 		if err != nil {
-			logger.Log(err.Error())
+			widget.Logger().Error("failed to obtain a request token", "error", err)
 			return title, err.Error(), true
 		}
 		widget.settings.requestKey = &requestToken
@@ -143,7 +142,7 @@ func (widget *Widget) authorizeWorkFlow() (string, string, bool) {
 	if widget.settings.accessToken == nil {
 		accessToken, err := widget.client.GetAccessToken(*widget.settings.requestKey)
 		if err != nil {
-			logger.Log(err.Error())
+			widget.Logger().Error("failed to get an access token", "error", err)
 			redirectURL := widget.client.CreateAuthLink(*widget.settings.requestKey)
 			content := fmt.Sprintf("Please click on %s to Authorize the app", redirectURL)
 			return title, content, true
@@ -192,7 +191,7 @@ func (widget *Widget) toggleLink() {
 		item := &widget.items[sel]
 		_, err := widget.client.ModifyLink(action, item.ItemID)
 		if err != nil {
-			logger.Log(err.Error())
+			widget.Logger().Error("failed to modify link", "action", action, "error", err)
 		}
 	}
 
@@ -13,20 +13,22 @@ const (
 type Settings struct {
 	*cfg.Common
 
-	cpuCombined bool
-	showCPU     bool
-	showMem     bool
-	showSwp     bool
+	cpuCombined  bool
+	historyWidth int `help:"The width of the sparkline of each bar's recent values, shown after it. 0 hides them." optional:"true" default:"0"`
+	showCPU      bool
+	showMem      bool
+	showSwp      bool
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
 	settings := Settings{
 		Common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
 
-		cpuCombined: ymlConfig.UBool("cpuCombined", false),
-		showCPU:     ymlConfig.UBool("showCPU", true),
-		showMem:     ymlConfig.UBool("showMem", true),
-		showSwp:     ymlConfig.UBool("showSwp", true),
+		cpuCombined:  ymlConfig.UBool("cpuCombined", false),
+		historyWidth: ymlConfig.UInt("historyWidth", 0),
+		showCPU:      ymlConfig.UBool("showCPU", true),
+		showMem:      ymlConfig.UBool("showMem", true),
+		showSwp:      ymlConfig.UBool("showSwp", true),
 	}
 
 	return &settings
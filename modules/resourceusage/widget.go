@@ -74,6 +74,7 @@ func MakeGraph(widget *Widget) {
 				Percent:    int(stat),
 				ValueLabel: fmt.Sprintf("%d%%", int(stat)),
 				LabelColor: "red",
+				Sparkline:  widget.sparkline("cpu"+label, stat),
 			}
 
 			stats[nextIndex] = bar
@@ -94,6 +95,7 @@ func MakeGraph(widget *Widget) {
 			Percent:    int(memInfo.UsedPercent),
 			ValueLabel: fmt.Sprintf("%s/%s", usedMemLabel, totalMemLabel),
 			LabelColor: "green",
+			Sparkline:  widget.sparkline("mem", memInfo.UsedPercent),
 		}
 		nextIndex++
 	}
@@ -117,9 +119,11 @@ func MakeGraph(widget *Widget) {
 			Percent:    int(swapPercent * 100),
 			ValueLabel: fmt.Sprintf("%s/%s", usedSwapLabel, totalSwapLabel),
 			LabelColor: "yellow",
+			Sparkline:  widget.sparkline("swp", swapPercent*100),
 		}
 	}
 
+	widget.SaveHistory()
 	widget.BarGraph.BuildBars(stats)
 
 }
@@ -136,6 +140,19 @@ func (widget *Widget) Refresh() {
 
 /* -------------------- Unexported Functions -------------------- */
 
+// sparkline records the value in the named history, and returns the history's sparkline
+// if they're shown
+func (widget *Widget) sparkline(name string, value float64) string {
+	if widget.settings.historyWidth <= 0 {
+		return ""
+	}
+
+	history := widget.History(name)
+	history.Push(value)
+
+	return history.Sparkline(widget.settings.historyWidth)
+}
+
 func getDataFromSystem(widget *Widget) (cpuStats []float64, memInfo mem.VirtualMemoryStat) {
 	if widget.settings.showCPU {
 		rCPUStats, err := cpu.Percent(time.Duration(0), !widget.settings.cpuCombined)
@@ -22,9 +22,11 @@ type colors struct {
 type Settings struct {
 	common *cfg.Common
 
-	colors  colors
-	sort    bool
-	symbols []string `help:"An array of Yahoo Finance symbols (for example: DOCN, GME, GC=F)"`
+	colors        colors
+	historyHeight int `help:"The height, in lines, of a braille chart of each symbol's recent prices, shown below the table with their lowest, average and highest prices. 0 shows a sparkline in the table instead." optional:"true" default:"0"`
+	historyWidth  int `help:"The width of the sparkline or chart of each symbol's recent prices. 0 hides them." optional:"true" default:"0"`
+	sort          bool
+	symbols       []string `help:"An array of Yahoo Finance symbols (for example: DOCN, GME, GC=F)"`
 }
 
 // NewSettingsFromYAML creates a new settings instance from a YAML config block
@@ -39,6 +41,8 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings.colors.up = ymlConfig.UString("colors.up", "green")
 	settings.colors.drop = ymlConfig.UString("colors.drop", "firebrick")
 	settings.colors.bigdrop = ymlConfig.UString("colors.bigdrop", "red")
+	settings.historyHeight = ymlConfig.UInt("historyHeight", 0)
+	settings.historyWidth = ymlConfig.UInt("historyWidth", 0)
 	settings.sort = ymlConfig.UBool("sort", false)
 	settings.symbols = utils.ToStrs(ymlConfig.UList("symbols"))
 	return &settings
@@ -14,6 +14,7 @@ type Widget struct {
 	view.TextWidget
 
 	settings *Settings
+	yquotes  []yquote
 }
 
 // NewWidget creates and returns an instance of Widget
@@ -31,6 +32,17 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Sett
 
 // Refresh updates the onscreen contents of the widget
 func (widget *Widget) Refresh() {
+	widget.yquotes = quotes(widget.settings.symbols)
+
+	if widget.settings.historyWidth > 0 {
+		for _, yq := range widget.yquotes {
+			// Symbols that couldn't be fetched have no price to record
+			if yq.Trend != "?" {
+				widget.History(yq.Symbol).Push(yq.MarketPrice)
+			}
+		}
+		widget.SaveHistory()
+	}
 
 	// The last call should always be to the display function
 	widget.display()
@@ -39,7 +51,8 @@ func (widget *Widget) Refresh() {
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) content() string {
-	yquotes := quotes(widget.settings.symbols)
+	yquotes := make([]yquote, len(widget.yquotes))
+	copy(yquotes, widget.yquotes)
 
 	colors := map[string]string{
 		"bigup":   widget.settings.colors.bigup,
@@ -55,16 +68,50 @@ func (widget *Widget) content() string {
 	t := table.NewWriter()
 	t.SetStyle(tableStyle())
 	for _, yq := range yquotes {
-		t.AppendRow([]interface{}{
+		row := []interface{}{
 			GetMarketIcon(yq.MarketState),
 			yq.Symbol,
 			fmt.Sprintf("%8.2f %s", yq.MarketPrice, yq.Currency),
 			GetTrendIcon(yq.Trend),
 			fmt.Sprintf("[%s]%+6.2f (%+5.2f%%)", colors[yq.Trend], yq.MarketChange, yq.MarketChangePct),
-		})
+		}
+
+		if widget.settings.historyWidth > 0 && widget.settings.historyHeight <= 0 {
+			row = append(row, fmt.Sprintf("[%s]%s", colors[yq.Trend], widget.History(yq.Symbol).Sparkline(widget.settings.historyWidth)))
+		}
+
+		t.AppendRow(row)
+	}
+
+	str := t.Render()
+
+	if widget.settings.historyWidth > 0 && widget.settings.historyHeight > 0 {
+		for _, yq := range yquotes {
+			str += "\n" + widget.chart(yq.Symbol, colors[yq.Trend])
+		}
+	}
+
+	return str
+}
+
+// chart returns a braille chart of the symbol's recent prices, under its lowest, average
+// and highest prices
+func (widget *Widget) chart(symbol, color string) string {
+	history := widget.History(symbol)
+
+	labels := "no prices yet"
+	if stats, ok := history.Stats(); ok {
+		labels = stats.Labels(nil)
 	}
 
-	return t.Render()
+	return fmt.Sprintf(
+		"\n%s [%s]%s[white]\n[%s]%s[white]",
+		symbol,
+		widget.settings.common.Colors.Subheading,
+		labels,
+		color,
+		history.BrailleChart(widget.settings.historyWidth, widget.settings.historyHeight),
+	)
 }
 
 func (widget *Widget) display() {
@@ -0,0 +1,26 @@
+package yfinance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_chart(t *testing.T) {
+	ymlConfig, _ := config.ParseYaml("historyWidth: 2\nhistoryHeight: 1\n")
+	globalConfig, _ := config.ParseYaml("wtf: {}")
+	widget := NewWidget(nil, nil, NewSettingsFromYAML("yfinance", ymlConfig, globalConfig))
+
+	assert.Equal(t, "\nGME [red]no prices yet[white]\n[green][white]", widget.chart("GME", "green"))
+
+	for _, price := range []float64{10, 20, 30} {
+		widget.History("GME").Push(price)
+	}
+
+	lines := strings.Split(widget.chart("GME", "green"), "\n")
+	assert.Equal(t, 3, len(lines))
+	assert.Equal(t, "GME [red]min 10.00 avg 20.00 max 30.00[white]", lines[1])
+	assert.Equal(t, 2, len([]rune(strings.TrimSuffix(strings.TrimPrefix(lines[2], "[green]"), "[white]"))))
+}
@@ -7,8 +7,8 @@ func (widget *Widget) initializeKeyboardControls() {
 	widget.InitializeRefreshKeyboardControl(widget.Refresh)
 
 	widget.SetKeyboardChar("d", widget.Delete, "Delete item")
-	widget.SetKeyboardChar("j", widget.Prev, "Select previous item")
-	widget.SetKeyboardChar("k", widget.Next, "Select next item")
+	widget.SetKeyboardChar("j", widget.Next, "Select next item")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous item")
 	widget.SetKeyboardChar("h", widget.PrevSource, "Select previous project")
 	widget.SetKeyboardChar("c", widget.Close, "Close item")
 	widget.SetKeyboardChar("l", widget.NextSource, "Select next project")
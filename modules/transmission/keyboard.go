@@ -6,8 +6,8 @@ func (widget *Widget) initializeKeyboardControls() {
 	widget.InitializeHelpTextKeyboardControl(widget.ShowHelp)
 	widget.InitializeRefreshKeyboardControl(nil)
 
-	widget.SetKeyboardChar("j", widget.Prev, "Select previous item")
-	widget.SetKeyboardChar("k", widget.Next, "Select next item")
+	widget.SetKeyboardChar("j", widget.Next, "Select next item")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous item")
 	widget.SetKeyboardChar("u", widget.Unselect, "Clear selection")
 
 	widget.SetKeyboardKey(tcell.KeyCtrlD, widget.deleteSelectedTorrent, "Delete the selected torrent")
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/registry"
 	"github.com/wtfutil/wtf/view"
 )
 
@@ -26,6 +27,12 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Sett
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Refresh() {
-	content := fmt.Sprintf("Widget %s and/or type %s does not exist", widget.Name(), widget.CommonSettings().Module.Type)
+	moduleType := widget.CommonSettings().Module.Type
+
+	content := fmt.Sprintf("Widget %s and/or type %s does not exist in this build, see wtfutil --list-modules", widget.Name(), moduleType)
+	if registry.Known(moduleType) {
+		content = fmt.Sprintf("Module type %s is not compiled into this build, see wtfutil --list-modules", moduleType)
+	}
+
 	widget.Redraw(func() (string, string, bool) { return widget.CommonSettings().Title, content, true })
 }
@@ -7,9 +7,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/view"
 )
 
@@ -18,6 +20,7 @@ type Widget struct {
 
 	monitors []Monitor
 	settings *Settings
+	tracker  *notify.Tracker
 	err      error
 }
 
@@ -26,6 +29,7 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.P
 		ScrollableWidget: view.NewScrollableWidget(tviewApp, redrawChan, pages, settings.Common),
 
 		settings: settings,
+		tracker:  notify.NewTracker(settings.Module),
 	}
 
 	widget.SetRenderFunction(widget.Render)
@@ -62,6 +66,10 @@ func (widget *Widget) Refresh() {
 	widget.err = err
 	widget.SetItemCount(len(monitors))
 
+	if err == nil {
+		widget.trackMonitors(monitors)
+	}
+
 	widget.Render()
 }
 
@@ -138,6 +146,7 @@ func formatUptimes(str string) string {
 }
 
 type Monitor struct {
+	ID   int    `json:"id"`
 	Name string `json:"friendly_name"`
 	// Monitor state, see: https://uptimerobot.com/api/#parameters
 	State int8 `json:"status"`
@@ -145,6 +154,42 @@ type Monitor struct {
 	Uptime string `json:"custom_uptime_ratio"`
 }
 
+// Status returns the monitor's state by name, see: https://uptimerobot.com/api/#parameters
+func (monitor Monitor) Status() string {
+	switch monitor.State {
+	case 0:
+		return "paused"
+	case 1:
+		return "notChecked"
+	case 2:
+		return "up"
+	case 8:
+		return "seemsDown"
+	case 9:
+		return "down"
+	default:
+		return "unknown"
+	}
+}
+
+// trackMonitors notifies on monitors that are added, removed or change state, and on the
+// number of monitors that are down
+func (widget *Widget) trackMonitors(monitors []Monitor) {
+	down := 0
+	items := make([]notify.Item, len(monitors))
+
+	for idx, monitor := range monitors {
+		items[idx] = notify.Item{ID: strconv.Itoa(monitor.ID), Name: monitor.Name, Status: monitor.Status()}
+
+		if monitor.State == 8 || monitor.State == 9 {
+			down++
+		}
+	}
+
+	widget.tracker.Track(items)
+	widget.tracker.Measure("down", float64(down))
+}
+
 func (widget *Widget) getMonitors() ([]Monitor, error) {
 	// See: https://uptimerobot.com/api/#getMonitorsWrap
 	resp, errh := http.PostForm("https://api.uptimerobot.com/v2/getMonitors",
@@ -32,3 +32,16 @@ func newUrlResult(urlString string) *urlResult {
 	uResult.IsValid = true
 	return &uResult
 }
+
+// Status returns "up" if the URL responded successfully, "down" if it didn't, and
+// "invalid" if it isn't a valid URL
+func (uResult *urlResult) Status() string {
+	switch {
+	case !uResult.IsValid:
+		return "invalid"
+	case uResult.ResultCode >= 200 && uResult.ResultCode < 400:
+		return "up"
+	default:
+		return "down"
+	}
+}
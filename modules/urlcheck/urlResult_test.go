@@ -44,3 +44,22 @@ func Test_newUrlResult(t *testing.T) {
 		})
 	}
 }
+
+func Test_urlResult_Status(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   urlResult
+		expected string
+	}{
+		{"ok", urlResult{IsValid: true, ResultCode: 200}, "up"},
+		{"redirect", urlResult{IsValid: true, ResultCode: 301}, "up"},
+		{"not_found", urlResult{IsValid: true, ResultCode: 404}, "down"},
+		{"timeout", urlResult{IsValid: true, ResultCode: InvalidResultCode}, "down"},
+		{"invalid", urlResult{IsValid: false, ResultCode: InvalidResultCode}, "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.result.Status())
+		})
+	}
+}
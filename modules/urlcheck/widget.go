@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/view"
 )
 
@@ -13,6 +14,7 @@ type Widget struct {
 	view.TextWidget
 
 	settings         *Settings          // settings from the configuration file
+	tracker          *notify.Tracker    // notifies on the URLs that go up or down
 	urlList          []*urlResult       // list of a collection of useful properies of the url
 	client           *http.Client       // the http client shared with all the requestes across all the refreshes
 	timeout          time.Duration      // the timeout for a single request
@@ -28,6 +30,7 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Sett
 		TextWidget: view.NewTextWidget(tviewApp, redrawChan, nil, settings.Common),
 
 		settings: settings,
+		tracker:  notify.NewTracker(settings.Common.Module),
 		urlList:  make([]*urlResult, maxUrl),
 		client:   &http.Client{},
 		timeout:  time.Duration(settings.requestTimeout) + time.Second,
@@ -44,6 +47,7 @@ func NewWidget(tviewApp *tview.Application, redrawChan chan bool, settings *Sett
 // Refresh updates the onscreen contents of the widget
 func (widget *Widget) Refresh() {
 	widget.check()
+	widget.track()
 	widget.display()
 }
 
@@ -69,6 +73,23 @@ func (widget *Widget) check() {
 	}
 }
 
+// Notify on the URLs that go up or down, and on the number that are down
+func (widget *Widget) track() {
+	down := 0
+	items := make([]notify.Item, len(widget.urlList))
+
+	for i, urlRes := range widget.urlList {
+		items[i] = notify.Item{ID: urlRes.Url, Name: urlRes.Url, Status: urlRes.Status()}
+
+		if urlRes.Status() == "down" {
+			down++
+		}
+	}
+
+	widget.tracker.Track(items)
+	widget.tracker.Measure("down", float64(down))
+}
+
 // Format and displays the results at every refresh
 func (widget *Widget) display() {
 	widget.Redraw(func() (string, string, bool) {
@@ -22,7 +22,7 @@ func Fetch(apiID, apiKey string) ([]OnCallTeam, error) {
 func victorOpsRequest(url string, apiID string, apiKey string) ([]OnCallTeam, error) {
 	req, err := http.NewRequest("GET", url, http.NoBody)
 	if err != nil {
-		logger.Log(fmt.Sprintf("Failed to initialize sessions to VictorOps. ERROR: %s", err))
+		logger.ForModule("", "victorops").Error("failed to initialize sessions to VictorOps", "error", err)
 		return nil, err
 	}
 
@@ -32,7 +32,7 @@ func victorOpsRequest(url string, apiID string, apiKey string) ([]OnCallTeam, er
 
 	resp, err := client.Do(req)
 	if err != nil {
-		logger.Log(fmt.Sprintf("Failed to make request to VictorOps. ERROR: %s", err))
+		logger.ForModule("", "victorops").Error("failed to make request to VictorOps", "error", err)
 		return nil, err
 	}
 	if resp.StatusCode != 200 {
@@ -42,7 +42,7 @@ func victorOpsRequest(url string, apiID string, apiKey string) ([]OnCallTeam, er
 
 	response := &OnCallResponse{}
 	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
-		logger.Log(fmt.Sprintf("Failed to decode JSON response. ERROR: %s", err))
+		logger.ForModule("", "victorops").Error("failed to decode JSON response", "error", err)
 		return nil, err
 	}
 
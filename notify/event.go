@@ -0,0 +1,200 @@
+package notify
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wtfutil/wtf/cfg"
+)
+
+// Kind is the type of change that an event describes
+type Kind string
+
+const (
+	// ItemAdded is emitted when an item appears that wasn't there before, i.e.: a new incident
+	ItemAdded Kind = "itemAdded"
+
+	// ItemRemoved is emitted when an item disappears, i.e.: a resolved incident
+	ItemRemoved Kind = "itemRemoved"
+
+	// StatusChanged is emitted when an item's status changes, i.e.: a job starts failing
+	StatusChanged Kind = "statusChanged"
+
+	// ThresholdCrossed is emitted when a measurement crosses the threshold set by a rule,
+	// i.e.: the number of sites that are down goes above zero
+	ThresholdCrossed Kind = "thresholdCrossed"
+
+	// Measured is emitted every time a module measures a value. Rules with thresholds
+	// turn measurements into ThresholdCrossed events
+	Measured Kind = "measured"
+)
+
+// Event describes a change in the data that a module displays
+type Event struct {
+	Kind           Kind      `json:"kind"`
+	Module         string    `json:"module"`
+	ModuleType     string    `json:"moduleType"`
+	Item           string    `json:"item"`
+	ItemID         string    `json:"itemId,omitempty"`
+	Status         string    `json:"status,omitempty"`
+	PreviousStatus string    `json:"previousStatus,omitempty"`
+	Value          float64   `json:"value,omitempty"`
+	Threshold      float64   `json:"threshold,omitempty"`
+	Time           time.Time `json:"time"`
+}
+
+// Message returns a human-readable description of the event
+func (event Event) Message() string {
+	switch event.Kind {
+	case ItemAdded:
+		if event.Status != "" {
+			return fmt.Sprintf("%s added (%s)", event.Item, event.Status)
+		}
+		return event.Item + " added"
+	case ItemRemoved:
+		return event.Item + " removed"
+	case StatusChanged:
+		return fmt.Sprintf("%s changed from %s to %s", event.Item, event.PreviousStatus, event.Status)
+	case ThresholdCrossed:
+		return fmt.Sprintf("%s is %s, crossing %s", event.Item, formatValue(event.Value), formatValue(event.Threshold))
+	default:
+		return fmt.Sprintf("%s is %s", event.Item, formatValue(event.Value))
+	}
+}
+
+/* -------------------- Tracker -------------------- */
+
+// Item is a single thing that a module displays and whose changes can be notified on
+type Item struct {
+	// ID uniquely identifies the item between refreshes. The name is used if it's empty
+	ID     string
+	Name   string
+	Status string
+}
+
+// Tracker turns the items a module displays into events, by comparing the items from
+// each refresh with those from the previous one
+type Tracker struct {
+	module cfg.Module
+
+	items       map[string]Item
+	initialized bool
+	mutex       sync.Mutex
+}
+
+// NewTracker creates and returns a tracker for the module's events
+func NewTracker(module cfg.Module) *Tracker {
+	return &Tracker{
+		module: module,
+		items:  map[string]Item{},
+	}
+}
+
+// Track compares the items with those passed to the previous call, and emits an event
+// for every item that was added, removed or changed status. The first call only records
+// the items, so that everything onscreen when wtfutil starts isn't notified as new
+func (tracker *Tracker) Track(items []Item) {
+	Emit(tracker.diff(items, time.Now())...)
+}
+
+// Measure emits a measurement of the module's data, i.e.: the number of failing jobs,
+// that rules with thresholds are checked against
+func (tracker *Tracker) Measure(name string, value float64) {
+	Emit(tracker.event(Measured, Item{Name: name}, time.Now(), func(event *Event) {
+		event.Value = value
+	}))
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (tracker *Tracker) diff(items []Item, now time.Time) []Event {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	current := map[string]Item{}
+	events := []Event{}
+
+	for _, item := range items {
+		id := itemID(item)
+		current[id] = item
+
+		if !tracker.initialized {
+			continue
+		}
+
+		previous, ok := tracker.items[id]
+		switch {
+		case !ok:
+			events = append(events, tracker.event(ItemAdded, item, now, nil))
+		case previous.Status != item.Status:
+			events = append(events, tracker.event(StatusChanged, item, now, func(event *Event) {
+				event.PreviousStatus = previous.Status
+			}))
+		}
+	}
+
+	if tracker.initialized {
+		for _, item := range items {
+			delete(tracker.items, itemID(item))
+		}
+
+		for _, item := range sortedItems(tracker.items) {
+			events = append(events, tracker.event(ItemRemoved, item, now, nil))
+		}
+	}
+
+	tracker.items = current
+	tracker.initialized = true
+
+	return events
+}
+
+func (tracker *Tracker) event(kind Kind, item Item, now time.Time, fn func(*Event)) Event {
+	event := Event{
+		Kind:       kind,
+		Module:     tracker.module.Name,
+		ModuleType: tracker.module.Type,
+		Item:       item.Name,
+		ItemID:     item.ID,
+		Status:     item.Status,
+		Time:       now,
+	}
+
+	if fn != nil {
+		fn(&event)
+	}
+
+	return event
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func itemID(item Item) string {
+	if item.ID != "" {
+		return item.ID
+	}
+
+	return item.Name
+}
+
+// sortedItems returns the items sorted by ID, so that events are emitted in a
+// predictable order
+func sortedItems(items map[string]Item) []Item {
+	ids := make([]string, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	sorted := make([]Item, len(ids))
+	for idx, id := range ids {
+		sorted[idx] = items[id]
+	}
+
+	return sorted
+}
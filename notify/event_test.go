@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+func Test_Tracker_diff(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(cfg.Module{Name: "ci", Type: "jenkins"})
+
+	// The first items are only recorded
+	events := tracker.diff([]Item{
+		{ID: "1", Name: "api", Status: "success"},
+		{ID: "2", Name: "web", Status: "success"},
+		{Name: "docs", Status: "success"},
+	}, now)
+	assert.Equal(t, []Event{}, events)
+
+	events = tracker.diff([]Item{
+		{ID: "1", Name: "api", Status: "failed"},
+		{ID: "3", Name: "worker", Status: "success"},
+		{Name: "docs", Status: "success"},
+	}, now)
+
+	expected := []Event{
+		{Kind: StatusChanged, Module: "ci", ModuleType: "jenkins", Item: "api", ItemID: "1", Status: "failed", PreviousStatus: "success", Time: now},
+		{Kind: ItemAdded, Module: "ci", ModuleType: "jenkins", Item: "worker", ItemID: "3", Status: "success", Time: now},
+		{Kind: ItemRemoved, Module: "ci", ModuleType: "jenkins", Item: "web", ItemID: "2", Status: "success", Time: now},
+	}
+
+	assert.Equal(t, expected, events)
+}
+
+func Test_Event_Message(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    Event
+		expected string
+	}{
+		{
+			name:     "with an added item",
+			event:    Event{Kind: ItemAdded, Item: "DB is down", Status: "triggered"},
+			expected: "DB is down added (triggered)",
+		},
+		{
+			name:     "with a removed item",
+			event:    Event{Kind: ItemRemoved, Item: "DB is down"},
+			expected: "DB is down removed",
+		},
+		{
+			name:     "with a changed status",
+			event:    Event{Kind: StatusChanged, Item: "api", Status: "failed", PreviousStatus: "success"},
+			expected: "api changed from success to failed",
+		},
+		{
+			name:     "with a crossed threshold",
+			event:    Event{Kind: ThresholdCrossed, Item: "down", Value: 2, Threshold: 0.5},
+			expected: "down is 2, crossing 0.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.event.Message())
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+)
+
+const (
+	defaultNotifyCommand = "notify-send"
+
+	webhookTimeout = 10 * time.Second
+)
+
+var (
+	notifier      = newNotifier(nil, nil)
+	notifierMutex = &sync.Mutex{}
+
+	// runCommand runs the command used by the notify action
+	runCommand = func(name string, args ...string) error {
+		return exec.Command(name, args...).Run()
+	}
+
+	// webhookClient sends the webhook action's requests
+	webhookClient = &http.Client{Timeout: webhookTimeout}
+)
+
+// Notifier checks events against the notification rules and performs the actions of the
+// rules they match
+type Notifier struct {
+	beeper        func()
+	flasher       func(moduleName string)
+	notifyCommand []string
+	rules         []*Rule
+
+	// fired is when each rule last fired for each item, for its cooldown
+	fired map[string]time.Time
+
+	// inside is whether each measurement was inside each threshold rule's threshold
+	// the last time it was measured
+	inside map[string]bool
+
+	mutex sync.Mutex
+}
+
+// Init replaces the notification rules with those in wtf.notifications. Rules with
+// errors are logged and ignored
+func Init(config *config.Config) {
+	rules, errs := ParseRules(config)
+	for idx, err := range errs {
+		logger.Warn("invalid notification rule", "rule", fmt.Sprintf("%s.%d", rulesPath, idx), "error", err)
+	}
+
+	notifyCommand := utils.ToStrs(config.UList("wtf.notifications.notifyCommand"))
+	if len(notifyCommand) == 0 {
+		notifyCommand = []string{defaultNotifyCommand}
+	}
+
+	notifierMutex.Lock()
+	defer notifierMutex.Unlock()
+
+	beeper, flasher := notifier.beeper, notifier.flasher
+	notifier = newNotifier(rules, notifyCommand)
+	notifier.beeper, notifier.flasher = beeper, flasher
+}
+
+// Emit checks the events against the notification rules
+func Emit(events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	notifierMutex.Lock()
+	current := notifier
+	notifierMutex.Unlock()
+
+	for _, event := range events {
+		current.notify(event)
+	}
+}
+
+// SetBeeper sets the function that rings the terminal bell for the bell action. Until
+// it's set, the bell action does nothing
+func SetBeeper(beeper func()) {
+	notifierMutex.Lock()
+	defer notifierMutex.Unlock()
+
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	notifier.beeper = beeper
+}
+
+// SetFlasher sets the function that flashes a module's border for the flash action
+func SetFlasher(flasher func(moduleName string)) {
+	notifierMutex.Lock()
+	defer notifierMutex.Unlock()
+
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	notifier.flasher = flasher
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func newNotifier(rules []*Rule, notifyCommand []string) *Notifier {
+	return &Notifier{
+		notifyCommand: notifyCommand,
+		rules:         rules,
+
+		fired:  map[string]time.Time{},
+		inside: map[string]bool{},
+	}
+}
+
+// notify performs the actions of every rule that the event matches
+func (notifier *Notifier) notify(event Event) {
+	for idx, rule := range notifier.rules {
+		if !rule.matches(event) {
+			continue
+		}
+
+		key := fmt.Sprintf("%d\x00%s\x00%s", idx, event.Module, itemID(Item{ID: event.ItemID, Name: event.Item}))
+
+		matched := event
+		if rule.hasThreshold() {
+			crossed, ok := notifier.crossing(key, rule, event)
+			if !ok {
+				continue
+			}
+			matched = crossed
+		}
+
+		if !notifier.cooledDown(key, rule, matched.Time) {
+			continue
+		}
+
+		notifier.fire(rule, matched)
+	}
+}
+
+// cooledDown returns TRUE if the rule's cooldown has passed since it last fired for
+// the item, and records that it is firing now
+func (notifier *Notifier) cooledDown(key string, rule *Rule, now time.Time) bool {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	if last, ok := notifier.fired[key]; ok && now.Sub(last) < rule.Cooldown {
+		return false
+	}
+
+	notifier.fired[key] = now
+	return true
+}
+
+// crossing returns a ThresholdCrossed event if the measurement moved inside the rule's
+// threshold since it was last measured. The first measurement is only recorded, the
+// same way that Tracker doesn't notify on the first items it sees
+func (notifier *Notifier) crossing(key string, rule *Rule, event Event) (Event, bool) {
+	inside, threshold := rule.crossed(event.Value)
+
+	notifier.mutex.Lock()
+	wasInside, measured := notifier.inside[key]
+	notifier.inside[key] = inside
+	notifier.mutex.Unlock()
+
+	if !measured || wasInside || !inside {
+		return Event{}, false
+	}
+
+	event.Kind = ThresholdCrossed
+	event.Threshold = threshold
+
+	return event, true
+}
+
+func (notifier *Notifier) fire(rule *Rule, event Event) {
+	for _, action := range rule.Actions {
+		switch action {
+		case ActionBell:
+			notifier.mutex.Lock()
+			beeper := notifier.beeper
+			notifier.mutex.Unlock()
+
+			if beeper != nil {
+				beeper()
+			}
+		case ActionFlash:
+			notifier.mutex.Lock()
+			flasher := notifier.flasher
+			notifier.mutex.Unlock()
+
+			if flasher != nil {
+				flasher(event.Module)
+			}
+		case ActionNotify:
+			go notifier.desktopNotify(rule, event)
+		case ActionWebhook:
+			go postWebhook(rule, event)
+		}
+	}
+}
+
+// desktopNotify shows the event as a desktop notification, titled with the rule's name
+func (notifier *Notifier) desktopNotify(rule *Rule, event Event) {
+	args := append([]string{}, notifier.notifyCommand[1:]...)
+	args = append(args, rule.Name, fmt.Sprintf("%s: %s", event.Module, event.Message()))
+
+	if err := runCommand(notifier.notifyCommand[0], args...); err != nil {
+		logger.Warn("desktop notification failed", "rule", rule.Name, "error", err)
+	}
+}
+
+// postWebhook sends the event, along with the rule's name and the event's message, as
+// JSON to the rule's webhook URL
+func postWebhook(rule *Rule, event Event) {
+	payload := struct {
+		Event
+		Message string `json:"message"`
+		Rule    string `json:"rule"`
+	}{event, event.Message(), rule.Name}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("failed to encode webhook payload", "rule", rule.Name, "error", err)
+		return
+	}
+
+	resp, err := webhookClient.Post(rule.Webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		logger.Warn("webhook failed", "rule", rule.Name, "error", err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		logger.Warn("webhook failed", "rule", rule.Name, "status", resp.Status)
+	}
+}
@@ -0,0 +1,198 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNotifier(t *testing.T, yaml string) *Notifier {
+	ymlConfig, err := config.ParseYaml(yaml)
+	assert.NoError(t, err)
+
+	rules, errs := ParseRules(ymlConfig)
+	assert.Empty(t, errs)
+
+	return newNotifier(rules, []string{"notify-send", "--urgency=critical"})
+}
+
+func Test_ParseRules(t *testing.T) {
+	ymlConfig, _ := config.ParseYaml(`
+wtf:
+  notifications:
+    rules:
+      - name: Failed jobs
+        modules: [jenkins]
+        events: [statusChanged]
+        status: [failed]
+        match: ^deploy-
+        cooldown: 5m
+        actions: [bell]
+      - actions: [flash]
+        above: 0
+      - actions: [siren]
+      - actions: [webhook]
+      - actions: [bell]
+        events: [thresholdCrossed]
+      - actions: [bell]
+        events: [itemAdded]
+        below: 3
+      - actions: [bell]
+        match: "("
+      - events: [itemAdded]
+`)
+
+	rules, errs := ParseRules(ymlConfig)
+
+	assert.Equal(t, 2, len(rules))
+
+	assert.Equal(t, "Failed jobs", rules[0].Name)
+	assert.Equal(t, []string{"jenkins"}, rules[0].Modules)
+	assert.Equal(t, []Kind{StatusChanged}, rules[0].Events)
+	assert.Equal(t, []string{"failed"}, rules[0].Statuses)
+	assert.Equal(t, "^deploy-", rules[0].Match.String())
+	assert.Equal(t, 5*time.Minute, rules[0].Cooldown)
+
+	assert.Equal(t, "rule 2", rules[1].Name)
+	assert.Equal(t, 0.0, *rules[1].Above)
+
+	messages := map[int]string{}
+	for idx, err := range errs {
+		messages[idx] = err.Error()
+	}
+
+	assert.Equal(
+		t,
+		map[int]string{
+			2: `unknown action "siren"`,
+			3: "the webhook action needs a webhook URL",
+			4: "thresholdCrossed events need a threshold, set above or below",
+			5: "itemAdded events can't have a threshold",
+			6: "invalid match: error parsing regexp: missing closing ): `(`",
+			7: "no actions",
+		},
+		messages,
+	)
+}
+
+func Test_Notifier_notify(t *testing.T) {
+	notifier := testNotifier(t, `
+wtf:
+  notifications:
+    rules:
+      - name: Failed jobs
+        modules: [jenkins]
+        status: [FAILED]
+        actions: [flash]
+      - name: New incidents
+        modules: [incidents]
+        events: [itemAdded]
+        match: ^DB
+        actions: [flash]
+`)
+
+	flashed := []string{}
+	notifier.flasher = func(moduleName string) { flashed = append(flashed, moduleName) }
+
+	events := []Event{
+		{Kind: StatusChanged, Module: "ci", ModuleType: "jenkins", Item: "api", Status: "failed"},
+		{Kind: StatusChanged, Module: "ci", ModuleType: "jenkins", Item: "web", Status: "success"},
+		{Kind: ItemAdded, Module: "incidents", ModuleType: "pagerduty", Item: "DB is down"},
+		{Kind: ItemAdded, Module: "incidents", ModuleType: "pagerduty", Item: "Disk is full"},
+		{Kind: ItemRemoved, Module: "incidents", ModuleType: "pagerduty", Item: "DB is slow"},
+		{Kind: Measured, Module: "ci", ModuleType: "jenkins", Item: "failed", Value: 1},
+	}
+
+	for _, event := range events {
+		notifier.notify(event)
+	}
+
+	assert.Equal(t, []string{"ci", "incidents"}, flashed)
+}
+
+func Test_Notifier_threshold(t *testing.T) {
+	notifier := testNotifier(t, `
+wtf:
+  notifications:
+    rules:
+      - match: ^down$
+        above: 0
+        actions: [bell]
+`)
+
+	rung := 0
+	notifier.beeper = func() { rung++ }
+
+	for _, value := range []float64{1, 0, 0, 2, 3, 0, 1} {
+		notifier.notify(Event{Kind: Measured, Module: "sites", Item: "down", Value: value})
+	}
+
+	// The first measurement is only recorded, and the bell rings each time the value goes
+	// from 0 to above it
+	assert.Equal(t, 2, rung)
+}
+
+func Test_Notifier_cooldown(t *testing.T) {
+	notifier := testNotifier(t, `
+wtf:
+  notifications:
+    rules:
+      - cooldown: 10m
+        actions: [bell]
+`)
+
+	rung := 0
+	notifier.beeper = func() { rung++ }
+
+	now := time.Now()
+	for _, offset := range []time.Duration{0, time.Minute, 11 * time.Minute} {
+		notifier.notify(Event{Kind: ItemAdded, Module: "incidents", Item: "DB is down", Time: now.Add(offset)})
+	}
+	notifier.notify(Event{Kind: ItemAdded, Module: "incidents", Item: "Disk is full", Time: now})
+
+	assert.Equal(t, 3, rung)
+}
+
+func Test_Notifier_notifyAndWebhook(t *testing.T) {
+	received := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		received <- payload
+	}))
+	defer server.Close()
+
+	notifier := testNotifier(t, `
+wtf:
+  notifications:
+    rules:
+      - name: New incident
+        actions: [notify, webhook]
+        webhook: `+server.URL+`
+`)
+
+	commands := make(chan []string, 1)
+	runCommand = func(name string, args ...string) error {
+		commands <- append([]string{name}, args...)
+		return nil
+	}
+
+	notifier.notify(Event{Kind: ItemAdded, Module: "incidents", ModuleType: "pagerduty", Item: "DB is down"})
+
+	assert.Equal(
+		t,
+		[]string{"notify-send", "--urgency=critical", "New incident", "incidents: DB is down added"},
+		<-commands,
+	)
+
+	payload := <-received
+	assert.Equal(t, "New incident", payload["rule"])
+	assert.Equal(t, "itemAdded", payload["kind"])
+	assert.Equal(t, "pagerduty", payload["moduleType"])
+	assert.Equal(t, "DB is down added", payload["message"])
+}
@@ -0,0 +1,231 @@
+package notify
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/utils"
+)
+
+const (
+	rulesPath = "wtf.notifications.rules"
+
+	// ActionBell rings the terminal bell
+	ActionBell = "bell"
+
+	// ActionFlash flashes the border of the module that the event came from
+	ActionFlash = "flash"
+
+	// ActionNotify shows a desktop notification with notify-send, or the command set in
+	// wtf.notifications.notifyCommand
+	ActionNotify = "notify"
+
+	// ActionWebhook POSTs the event as JSON to the rule's webhook URL
+	ActionWebhook = "webhook"
+)
+
+// Rule decides which events to notify on, and how. Events match a rule when they come
+// from one of its modules, are one of its kinds, have one of its statuses and have an
+// item name matching its pattern. Any of these that are left out match every event.
+// Rules with a threshold (above, below or both) match measurements instead, when the
+// value moves inside the threshold:
+//
+//	wtf:
+//	  notifications:
+//	    rules:
+//	      - name: Jenkins job failed
+//	        modules: [jenkins]         # module names or types
+//	        events: [statusChanged]
+//	        status: [failed]
+//	        match: ^deploy-            # a regular expression on the item name
+//	        actions: [bell, flash, notify, webhook]
+//	        webhook: https://hooks.example.com/wtf
+//	        cooldown: 10m              # the least time between notifications per item
+//	      - name: Sites are down
+//	        modules: [urlcheck, uptimerobot]
+//	        match: ^down$
+//	        above: 0
+//	        actions: [flash]
+type Rule struct {
+	Actions  []string
+	Above    *float64
+	Below    *float64
+	Cooldown time.Duration
+	Events   []Kind
+	Match    *regexp.Regexp
+	Modules  []string
+	Name     string
+	Statuses []string
+	Webhook  string
+}
+
+// ParseRules reads the rules in wtf.notifications.rules. Rules with errors are left out,
+// and the errors returned, keyed by the rule's index
+func ParseRules(config *config.Config) ([]*Rule, map[int]error) {
+	rules := []*Rule{}
+	errs := map[int]error{}
+
+	for idx := range config.UList(rulesPath) {
+		ruleConfig, err := config.Get(fmt.Sprintf("%s.%d", rulesPath, idx))
+		if err != nil {
+			errs[idx] = err
+			continue
+		}
+
+		rule, err := newRule(ruleConfig)
+		if err != nil {
+			errs[idx] = err
+			continue
+		}
+
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", idx+1)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, errs
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func newRule(ruleConfig *config.Config) (*Rule, error) {
+	rule := &Rule{
+		Actions:  utils.ToStrs(ruleConfig.UList("actions")),
+		Cooldown: cfg.ParseTimeString(ruleConfig, "cooldown", "0s"),
+		Modules:  utils.ToStrs(ruleConfig.UList("modules")),
+		Name:     ruleConfig.UString("name"),
+		Statuses: utils.ToStrs(ruleConfig.UList("status")),
+		Webhook:  ruleConfig.UString("webhook"),
+	}
+
+	for _, event := range utils.ToStrs(ruleConfig.UList("events")) {
+		rule.Events = append(rule.Events, Kind(event))
+	}
+
+	if above, err := ruleConfig.Float64("above"); err == nil {
+		rule.Above = &above
+	}
+
+	if below, err := ruleConfig.Float64("below"); err == nil {
+		rule.Below = &below
+	}
+
+	if pattern := ruleConfig.UString("match"); pattern != "" {
+		match, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid match: %w", err)
+		}
+		rule.Match = match
+	}
+
+	return rule, rule.validate()
+}
+
+// crossed returns whether the value is inside the rule's threshold, and the threshold
+// it's compared against
+func (rule *Rule) crossed(value float64) (bool, float64) {
+	inside := true
+	threshold := 0.0
+
+	if rule.Below != nil {
+		inside = value < *rule.Below
+		threshold = *rule.Below
+	}
+
+	if rule.Above != nil {
+		inside = inside && value > *rule.Above
+		threshold = *rule.Above
+	}
+
+	return inside, threshold
+}
+
+func (rule *Rule) hasThreshold() bool {
+	return rule.Above != nil || rule.Below != nil
+}
+
+// matches returns TRUE if the event is one that the rule notifies on, ignoring thresholds
+func (rule *Rule) matches(event Event) bool {
+	if len(rule.Modules) > 0 && !containsFold(rule.Modules, event.Module) && !containsFold(rule.Modules, event.ModuleType) {
+		return false
+	}
+
+	if len(rule.Statuses) > 0 && !containsFold(rule.Statuses, event.Status) {
+		return false
+	}
+
+	if rule.Match != nil && !rule.Match.MatchString(event.Item) {
+		return false
+	}
+
+	if rule.hasThreshold() {
+		return event.Kind == Measured
+	}
+
+	if event.Kind == Measured {
+		return false
+	}
+
+	if len(rule.Events) == 0 {
+		return true
+	}
+
+	for _, kind := range rule.Events {
+		if kind == event.Kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rule *Rule) validate() error {
+	if len(rule.Actions) == 0 {
+		return fmt.Errorf("no actions")
+	}
+
+	for _, action := range rule.Actions {
+		switch action {
+		case ActionBell, ActionFlash, ActionNotify:
+		case ActionWebhook:
+			if rule.Webhook == "" {
+				return fmt.Errorf("the %s action needs a webhook URL", ActionWebhook)
+			}
+		default:
+			return fmt.Errorf("unknown action %q", action)
+		}
+	}
+
+	for _, kind := range rule.Events {
+		switch kind {
+		case ItemAdded, ItemRemoved, StatusChanged:
+			if rule.hasThreshold() {
+				return fmt.Errorf("%s events can't have a threshold", kind)
+			}
+		case ThresholdCrossed:
+			if !rule.hasThreshold() {
+				return fmt.Errorf("%s events need a threshold, set above or below", kind)
+			}
+		default:
+			return fmt.Errorf("unknown event %q", kind)
+		}
+	}
+
+	return nil
+}
+
+func containsFold(list []string, str string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, str) {
+			return true
+		}
+	}
+
+	return false
+}
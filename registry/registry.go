@@ -0,0 +1,112 @@
+// Package registry holds the module types that wtfutil can display. Each module registers
+// its types from an init function, so a module is only compiled in, along with its
+// dependencies, if something imports its package. See modules/all for the build tags
+// that choose which modules are imported
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+var (
+	knownTypes   = map[string]bool{}
+	modules      = map[string]*Module{}
+	modulesMutex = &sync.RWMutex{}
+)
+
+// SettingsFunc creates a module's settings from its configuration and the global
+// configuration, i.e.: a module's NewSettingsFromYAML
+type SettingsFunc[S any] func(name string, moduleConfig *config.Config, globalConfig *config.Config) S
+
+// WidgetFunc creates a module's widget from its settings. Widgets that don't show modals
+// can ignore pages
+type WidgetFunc[S any] func(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, settings S) wtf.Wtfable
+
+// Module is a registered module type
+type Module struct {
+	Description string
+	Type        string
+
+	newWidget func(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, name string, moduleConfig *config.Config, globalConfig *config.Config) wtf.Wtfable
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// AddKnownTypes records module types that wtfutil has, whether or not they're compiled
+// into this build, so that a type left out by build tags can be told apart from one that
+// doesn't exist
+func AddKnownTypes(moduleTypes ...string) {
+	modulesMutex.Lock()
+	defer modulesMutex.Unlock()
+
+	for _, moduleType := range moduleTypes {
+		knownTypes[moduleType] = true
+	}
+}
+
+// Known returns TRUE if the module type exists, even if it isn't compiled into this build
+func Known(moduleType string) bool {
+	modulesMutex.RLock()
+	defer modulesMutex.RUnlock()
+
+	_, registered := modules[moduleType]
+	return registered || knownTypes[moduleType]
+}
+
+// Register adds a module type, described by a one-line description, that is created by
+// the settings and widget constructors. It panics if the type is already registered,
+// since two modules claiming the same type is a programming error
+func Register[S any](moduleType, description string, newSettings SettingsFunc[S], newWidget WidgetFunc[S]) {
+	modulesMutex.Lock()
+	defer modulesMutex.Unlock()
+
+	if _, ok := modules[moduleType]; ok {
+		panic(fmt.Sprintf("module type %q is already registered", moduleType))
+	}
+
+	modules[moduleType] = &Module{
+		Description: description,
+		Type:        moduleType,
+
+		newWidget: func(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, name string, moduleConfig *config.Config, globalConfig *config.Config) wtf.Wtfable {
+			return newWidget(tviewApp, redrawChan, pages, newSettings(name, moduleConfig, globalConfig))
+		},
+	}
+}
+
+// Lookup returns the module registered for the type, if there is one
+func Lookup(moduleType string) (*Module, bool) {
+	modulesMutex.RLock()
+	defer modulesMutex.RUnlock()
+
+	module, ok := modules[moduleType]
+	return module, ok
+}
+
+// Modules returns every registered module, sorted by type
+func Modules() []*Module {
+	modulesMutex.RLock()
+	defer modulesMutex.RUnlock()
+
+	registered := make([]*Module, 0, len(modules))
+	for _, module := range modules {
+		registered = append(registered, module)
+	}
+
+	sort.Slice(registered, func(i, j int) bool {
+		return registered[i].Type < registered[j].Type
+	})
+
+	return registered
+}
+
+// NewWidget creates the module's widget, named name, from its configuration
+func (module *Module) NewWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, name string, moduleConfig *config.Config, globalConfig *config.Config) wtf.Wtfable {
+	return module.newWidget(tviewApp, redrawChan, pages, name, moduleConfig, globalConfig)
+}
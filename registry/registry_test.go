@@ -0,0 +1,72 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+type testSettings struct {
+	name  string
+	title string
+}
+
+type testWidget struct {
+	wtf.Wtfable
+
+	pages    *tview.Pages
+	settings *testSettings
+}
+
+func newTestSettings(name string, moduleConfig *config.Config, _ *config.Config) *testSettings {
+	return &testSettings{name: name, title: moduleConfig.UString("title")}
+}
+
+func newTestWidget(_ *tview.Application, _ chan bool, pages *tview.Pages, settings *testSettings) wtf.Wtfable {
+	return &testWidget{pages: pages, settings: settings}
+}
+
+func Test_Register(t *testing.T) {
+	Register("registrytest_b", "Second test module", newTestSettings, newTestWidget)
+	Register("registrytest_a", "First test module", newTestSettings, newTestWidget)
+
+	module, ok := Lookup("registrytest_b")
+	assert.True(t, ok)
+	assert.Equal(t, "registrytest_b", module.Type)
+	assert.Equal(t, "Second test module", module.Description)
+
+	moduleConfig, _ := config.ParseYaml("title: Builds")
+	pages := tview.NewPages()
+
+	widget := module.NewWidget(nil, nil, pages, "ci", moduleConfig, &config.Config{})
+
+	assert.Equal(t, &testWidget{pages: pages, settings: &testSettings{name: "ci", title: "Builds"}}, widget)
+
+	_, ok = Lookup("registrytest_missing")
+	assert.False(t, ok)
+
+	types := []string{}
+	for _, module := range Modules() {
+		types = append(types, module.Type)
+	}
+	assert.Equal(t, []string{"registrytest_a", "registrytest_b"}, types)
+
+	assert.Panics(t, func() {
+		Register("registrytest_a", "Duplicate", newTestSettings, newTestWidget)
+	})
+}
+
+func Test_Known(t *testing.T) {
+	Register("registrytest_known", "Known test module", newTestSettings, newTestWidget)
+	AddKnownTypes("registrytest_excluded")
+
+	assert.True(t, Known("registrytest_known"))
+	assert.True(t, Known("registrytest_excluded"))
+	assert.False(t, Known("registrytest_bogus"))
+
+	_, ok := Lookup("registrytest_excluded")
+	assert.False(t, ok)
+}
@@ -0,0 +1,230 @@
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/app"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/view"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const (
+	// FormatJSON renders widgets as a JSON array of objects
+	FormatJSON = "json"
+
+	// FormatText renders widgets as plain text, without any color tags
+	FormatText = "text"
+
+	// renderTimeout is the longest time a widget is given to render its content
+	renderTimeout = 30 * time.Second
+
+	// settleTime is how long a widget has to go without rendering before its content
+	// is considered complete
+	settleTime = 500 * time.Millisecond
+)
+
+// renderable is implemented by widgets that record the content they last rendered
+type renderable interface {
+	Rendered() view.RenderedContent
+}
+
+// Output is the rendered content of a single widget
+type Output struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Wrap    bool   `json:"wrap"`
+}
+
+// Display refreshes the requested modules once, without starting the UI, and writes
+// their content to stdout in the requested format. It returns the exit code the `render`
+// command should exit with
+func Display(config *config.Config, configFilePath string, moduleName string, all bool, format string) int {
+	if moduleName == "" && !all {
+		fmt.Fprintf(os.Stderr, "render: either --module=<name> or --all is required, see `%s --help`\n", os.Args[0])
+		return 1
+	}
+
+	outputs, err := Render(config, configFilePath, moduleName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		return 1
+	}
+
+	if err := Write(os.Stdout, outputs, format); err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// Render refreshes the named module, or every enabled module in every dashboard if no
+// name is given, and returns their rendered content sorted by module name
+func Render(config *config.Config, configFilePath string, moduleName string) ([]Output, error) {
+	// Some widgets expect a tview app, even though it never runs
+	tviewApp := tview.NewApplication()
+	widgets := []*headlessWidget{}
+
+	for _, dashboard := range cfg.LoadDashboards(config, configFilePath) {
+		moduleNames, _ := dashboard.Config.Map("wtf.mods")
+
+		if moduleName != "" {
+			if _, ok := moduleNames[moduleName]; !ok {
+				continue
+			}
+
+			// Render the module even if it is disabled in the dashboard
+			_ = dashboard.Config.Set("wtf.mods."+moduleName+".enabled", true)
+
+			widgets = append(widgets, newHeadlessWidget(tviewApp, moduleName, dashboard.Config))
+			break
+		}
+
+		for name := range moduleNames {
+			if widget := newHeadlessWidget(tviewApp, name, dashboard.Config); widget != nil {
+				widgets = append(widgets, widget)
+			}
+		}
+	}
+
+	if moduleName != "" && len(widgets) == 0 {
+		return nil, fmt.Errorf("module %q is not defined in the configuration", moduleName)
+	}
+
+	outputs := make([]Output, len(widgets))
+
+	var wg sync.WaitGroup
+	for idx, widget := range widgets {
+		wg.Add(1)
+
+		go func(idx int, widget *headlessWidget) {
+			defer wg.Done()
+			outputs[idx] = widget.refresh()
+		}(idx, widget)
+	}
+	wg.Wait()
+
+	sort.SliceStable(outputs, func(i, j int) bool {
+		return outputs[i].Name < outputs[j].Name
+	})
+
+	return outputs, nil
+}
+
+// Write writes the rendered content in the given format
+func Write(writer io.Writer, outputs []Output, format string) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(outputs)
+	case FormatText, "":
+		texts := []string{}
+		for _, output := range outputs {
+			texts = append(texts, fmt.Sprintf("== %s ==\n%s\n", output.Title, output.Content))
+		}
+
+		_, err := fmt.Fprint(writer, strings.Join(texts, "\n"))
+		return err
+	default:
+		return errors.New("unsupported format " + format + ", use text or json")
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// headlessWidget is a widget that is refreshed without being displayed. It has its own
+// redraw channel so that renders that happen after Refresh returns, i.e.: when the data
+// is fetched in a goroutine, can be waited for
+type headlessWidget struct {
+	widget     wtf.Wtfable
+	redrawChan chan bool
+}
+
+func newHeadlessWidget(tviewApp *tview.Application, moduleName string, config *config.Config) *headlessWidget {
+	redrawChan := make(chan bool)
+
+	widget := app.MakeWidget(tviewApp, nil, moduleName, config, redrawChan)
+	if widget == nil {
+		return nil
+	}
+
+	return &headlessWidget{
+		widget:     widget,
+		redrawChan: redrawChan,
+	}
+}
+
+// refresh runs the widget's Refresh once, waits for its rendering to settle, and
+// returns whatever it rendered
+func (headless *headlessWidget) refresh() Output {
+	refreshed := make(chan bool)
+
+	go func() {
+		headless.widget.Refresh()
+		close(refreshed)
+	}()
+
+	headless.waitForRender(refreshed)
+
+	// Keep draining redraws from any goroutines the widget left running
+	go func() {
+		for range headless.redrawChan {
+		}
+	}()
+
+	output := Output{
+		Name:  headless.widget.Name(),
+		Type:  headless.widget.CommonSettings().Module.Type,
+		Title: headless.widget.CommonSettings().Title,
+	}
+
+	if rend, ok := headless.widget.(renderable); ok {
+		rendered := rend.Rendered()
+
+		if title := rendered.PlainTitle(); title != "" {
+			output.Title = title
+		}
+		output.Content = rendered.PlainContent()
+		output.Wrap = rendered.Wrap
+	}
+
+	return output
+}
+
+// waitForRender returns once Refresh has returned and the widget has not asked to be
+// redrawn for settleTime, or once renderTimeout has passed
+func (headless *headlessWidget) waitForRender(refreshed chan bool) {
+	timeout := time.After(renderTimeout)
+	settled := time.After(settleTime)
+
+	for {
+		select {
+		case <-headless.redrawChan:
+			settled = time.After(settleTime)
+		case <-refreshed:
+			refreshed = nil
+		case <-settled:
+			if refreshed == nil {
+				return
+			}
+			settled = time.After(settleTime)
+		case <-timeout:
+			return
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testOutputs = []Output{
+	{Name: "clocks", Type: "clocks", Title: "Clocks", Content: "Toronto 09:00"},
+	{Name: "uptime", Type: "cmdrunner", Title: "uptime", Content: "up 5 days", Wrap: true},
+}
+
+func Test_Write(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "as text",
+			format:   FormatText,
+			expected: "== Clocks ==\nToronto 09:00\n\n== uptime ==\nup 5 days\n",
+		},
+		{
+			name:   "as json",
+			format: FormatJSON,
+			expected: `[
+  {
+    "name": "clocks",
+    "type": "clocks",
+    "title": "Clocks",
+    "content": "Toronto 09:00",
+    "wrap": false
+  },
+  {
+    "name": "uptime",
+    "type": "cmdrunner",
+    "title": "uptime",
+    "content": "up 5 days",
+    "wrap": true
+  }
+]
+`,
+		},
+		{
+			name:        "as unsupported format",
+			format:      "xml",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := Write(buf, testOutputs, tt.format)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
@@ -39,6 +39,26 @@ func HelpFromInterface(item interface{}) string {
 	return result
 }
 
+// KeysFromInterface returns the configuration keys described by the fields of a settings
+// struct, i.e.: a field named apiKey yields the key "apiKey". Fields without help
+// tags are included because not every module documents all of its settings
+func KeysFromInterface(item interface{}) []string {
+	keys := []string{}
+	t := reflect.TypeOf(item)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			continue
+		}
+
+		keys = append(keys, lowercaseTitle(field.Name))
+	}
+
+	return keys
+}
+
 // StripColorTags removes tcell color tags from a given string
 func StripColorTags(input string) string {
 	openColorRegex := regexp.MustCompile(`\[.*?\]`)
@@ -65,6 +65,53 @@ func FindBetween(input string, left string, right string) []string {
 	return out
 }
 
+// FuzzyMatch reports whether every character of the pattern appears in the string, in
+// order and ignoring case, and scores how closely they match. Characters that follow one
+// another or start a word score higher, so "hn" scores higher against "Hacker News" than
+// against "Hackernews"
+//
+// Example:
+//
+//	score, ok := FuzzyMatch("hn", "Hacker News")
+//	> 8, true
+func FuzzyMatch(pattern string, str string) (int, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	strRunes := []rune(strings.ToLower(str))
+
+	score := 0
+	idx := 0
+	prevMatch := -2
+
+	for i, char := range strRunes {
+		if idx >= len(patternRunes) {
+			break
+		}
+
+		if char != patternRunes[idx] {
+			continue
+		}
+
+		score++
+
+		if i == prevMatch+1 {
+			score += 2
+		}
+
+		if i == 0 || strings.ContainsRune(" -_:./", strRunes[i-1]) {
+			score += 3
+		}
+
+		prevMatch = i
+		idx++
+	}
+
+	if idx < len(patternRunes) {
+		return 0, false
+	}
+
+	return score, true
+}
+
 // HighlightableHelper pads the given text with blank spaces to the width of the view
 // containing it. This is helpful for extending row highlighting across the entire width
 // of the view
@@ -120,6 +120,68 @@ func Test_Truncate(t *testing.T) {
 	assert.Equal(t, "🌮🚙", Truncate("🌮🚙💥👾", 2, false))
 }
 
+func Test_FuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern       string
+		str           string
+		expectedScore int
+		expectedOk    bool
+	}{
+		{
+			name:          "with empty pattern",
+			pattern:       "",
+			str:           "GitHub",
+			expectedScore: 0,
+			expectedOk:    true,
+		},
+		{
+			name:          "with characters out of order",
+			pattern:       "hg",
+			str:           "GitHub",
+			expectedScore: 0,
+			expectedOk:    false,
+		},
+		{
+			name:          "with a missing character",
+			pattern:       "gx",
+			str:           "GitHub",
+			expectedScore: 0,
+			expectedOk:    false,
+		},
+		{
+			name:          "with a prefix",
+			pattern:       "git",
+			str:           "GitHub",
+			expectedScore: 10,
+			expectedOk:    true,
+		},
+		{
+			name:          "with scattered characters",
+			pattern:       "gh",
+			str:           "GitHub",
+			expectedScore: 5,
+			expectedOk:    true,
+		},
+		{
+			name:          "with word starts",
+			pattern:       "jh",
+			str:           "Jenkins Health",
+			expectedScore: 8,
+			expectedOk:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, ok := FuzzyMatch(tt.pattern, tt.str)
+
+			assert.Equal(t, tt.expectedScore, score)
+			assert.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
 func Test_PrettyNumber(t *testing.T) {
 	locPrinter := message.NewPrinter(language.English)
 
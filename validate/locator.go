@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/wtfutil/wtf/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// locator finds the line and column of configuration keys in a YAML file
+type locator struct {
+	root *yaml.Node
+}
+
+func newLocator(filePath string) *locator {
+	loc := &locator{}
+
+	absPath, _ := utils.ExpandHomeDir(filePath)
+
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return loc
+	}
+
+	root := &yaml.Node{}
+	if yaml.Unmarshal(data, root) == nil {
+		loc.root = root
+	}
+
+	return loc
+}
+
+// locate returns the line and column of the key at the given dotted path. If the key
+// does not exist it returns the location of its closest existing parent, or 0, 0 if
+// the file could not be parsed
+func (loc *locator) locate(path string) (int, int) {
+	if loc.root == nil {
+		return 0, 0
+	}
+
+	node := loc.root
+	line, col := node.Line, node.Column
+
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, part := range strings.Split(path, ".") {
+		key, value := child(node, part)
+		if value == nil {
+			break
+		}
+
+		line, col = key.Line, key.Column
+		node = value
+	}
+
+	return line, col
+}
+
+// child returns the node that names the given part of a path, and the node that holds
+// its value
+func child(node *yaml.Node, part string) (*yaml.Node, *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == part {
+				return node.Content[i], node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		idx, err := strconv.Atoi(part)
+		if err == nil && idx >= 0 && idx < len(node.Content) {
+			return node.Content[idx], node.Content[idx]
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,534 @@
+// Code generated by settings_keys_gen.go; DO NOT EDIT.
+
+package validate
+
+// moduleSettingsKeys are the top-level configuration keys that modules' settings read,
+// by the import path of the package the settings are in
+var moduleSettingsKeys = map[string][]string{
+	"github.com/wtfutil/wtf/modules/airbrake": {
+		"authToken",
+		"projectID",
+	},
+	"github.com/wtfutil/wtf/modules/asana": {
+		"allUsers",
+		"apiKey",
+		"hideComplete",
+		"mode",
+		"projectId",
+		"sections",
+		"workspaceId",
+	},
+	"github.com/wtfutil/wtf/modules/azuredevops": {
+		"apiToken",
+		"labelColor",
+		"maxRows",
+		"orgURL",
+		"projectName",
+	},
+	"github.com/wtfutil/wtf/modules/bamboohr": {
+		"apiKey",
+		"apikey",
+		"subdomain",
+	},
+	"github.com/wtfutil/wtf/modules/buildkite": {
+		"apiKey",
+		"organizationSlug",
+		"pipelines",
+	},
+	"github.com/wtfutil/wtf/modules/cds/favorites": {
+		"apiURL",
+		"hideTags",
+		"token",
+	},
+	"github.com/wtfutil/wtf/modules/cds/queue": {
+		"apiURL",
+		"token",
+	},
+	"github.com/wtfutil/wtf/modules/cds/status": {
+		"apiURL",
+		"token",
+	},
+	"github.com/wtfutil/wtf/modules/circleci": {
+		"apiKey",
+		"apikey",
+		"numberOfBuilds",
+	},
+	"github.com/wtfutil/wtf/modules/clocks": {
+		"dateFormat",
+		"locations",
+		"sort",
+		"timeFormat",
+	},
+	"github.com/wtfutil/wtf/modules/cmdrunner": {
+		"args",
+		"cmd",
+		"maxLines",
+		"pty",
+		"tail",
+		"workingDir",
+	},
+	"github.com/wtfutil/wtf/modules/covid": {
+		"countries",
+	},
+	"github.com/wtfutil/wtf/modules/cryptocurrency/bittrex": {
+		"colors",
+		"summary",
+	},
+	"github.com/wtfutil/wtf/modules/cryptocurrency/blockfolio": {
+		"device_token",
+		"displayHoldings",
+	},
+	"github.com/wtfutil/wtf/modules/cryptocurrency/cryptolive": {
+		"colors",
+		"currencies",
+		"top",
+	},
+	"github.com/wtfutil/wtf/modules/cryptocurrency/cryptolive/price": {
+		"colors",
+		"currencies",
+	},
+	"github.com/wtfutil/wtf/modules/cryptocurrency/cryptolive/toplist": {
+		"colors",
+		"top",
+	},
+	"github.com/wtfutil/wtf/modules/datadog": {
+		"apiKey",
+		"apikey",
+		"applicationKey",
+		"monitors",
+	},
+	"github.com/wtfutil/wtf/modules/devto": {
+		"contentState",
+		"contentTag",
+		"contentUsername",
+		"numberOfArticles",
+	},
+	"github.com/wtfutil/wtf/modules/digitalclock": {
+		"color",
+		"dateFormat",
+		"font",
+		"hourFormat",
+		"withDate",
+		"withDatePrefix",
+	},
+	"github.com/wtfutil/wtf/modules/digitalocean": {
+		"apiKey",
+		"apikey",
+		"columns",
+		"dateFormat",
+	},
+	"github.com/wtfutil/wtf/modules/docker": {
+		"labelColor",
+	},
+	"github.com/wtfutil/wtf/modules/external": {
+		"args",
+		"cmd",
+		"env",
+		"workingDir",
+	},
+	"github.com/wtfutil/wtf/modules/feedreader": {
+		"colors",
+		"dateFormat",
+		"disableHTTP2",
+		"feedLimit",
+		"feeds",
+		"showPublishDate",
+		"showSource",
+		"userAgent",
+	},
+	"github.com/wtfutil/wtf/modules/football": {
+		"apiKey",
+		"apikey",
+		"favTeam",
+		"league",
+		"matchesFrom",
+		"matchesTo",
+		"standingCount",
+	},
+	"github.com/wtfutil/wtf/modules/gcal": {
+		"calendarReadLevel",
+		"colors",
+		"conflictIcon",
+		"currentIcon",
+		"displayResponseStatus",
+		"email",
+		"eventCount",
+		"hourFormat",
+		"multiCalendar",
+		"secretFile",
+		"showAllDay",
+		"showDeclined",
+		"showEndTime",
+		"timezone",
+		"withLocation",
+	},
+	"github.com/wtfutil/wtf/modules/gerrit": {
+		"colors",
+		"domain",
+		"password",
+		"projects",
+		"username",
+	},
+	"github.com/wtfutil/wtf/modules/git": {
+		"branchInTitle",
+		"commitCount",
+		"commitFormat",
+		"dateFormat",
+		"lastFolderTitle",
+		"repositories",
+		"sections",
+		"showFilesIfEmpty",
+		"showModuleName",
+	},
+	"github.com/wtfutil/wtf/modules/github": {
+		"apiKey",
+		"apikey",
+		"baseURL",
+		"customQueries",
+		"enableStatus",
+		"showMyPullRequests",
+		"showOpenReviewRequests",
+		"showStats",
+		"uploadURL",
+		"username",
+	},
+	"github.com/wtfutil/wtf/modules/gitlab": {
+		"apiKey",
+		"apikey",
+		"domain",
+		"username",
+	},
+	"github.com/wtfutil/wtf/modules/gitlabtodo": {
+		"apiKey",
+		"domain",
+		"numberOfTodos",
+		"showProject",
+	},
+	"github.com/wtfutil/wtf/modules/gitter": {
+		"apiToken",
+		"numberOfMessages",
+		"roomUri",
+	},
+	"github.com/wtfutil/wtf/modules/googleanalytics": {
+		"enableRealtime",
+		"months",
+		"secretFile",
+		"viewIds",
+	},
+	"github.com/wtfutil/wtf/modules/grafana": {
+		"apiKey",
+		"baseUri",
+	},
+	"github.com/wtfutil/wtf/modules/gspreadsheets": {
+		"cells",
+		"colors",
+		"secretFile",
+		"sheetId",
+	},
+	"github.com/wtfutil/wtf/modules/hackernews": {
+		"numberOfStories",
+		"storyType",
+	},
+	"github.com/wtfutil/wtf/modules/healthchecks": {
+		"apiKey",
+		"apiURL",
+		"tags",
+	},
+	"github.com/wtfutil/wtf/modules/hibp": {
+		"accounts",
+		"apiKey",
+		"apikey",
+		"colors",
+		"since",
+	},
+	"github.com/wtfutil/wtf/modules/ipaddresses/ipapi": {
+		"args",
+		"colors",
+	},
+	"github.com/wtfutil/wtf/modules/ipaddresses/ipinfo": {
+		"apiToken",
+		"protocolVersion",
+	},
+	"github.com/wtfutil/wtf/modules/jenkins": {
+		"apiKey",
+		"apikey",
+		"jobNameRegex",
+		"successBallColor",
+		"url",
+		"user",
+	},
+	"github.com/wtfutil/wtf/modules/jira": {
+		"apiKey",
+		"apikey",
+		"colors",
+		"domain",
+		"email",
+		"jql",
+		"personalAccessToken",
+		"project",
+		"username",
+	},
+	"github.com/wtfutil/wtf/modules/krisinformation": {
+		"country",
+		"county",
+		"latitude",
+		"longitude",
+		"maxages",
+		"maxitems",
+		"radius",
+	},
+	"github.com/wtfutil/wtf/modules/kubernetes": {
+		"context",
+		"kubeconfig",
+		"namespaces",
+		"objects",
+		"title",
+	},
+	"github.com/wtfutil/wtf/modules/logger": {
+		"level",
+		"modules",
+	},
+	"github.com/wtfutil/wtf/modules/lunarphase": {
+		"language",
+	},
+	"github.com/wtfutil/wtf/modules/mercurial": {
+		"commitCount",
+		"commitFormat",
+		"repositories",
+	},
+	"github.com/wtfutil/wtf/modules/newrelic": {
+		"apiKey",
+		"applicationIDs",
+		"deployCount",
+	},
+	"github.com/wtfutil/wtf/modules/nextbus": {
+		"agency",
+		"route",
+		"stopID",
+	},
+	"github.com/wtfutil/wtf/modules/opsgenie": {
+		"apiKey",
+		"apikey",
+		"displayEmpty",
+		"region",
+		"schedule",
+		"scheduleIdentifierType",
+	},
+	"github.com/wtfutil/wtf/modules/oura": {
+		"accessToken",
+		"days",
+		"myName",
+	},
+	"github.com/wtfutil/wtf/modules/pagerduty": {
+		"apiKey",
+		"apikey",
+		"escalationFilter",
+		"myName",
+		"scheduleIDs",
+		"showIncidents",
+		"showOnCallEnd",
+		"showSchedules",
+		"teamIDs",
+		"userIDs",
+	},
+	"github.com/wtfutil/wtf/modules/pihole": {
+		"apiUrl",
+		"maxClientWidth",
+		"maxDomainWidth",
+		"showSummary",
+		"showTopClients",
+		"showTopItems",
+		"token",
+	},
+	"github.com/wtfutil/wtf/modules/pocket": {
+		"consumerKey",
+	},
+	"github.com/wtfutil/wtf/modules/progress": {
+		"colors",
+		"current",
+		"currentCmd",
+		"maximum",
+		"maximumCmd",
+		"minimum",
+		"minimumCmd",
+		"padding",
+		"showPercentage",
+	},
+	"github.com/wtfutil/wtf/modules/resourceusage": {
+		"cpuCombined",
+		"historyWidth",
+		"showCPU",
+		"showMem",
+		"showSwp",
+	},
+	"github.com/wtfutil/wtf/modules/rollbar": {
+		"accessToken",
+		"activeOnly",
+		"assignedToName",
+		"count",
+		"projectName",
+		"projectOwner",
+	},
+	"github.com/wtfutil/wtf/modules/spacex": {
+		"spacex",
+	},
+	"github.com/wtfutil/wtf/modules/spotify": {
+		"colors",
+	},
+	"github.com/wtfutil/wtf/modules/spotifyweb": {
+		"callbackPort",
+		"clientID",
+		"secretKey",
+	},
+	"github.com/wtfutil/wtf/modules/steam": {
+		"key",
+		"numberOfResults",
+		"steam",
+		"userIds",
+	},
+	"github.com/wtfutil/wtf/modules/stocks/finnhub": {
+		"apiKey",
+		"apikey",
+		"symbols",
+	},
+	"github.com/wtfutil/wtf/modules/stocks/yfinance": {
+		"colors",
+		"historyHeight",
+		"historyWidth",
+		"sort",
+		"symbols",
+	},
+	"github.com/wtfutil/wtf/modules/subreddit": {
+		"numberOfPosts",
+		"sortOrder",
+		"subreddit",
+		"topTimePeriod",
+	},
+	"github.com/wtfutil/wtf/modules/textfile": {
+		"filePaths",
+		"format",
+		"formatStyle",
+		"wrapText",
+	},
+	"github.com/wtfutil/wtf/modules/todo": {
+		"checkedIcon",
+		"checkedPos",
+		"colors",
+		"dates",
+		"filename",
+		"newPos",
+		"tags",
+		"uncheckedIcon",
+	},
+	"github.com/wtfutil/wtf/modules/todo_plus": {
+		"accessToken",
+		"apiKey",
+		"apikey",
+		"backendSettings",
+		"backendType",
+		"board",
+		"list",
+		"projects",
+		"username",
+	},
+	"github.com/wtfutil/wtf/modules/todo_plus/backend": {
+		"accessToken",
+		"apiKey",
+		"board",
+		"lists",
+		"projects",
+		"username",
+	},
+	"github.com/wtfutil/wtf/modules/transmission": {
+		"hideComplete",
+		"host",
+		"https",
+		"password",
+		"port",
+		"url",
+		"username",
+	},
+	"github.com/wtfutil/wtf/modules/travisci": {
+		"apiKey",
+		"apikey",
+		"baseURL",
+		"compact",
+		"limit",
+		"pro",
+		"sort_by",
+	},
+	"github.com/wtfutil/wtf/modules/twitch": {
+		"appAccessToken",
+		"clientId",
+		"clientSecret",
+		"gameIds",
+		"languages",
+		"numberOfResults",
+		"redirectURI",
+		"streamType",
+		"streams",
+		"twitch",
+		"userAccessToken",
+		"userId",
+		"userIds",
+		"userLogins",
+		"userRefreshToken",
+	},
+	"github.com/wtfutil/wtf/modules/twitter": {
+		"bearerToken",
+		"consumerKey",
+		"consumerSecret",
+		"count",
+		"screenName",
+	},
+	"github.com/wtfutil/wtf/modules/twitterstats": {
+		"bearerToken",
+		"consumerKey",
+		"consumerSecret",
+		"screenNames",
+	},
+	"github.com/wtfutil/wtf/modules/updown": {
+		"apiKey",
+		"tokens",
+	},
+	"github.com/wtfutil/wtf/modules/uptimerobot": {
+		"apiKey",
+		"offlineFirst",
+		"uptimePeriods",
+	},
+	"github.com/wtfutil/wtf/modules/urlcheck": {
+		"timeout",
+	},
+	"github.com/wtfutil/wtf/modules/victorops": {
+		"apiID",
+		"apiKey",
+		"apikey",
+		"team",
+	},
+	"github.com/wtfutil/wtf/modules/weatherservices/arpansagovau": {
+		"locationid",
+	},
+	"github.com/wtfutil/wtf/modules/weatherservices/prettyweather": {
+		"city",
+		"language",
+		"unit",
+		"view",
+	},
+	"github.com/wtfutil/wtf/modules/weatherservices/weather": {
+		"apiKey",
+		"apikey",
+		"cityids",
+		"colors",
+		"compact",
+		"language",
+		"tempUnit",
+		"useEmoji",
+	},
+	"github.com/wtfutil/wtf/modules/zendesk": {
+		"apiKey",
+		"apikey",
+		"status",
+		"subdomain",
+		"username",
+	},
+}
@@ -0,0 +1,254 @@
+//go:build ignore
+
+// This program finds the configuration keys that every module's settings read, and
+// writes them to settings_keys.go for `wtfutil validate` to check module configurations
+// against. It's run from the validate directory by go generate:
+//
+//	go generate ./validate
+//
+// A key is read when it's the first argument of a method called on a *config.Config
+// parameter, other than globalConfig, in a module's package. Only the top-level part of
+// dotted keys is kept, since that's what validate checks
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const modulePath = "github.com/wtfutil/wtf"
+
+func main() {
+	out := flag.String("o", "settings_keys.go", "The file to write the keys to")
+	flag.Parse()
+
+	keys, err := scanModules(filepath.Join("..", "modules"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*out, render(keys), 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// scanModules returns the keys read in every package under the directory, by the
+// package's import path
+func scanModules(dir string) (map[string][]string, error) {
+	packages := map[string][]string{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return err
+		}
+
+		keys, err := scanPackage(path)
+		if err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			rel, _ := filepath.Rel("..", path)
+			packages[modulePath+"/"+filepath.ToSlash(rel)] = keys
+		}
+
+		return nil
+	})
+
+	return packages, err
+}
+
+// scanPackage returns the sorted keys read by the non-test files in the directory
+func scanPackage(dir string) ([]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+
+	for _, pkg := range pkgs {
+		consts := stringConsts(pkg)
+
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil {
+					continue
+				}
+
+				configs := configParams(fn)
+				if len(configs) == 0 {
+					continue
+				}
+
+				ast.Inspect(fn.Body, func(node ast.Node) bool {
+					if key, ok := readKey(node, configs, consts); ok {
+						found[strings.SplitN(key, ".", 2)[0]] = true
+					}
+					return true
+				})
+			}
+		}
+	}
+
+	keys := []string{}
+	for key := range found {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// configParams returns the names of the function's *config.Config parameters that hold a
+// module's configuration
+func configParams(fn *ast.FuncDecl) map[string]bool {
+	params := map[string]bool{}
+
+	for _, field := range fn.Type.Params.List {
+		star, ok := field.Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+
+		sel, ok := star.X.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Config" {
+			continue
+		}
+
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "config" {
+			continue
+		}
+
+		for _, name := range field.Names {
+			if name.Name != "globalConfig" {
+				params[name.Name] = true
+			}
+		}
+	}
+
+	return params
+}
+
+// readKey returns the key that the node reads, if it's a method call on one of the
+// configurations with a string as its first argument
+func readKey(node ast.Node, configs map[string]bool, consts map[string]string) (string, bool) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return "", false
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+
+	if recv, ok := sel.X.(*ast.Ident); !ok || !configs[recv.Name] {
+		return "", false
+	}
+
+	switch arg := call.Args[0].(type) {
+	case *ast.BasicLit:
+		if arg.Kind != token.STRING {
+			return "", false
+		}
+
+		key, err := strconv.Unquote(arg.Value)
+		return key, err == nil
+	case *ast.Ident:
+		key, ok := consts[arg.Name]
+		return key, ok
+	}
+
+	return "", false
+}
+
+// stringConsts returns the package's string constants, by name
+func stringConsts(pkg *ast.Package) map[string]string {
+	consts := map[string]string{}
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				val, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+
+				for idx, name := range val.Names {
+					if idx >= len(val.Values) {
+						break
+					}
+
+					lit, ok := val.Values[idx].(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						continue
+					}
+
+					if str, err := strconv.Unquote(lit.Value); err == nil {
+						consts[name.Name] = str
+					}
+				}
+			}
+		}
+	}
+
+	return consts
+}
+
+func render(packages map[string][]string) []byte {
+	paths := []string{}
+	for path := range packages {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by settings_keys_gen.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package validate\n\n")
+	buf.WriteString("// moduleSettingsKeys are the top-level configuration keys that modules' settings read,\n")
+	buf.WriteString("// by the import path of the package the settings are in\n")
+	buf.WriteString("var moduleSettingsKeys = map[string][]string{\n")
+
+	for _, path := range paths {
+		fmt.Fprintf(buf, "%q: {\n", path)
+		for _, key := range packages[path] {
+			fmt.Fprintf(buf, "%q,\n", key)
+		}
+		buf.WriteString("},\n")
+	}
+
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	return src
+}
@@ -0,0 +1,374 @@
+//go:generate go run settings_keys_gen.go
+
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/app"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/modules/unknown"
+	"github.com/wtfutil/wtf/notify"
+	"github.com/wtfutil/wtf/registry"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// commonKeys are the module configuration keys that are read by cfg.Common and the view
+// package, and are therefore valid for every module
+var commonKeys = []string{
+	"border",
+	"cache",
+	"cacheTTL",
+	"colors",
+	"enabled",
+	"focusChar",
+	"focusable",
+	"graphIcon",
+	"graphStars",
+	"historySize",
+	"http",
+	"keys",
+	"persistHistory",
+	"position",
+	"refreshInterval",
+	"secretCommand",
+	"secretStore",
+	"title",
+	"type",
+	"verifyServerCertificate",
+}
+
+// Problem is a single issue found in a configuration file
+type Problem struct {
+	FilePath string
+	Line     int
+	Column   int
+	Module   string
+	Message  string
+
+	// Warning is TRUE for problems that do not stop wtfutil from running, such as
+	// unknown configuration keys that are ignored
+	Warning bool
+}
+
+// String returns the Stringer representation of the problem, in the familiar
+// file:line:column format
+func (problem Problem) String() string {
+	severity := aurora.Red("error").String()
+	if problem.Warning {
+		severity = aurora.Yellow("warning").String()
+	}
+
+	return fmt.Sprintf(
+		"%s:%d:%d: %s: %s: %s",
+		problem.FilePath,
+		problem.Line,
+		problem.Column,
+		severity,
+		problem.Module,
+		problem.Message,
+	)
+}
+
+// Display validates the configuration, writes every problem found to the console, and
+// returns the exit code the `validate` command should exit with: 1 if there were errors,
+// and 0 otherwise. Warnings are for things that wtfutil works around, like settings it
+// ignores, so they don't fail the check
+func Display(config *config.Config, configFilePath string) int {
+	problems := Validate(config, configFilePath)
+
+	errCount := 0
+	for _, problem := range problems {
+		fmt.Println(problem)
+
+		if !problem.Warning {
+			errCount++
+		}
+	}
+
+	switch {
+	case errCount > 0:
+		fmt.Printf("\n%d errors, %d warnings\n", errCount, len(problems)-errCount)
+		return 1
+	case len(problems) > 0:
+		fmt.Printf("\n%d warnings\n", len(problems))
+		return 0
+	default:
+		fmt.Printf("%s is valid\n", configFilePath)
+		return 0
+	}
+}
+
+// Validate builds the settings for every enabled module in every dashboard in the
+// configuration without starting the UI, and returns all the problems found
+func Validate(config *config.Config, configFilePath string) []Problem {
+	problems := []Problem{}
+
+	// An unknown theme falls back to the default theme, so it doesn't stop wtfutil
+	if _, err := cfg.LoadColorTheme(cfg.ThemeName(config)); err != nil {
+		line, col := newLocator(configFilePath).locate("wtf.theme")
+
+		problems = append(problems, Problem{
+			FilePath: configFilePath,
+			Line:     line,
+			Column:   col,
+			Module:   "wtf",
+			Message:  err.Error(),
+			Warning:  true,
+		})
+	}
+
+	// Rules with errors are ignored, so they don't stop wtfutil either
+	_, ruleErrs := notify.ParseRules(config)
+	for idx := 0; idx < len(config.UList("wtf.notifications.rules")); idx++ {
+		err, ok := ruleErrs[idx]
+		if !ok {
+			continue
+		}
+
+		line, col := newLocator(configFilePath).locate(fmt.Sprintf("wtf.notifications.rules.%d", idx))
+
+		problems = append(problems, Problem{
+			FilePath: configFilePath,
+			Line:     line,
+			Column:   col,
+			Module:   "wtf",
+			Message:  fmt.Sprintf("invalid notification rule: %v", err),
+			Warning:  true,
+		})
+	}
+
+	// A layout with no columns uses one instead
+	for _, path := range cfg.InvalidLayoutColumns(config) {
+		line, col := newLocator(configFilePath).locate(path)
+
+		problems = append(problems, Problem{
+			FilePath: configFilePath,
+			Line:     line,
+			Column:   col,
+			Module:   "wtf",
+			Message:  fmt.Sprintf("%s must be at least 1", path),
+			Warning:  true,
+		})
+	}
+
+	for _, dashboard := range cfg.LoadDashboards(config, configFilePath) {
+		problems = append(problems, validateDashboard(dashboard)...)
+	}
+
+	return problems
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func validateDashboard(dashboard *cfg.Dashboard) []Problem {
+	locator := newLocator(dashboard.FilePath)
+	problems := []Problem{}
+
+	problemAt := func(module string, keyPath string, warning bool, msg string) Problem {
+		path := dashboard.ModsPath + "." + module
+		if keyPath != "" {
+			path += "." + keyPath
+		}
+
+		line, col := locator.locate(path)
+
+		return Problem{
+			FilePath: dashboard.FilePath,
+			Line:     line,
+			Column:   col,
+			Module:   module,
+			Message:  msg,
+			Warning:  warning,
+		}
+	}
+
+	// Widgets may ask for a redraw as they're built. Nothing is drawn, so throw those away
+	redrawChan := make(chan bool)
+	go func() {
+		for range redrawChan {
+		}
+	}()
+
+	moduleNames, _ := dashboard.Config.Map("wtf.mods")
+
+	names := []string{}
+	for name := range moduleNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	widgets := []wtf.Wtfable{}
+
+	for _, name := range names {
+		widget := app.MakeWidget(nil, nil, name, dashboard.Config, redrawChan)
+		if widget == nil {
+			continue
+		}
+
+		if _, ok := widget.(*unknown.Widget); ok {
+			moduleType := widget.CommonSettings().Module.Type
+
+			msg := fmt.Sprintf("unknown module type %q", moduleType)
+			if registry.Known(moduleType) {
+				msg = fmt.Sprintf("module type %q is not compiled into this build", moduleType)
+			}
+
+			problems = append(problems, problemAt(name, "type", false, msg))
+			continue
+		}
+
+		moduleConfig, _ := dashboard.Config.Get("wtf.mods." + name)
+		for _, key := range unknownKeys(widget, moduleConfig) {
+			problems = append(problems, problemAt(name, key, true, fmt.Sprintf("unknown configuration key %q", key)))
+		}
+
+		widgets = append(widgets, widget)
+	}
+
+	validationErrors := app.NewModuleValidator().Errors(widgets)
+	for _, name := range names {
+		for _, val := range validationErrors[name] {
+			msg := fmt.Sprintf("invalid position %s: %v", val.Name(), val.Error())
+			problems = append(problems, problemAt(name, "position."+val.Name(), false, msg))
+		}
+	}
+
+	keyErrors := app.NewModuleValidator().KeyErrors(widgets)
+	for _, name := range names {
+		for _, keyErr := range keyErrors[name] {
+			problems = append(problems, problemAt(name, "keys."+keyErr.From, false, keyErr.Error()))
+		}
+	}
+
+	// The auto layout places modules itself, so they can't overlap or be outside the grid
+	if cfg.NewLayoutSettingsFromYAML(dashboard.Config).IsAuto() {
+		return problems
+	}
+
+	for _, overlap := range overlappingWidgets(widgets, validationErrors) {
+		msg := fmt.Sprintf("position overlaps with %q", overlap[0].Name())
+		problems = append(problems, problemAt(overlap[1].Name(), "position", false, msg))
+	}
+
+	for _, widget := range outOfGridWidgets(widgets, validationErrors, dashboard.Config) {
+		problems = append(problems, problemAt(widget.Name(), "position", false, "position is outside of the grid"))
+	}
+
+	return problems
+}
+
+// unknownKeys returns the top-level keys in the module's configuration that are neither
+// common to all modules nor read by the module's settings
+func unknownKeys(widget wtf.Wtfable, moduleConfig *config.Config) []string {
+	knownKeys := map[string]bool{}
+	for _, key := range append(commonKeys, settingsKeys(widget)...) {
+		knownKeys[strings.ToLower(key)] = true
+	}
+
+	keys, _ := moduleConfig.Map("")
+
+	unknown := []string{}
+	for key := range keys {
+		if !knownKeys[strings.ToLower(key)] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	return unknown
+}
+
+// settingsKeys finds the module's settings struct on the widget and returns the keys
+// that its package reads, as found by settings_keys_gen.go. Settings that aren't in
+// settings_keys.go fall back to the keys described by their fields
+func settingsKeys(widget wtf.Wtfable) []string {
+	widgetVal := reflect.Indirect(reflect.ValueOf(widget))
+	if widgetVal.Kind() != reflect.Struct {
+		return []string{}
+	}
+
+	settingsVal := widgetVal.FieldByName("settings")
+	if !settingsVal.IsValid() || settingsVal.Kind() != reflect.Ptr {
+		return []string{}
+	}
+
+	settingsType := settingsVal.Type().Elem()
+	if settingsType.Kind() != reflect.Struct {
+		return []string{}
+	}
+
+	keys, ok := moduleSettingsKeys[settingsType.PkgPath()]
+	if !ok {
+		keys = utils.KeysFromInterface(reflect.New(settingsType).Elem().Interface())
+	}
+	keys = append([]string{}, keys...)
+
+	// Multi-source widgets read their sources from keys of their own choosing
+	if multiSource, ok := widget.(interface{ SourceKeys() []string }); ok {
+		keys = append(keys, multiSource.SourceKeys()...)
+	}
+
+	return keys
+}
+
+// overlappingWidgets returns every pair of widgets whose grid positions overlap. Widgets
+// with invalid positions are ignored
+func overlappingWidgets(widgets []wtf.Wtfable, validationErrors map[string][]cfg.Validatable) [][2]wtf.Wtfable {
+	positioned := positionedWidgets(widgets, validationErrors)
+	overlaps := [][2]wtf.Wtfable{}
+
+	for i := 0; i < len(positioned); i++ {
+		for j := i + 1; j < len(positioned); j++ {
+			a := positioned[i].CommonSettings().PositionSettings
+			b := positioned[j].CommonSettings().PositionSettings
+
+			if a.Left < b.Left+b.Width && b.Left < a.Left+a.Width &&
+				a.Top < b.Top+b.Height && b.Top < a.Top+a.Height {
+				overlaps = append(overlaps, [2]wtf.Wtfable{positioned[i], positioned[j]})
+			}
+		}
+	}
+
+	return overlaps
+}
+
+// outOfGridWidgets returns the widgets that extend beyond the columns and rows defined
+// in the grid
+func outOfGridWidgets(widgets []wtf.Wtfable, validationErrors map[string][]cfg.Validatable, config *config.Config) []wtf.Wtfable {
+	cols := len(config.UList("wtf.grid.columns"))
+	rows := len(config.UList("wtf.grid.rows"))
+
+	outside := []wtf.Wtfable{}
+	if cols == 0 || rows == 0 {
+		return outside
+	}
+
+	for _, widget := range positionedWidgets(widgets, validationErrors) {
+		pos := widget.CommonSettings().PositionSettings
+
+		if pos.Left+pos.Width > cols || pos.Top+pos.Height > rows {
+			outside = append(outside, widget)
+		}
+	}
+
+	return outside
+}
+
+func positionedWidgets(widgets []wtf.Wtfable, validationErrors map[string][]cfg.Validatable) []wtf.Wtfable {
+	positioned := []wtf.Wtfable{}
+
+	for _, widget := range widgets {
+		if _, hasErrors := validationErrors[widget.Name()]; !hasErrors {
+			positioned = append(positioned, widget)
+		}
+	}
+
+	return positioned
+}
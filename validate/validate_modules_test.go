@@ -0,0 +1,62 @@
+//go:build (!slim || mod_clocks) && !nomod_clocks
+
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests need the clocks module, and the modules in the sample configs, to be
+// compiled in
+
+func Test_Validate(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.yml")
+	assert.NoError(t, os.WriteFile(filePath, []byte(invalidConfig), 0600))
+
+	ymlConfig, err := config.ParseYaml(invalidConfig)
+	assert.NoError(t, err)
+
+	problems := Validate(ymlConfig, filePath)
+
+	expected := []Problem{
+		{Module: "clocks", Line: 8, Column: 7, Message: `unknown configuration key "bogus"`, Warning: true},
+		{Module: "misspelled", Line: 23, Column: 7, Message: `unknown module type "clockz"`},
+		{Module: "broken", Line: 29, Column: 9, Message: `invalid position top: strconv.ParseInt: parsing "abc": invalid syntax`},
+		{Module: "overlapping", Line: 17, Column: 7, Message: `position overlaps with "clocks"`},
+		{Module: "overlapping", Line: 17, Column: 7, Message: "position is outside of the grid"},
+		{Module: "remapped", Line: 37, Column: 9, Message: `cannot remap "\\" to "Ctrl-R": the key is reserved by wtfutil`},
+		{Module: "wtf", Line: 45, Column: 9, Message: `invalid notification rule: unknown action "siren"`, Warning: true},
+		{Module: "wtf", Line: 50, Column: 9, Message: "wtf.layout.breakpoints.0.columns must be at least 1", Warning: true},
+	}
+
+	for idx := range expected {
+		expected[idx].FilePath = filePath
+	}
+
+	assert.ElementsMatch(t, expected, problems)
+}
+
+func Test_Validate_SampleConfigs(t *testing.T) {
+	paths, _ := filepath.Glob(filepath.Join("..", "_sample_configs", "*.yml"))
+	assert.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			ymlConfig, err := config.ParseYamlFile(path)
+			assert.NoError(t, err)
+
+			for _, problem := range Validate(ymlConfig, path) {
+				// Builds can leave modules out
+				if !strings.Contains(problem.Message, "not compiled into this build") {
+					t.Errorf("unexpected problem: %s", problem)
+				}
+			}
+		})
+	}
+}
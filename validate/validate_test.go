@@ -0,0 +1,131 @@
+package validate
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/registry"
+)
+
+const invalidConfig = `wtf:
+  grid:
+    columns: [20, 20]
+    rows: [3, 3]
+  mods:
+    clocks:
+      enabled: true
+      bogus: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+    overlapping:
+      type: clocks
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 3
+    misspelled:
+      type: clockz
+      enabled: true
+    broken:
+      type: clocks
+      enabled: true
+      position:
+        top: abc
+        left: 1
+        height: 1
+        width: 1
+    remapped:
+      type: clocks
+      enabled: true
+      keys:
+        "\\": Ctrl-R
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: 1
+  notifications:
+    rules:
+      - name: bogus
+        actions: [siren]
+  layout:
+    breakpoints:
+      - below: 100
+        columns: 0
+`
+
+func Test_Validate_ExcludedModule(t *testing.T) {
+	registry.AddKnownTypes("validatetest_excluded")
+
+	excludedConfig := "wtf:\n  mods:\n    excluded:\n      type: validatetest_excluded\n      enabled: true\n"
+
+	filePath := filepath.Join(t.TempDir(), "config.yml")
+	assert.NoError(t, os.WriteFile(filePath, []byte(excludedConfig), 0600))
+
+	ymlConfig, err := config.ParseYaml(excludedConfig)
+	assert.NoError(t, err)
+
+	expected := []Problem{
+		{
+			FilePath: filePath,
+			Line:     4,
+			Column:   7,
+			Module:   "excluded",
+			Message:  `module type "validatetest_excluded" is not compiled into this build`,
+		},
+	}
+
+	assert.Equal(t, expected, Validate(ymlConfig, filePath))
+}
+
+func Test_locate(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.yml")
+	assert.NoError(t, os.WriteFile(filePath, []byte(invalidConfig), 0600))
+
+	loc := newLocator(filePath)
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedLine int
+		expectedCol  int
+	}{
+		{name: "existing key", path: "wtf.mods.broken.position.top", expectedLine: 29, expectedCol: 9},
+		{name: "list item", path: "wtf.grid.columns.1", expectedLine: 3, expectedCol: 19},
+		{name: "missing key", path: "wtf.mods.misspelled.position.top", expectedLine: 22, expectedCol: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, col := loc.locate(tt.path)
+
+			assert.Equal(t, tt.expectedLine, line)
+			assert.Equal(t, tt.expectedCol, col)
+		})
+	}
+}
+
+func Test_moduleSettingsKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the generator")
+	}
+
+	generated := filepath.Join(t.TempDir(), "settings_keys.go")
+
+	out, err := exec.Command("go", "run", "settings_keys_gen.go", "-o", generated).CombinedOutput()
+	assert.NoError(t, err, string(out))
+
+	expected, _ := os.ReadFile(generated)
+	actual, _ := os.ReadFile("settings_keys.go")
+
+	assert.Equal(t, string(expected), string(actual), "settings_keys.go is out of date, run `go generate ./validate`")
+}
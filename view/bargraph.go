@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
@@ -27,6 +28,9 @@ type Bar struct {
 	Percent    int
 	ValueLabel string
 	LabelColor string
+
+	// Sparkline is shown after the value label, i.e.: a History's Sparkline
+	Sparkline string
 }
 
 // NewBarGraph creates and returns an instance of BarGraph
@@ -40,6 +44,7 @@ func NewBarGraph(tviewApp *tview.Application, redrawChan chan bool, _ string, co
 	}
 
 	widget.View = widget.createView(widget.bordered)
+	widget.Base.SetView(widget.View)
 
 	return widget
 }
@@ -49,7 +54,12 @@ func NewBarGraph(tviewApp *tview.Application, redrawChan chan bool, _ string, co
 // BuildBars will build a string of * to represent your data of [time][value]
 // time should be passed as a int64
 func (widget *BarGraph) BuildBars(data []Bar) {
-	widget.View.SetText(BuildStars(data, widget.maxStars, widget.starChar))
+	content := BuildStars(data, widget.maxStars, widget.starChar)
+
+	widget.setRendered(widget.CommonSettings().Title, content, false)
+	widget.View.SetTitle(widget.ContextualTitle(widget.CommonSettings().Title))
+	widget.refreshBorderColor()
+	widget.View.SetText(content)
 }
 
 // BuildStars build the string to display
@@ -59,11 +69,18 @@ func BuildStars(data []Bar, maxStars int, starChar string) string {
 	// the number of characters in the longest label
 	var longestLabel int
 
+	// the number of characters in the longest value label, to line the sparklines up
+	var longestValueLabel int
+
 	//just getting min and max values
 	for _, bar := range data {
 		if len(bar.Label) > longestLabel {
 			longestLabel = len(bar.Label)
 		}
+
+		if width := utf8.RuneCountInString(valueLabel(bar)); width > longestValueLabel {
+			longestValueLabel = width
+		}
 	}
 
 	// each number = how many stars?
@@ -74,16 +91,22 @@ func BuildStars(data []Bar, maxStars int, starChar string) string {
 		//how many stars for this one?
 		var starCount = int(float64(bar.Percent) * starRatio)
 
-		label := bar.ValueLabel
-		if label == "" {
-			label = fmt.Sprint(bar.Percent)
-		}
+		label := valueLabel(bar)
 
 		labelColor := bar.LabelColor
 		if labelColor == "" {
 			labelColor = "default"
 		}
 
+		if bar.Sparkline != "" {
+			label += fmt.Sprintf(
+				"%s [%s]%s[default]",
+				strings.Repeat(" ", longestValueLabel-utf8.RuneCountInString(label)),
+				labelColor,
+				bar.Sparkline,
+			)
+		}
+
 		//write the line
 		_, err := buffer.WriteString(
 			fmt.Sprintf(
@@ -110,6 +133,15 @@ func (widget *BarGraph) TextView() *tview.TextView {
 
 /* -------------------- Unexported Functions -------------------- */
 
+// valueLabel returns the label shown after the bar, its percent unless it has its own
+func valueLabel(bar Bar) string {
+	if bar.ValueLabel == "" {
+		return fmt.Sprint(bar.Percent)
+	}
+
+	return bar.ValueLabel
+}
+
 func (widget *BarGraph) createView(bordered bool) *tview.TextView {
 	view := tview.NewTextView()
 
@@ -83,3 +83,16 @@ func Test_BuildStars(t *testing.T) {
 		result,
 	)
 }
+
+func Test_BuildStars_Sparkline(t *testing.T) {
+	data := []Bar{
+		{Label: "CPU", Percent: 5, ValueLabel: "5%", Sparkline: "▁▃█"},
+		{Label: "Mem", Percent: 50, ValueLabel: "50%", LabelColor: "red", Sparkline: "▂"},
+	}
+
+	result := BuildStars(data, 2, "*")
+	assert.Equal(t,
+		"CPU[[default][default]  ] 5%  [default]▁▃█[default]\nMem[[red]*[default] ] 50% [red]▂[default]\n",
+		result,
+	)
+}
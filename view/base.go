@@ -6,23 +6,35 @@ import (
 	"time"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cache"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 type Base struct {
 	bordered        bool
+	cached          bool
 	commonSettings  *cfg.Common
 	enabled         bool
 	enabledMutex    *sync.Mutex
 	focusChar       string
 	focusable       bool
 	helpTextFunc    func() string
+	histories       map[string]*History
+	historiesLoaded bool
+	historiesMutex  *sync.Mutex
+	lastError       error
+	lastSuccess     time.Time
 	name            string
 	pages           *tview.Pages
 	quitChan        chan bool
 	refreshInterval time.Duration
 	refreshing      bool
+	statusMutex     *sync.Mutex
+	rendered        RenderedContent
+	renderedMutex   *sync.Mutex
 	tviewApp        *tview.Application
 	view            *tview.TextView
 
@@ -40,11 +52,15 @@ func NewBase(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pag
 		enabledMutex:    &sync.Mutex{},
 		focusChar:       commonSettings.FocusChar(),
 		focusable:       commonSettings.Focusable,
+		histories:       map[string]*History{},
+		historiesMutex:  &sync.Mutex{},
 		name:            commonSettings.Name,
 		pages:           pages,
-		quitChan:        make(chan bool),
+		quitChan:        make(chan bool, 1),
 		refreshInterval: commonSettings.RefreshInterval,
 		refreshing:      false,
+		renderedMutex:   &sync.Mutex{},
+		statusMutex:     &sync.Mutex{},
 		tviewApp:        tviewApp,
 
 		RedrawChan: redrawChan,
@@ -55,6 +71,27 @@ func NewBase(tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pag
 
 /* -------------------- Exported Functions -------------------- */
 
+// ApplyColors re-colors the widget's view with its current color settings, i.e.: after
+// the theme has been changed
+func (base *Base) ApplyColors() {
+	if base.view == nil {
+		return
+	}
+
+	colors := base.commonSettings.Colors
+
+	base.view.SetBackgroundColor(wtf.ColorFor(colors.WidgetTheme.Background))
+	base.view.SetTextColor(wtf.ColorFor(colors.TextTheme.Text))
+	base.view.SetTitleColor(wtf.ColorFor(colors.TextTheme.Title))
+
+	if base.view.HasFocus() {
+		base.view.SetBorderColor(wtf.ColorFor(colors.BorderTheme.Focused))
+		return
+	}
+
+	base.refreshBorderColor()
+}
+
 // Bordered returns whether or not this widget should be drawn with a border
 func (base *Base) Bordered() bool {
 	return base.bordered
@@ -62,6 +99,10 @@ func (base *Base) Bordered() bool {
 
 // BorderColor returns the color that the border of this widget should be drawn in
 func (base *Base) BorderColor() string {
+	if base.LastError() != nil {
+		return base.commonSettings.Colors.BorderTheme.Error
+	}
+
 	if base.Focusable() {
 		return base.commonSettings.Colors.BorderTheme.Focusable
 	}
@@ -77,7 +118,22 @@ func (base *Base) ConfigText() string {
 	return utils.HelpFromInterface(cfg.Common{})
 }
 
+// ContextualTitle returns the title to display for this widget, including its focus
+// character and, if its data is failing to refresh or is out of date, its status
 func (base *Base) ContextualTitle(defaultStr string) string {
+	title := base.focusTitle(defaultStr)
+
+	if status := base.status(); status != "" {
+		if title == "" {
+			title = " "
+		}
+		title += fmt.Sprintf("[red]%s[white] ", status)
+	}
+
+	return title
+}
+
+func (base *Base) focusTitle(defaultStr string) string {
 	switch {
 	case defaultStr == "" && base.FocusChar() == "":
 		return ""
@@ -121,6 +177,76 @@ func (base *Base) FocusChar() string {
 	return base.focusChar
 }
 
+// History returns the module's history of the named metric, to push samples into and
+// chart. It's created the first time it's asked for, holding historySize samples, and
+// carries on from the history on disk if persistHistory is set
+func (base *Base) History(name string) *History {
+	base.historiesMutex.Lock()
+	defer base.historiesMutex.Unlock()
+
+	if history, ok := base.histories[name]; ok {
+		return history
+	}
+
+	if !base.historiesLoaded && base.commonSettings.PersistHistory {
+		base.historiesLoaded = true
+		base.loadHistories()
+
+		if history, ok := base.histories[name]; ok {
+			return history
+		}
+	}
+
+	history := NewHistory(base.commonSettings.HistorySize)
+	base.histories[name] = history
+
+	return history
+}
+
+// LastError returns the error from the most recent data refresh, or nil if it succeeded
+func (base *Base) LastError() error {
+	base.statusMutex.Lock()
+	defer base.statusMutex.Unlock()
+
+	return base.lastError
+}
+
+// LastSuccess returns the time of the most recent successful data refresh. It is the
+// zero time if the widget has never refreshed successfully
+func (base *Base) LastSuccess() time.Time {
+	base.statusMutex.Lock()
+	defer base.statusMutex.Unlock()
+
+	return base.lastSuccess
+}
+
+// LoadCache reads the module's last successful payload from the on-disk cache into data,
+// if caching is enabled and the payload has not expired. Until the widget next refreshes
+// successfully its title shows how old the cached data is. Returns TRUE if data was loaded
+func (base *Base) LoadCache(data interface{}) bool {
+	if !base.commonSettings.Cache {
+		return false
+	}
+
+	timestamp, err := cache.Load(base.cacheKey(), base.commonSettings.CacheTTL, data)
+	if err != nil {
+		return false
+	}
+
+	base.statusMutex.Lock()
+	defer base.statusMutex.Unlock()
+
+	base.cached = true
+	base.lastSuccess = timestamp
+
+	return true
+}
+
+// Logger returns a logger whose entries are tagged with the module's name and type
+func (base *Base) Logger() *logger.Logger {
+	return logger.ForModule(base.name, base.commonSettings.Module.Type)
+}
+
 func (base *Base) Name() string {
 	return base.name
 }
@@ -139,10 +265,57 @@ func (base *Base) RefreshInterval() time.Duration {
 	return base.refreshInterval
 }
 
+// SaveCache writes the module's payload to the on-disk cache, if caching is enabled, so
+// that it can be displayed the next time wtfutil starts. Modules that support caching
+// should call it after every successful refresh
+func (base *Base) SaveCache(data interface{}) {
+	if !base.commonSettings.Cache {
+		return
+	}
+
+	_ = cache.Save(base.cacheKey(), data)
+}
+
+// SaveHistory writes the module's histories to disk, if persistHistory is set, so that
+// they carry on from where they left off the next time wtfutil starts. Modules that keep
+// a history should call it after pushing samples into it
+func (base *Base) SaveHistory() {
+	if !base.commonSettings.PersistHistory {
+		return
+	}
+
+	base.historiesMutex.Lock()
+	defer base.historiesMutex.Unlock()
+
+	_ = cache.Save(base.historyKey(), base.histories)
+}
+
+// SetError records the outcome of a data refresh. Modules should call it at the end of
+// every refresh, passing nil when the refresh succeeded
+func (base *Base) SetError(err error) {
+	base.statusMutex.Lock()
+	defer base.statusMutex.Unlock()
+
+	base.lastError = err
+
+	if err == nil {
+		base.cached = false
+		base.lastSuccess = time.Now()
+	}
+}
+
 func (base *Base) SetFocusChar(char string) {
 	base.focusChar = char
 }
 
+// Rendered returns the content that this widget most recently rendered
+func (base *Base) Rendered() RenderedContent {
+	base.renderedMutex.Lock()
+	defer base.renderedMutex.Unlock()
+
+	return base.rendered
+}
+
 // SetView assigns the passed-in tview.TextView view to this widget
 func (base *Base) SetView(view *tview.TextView) {
 	base.view = view
@@ -168,13 +341,113 @@ func (base *Base) ShowHelp() {
 	base.RedrawChan <- true
 }
 
+// Stale returns TRUE if the widget's data is out of date, either because its most recent
+// refresh failed or because it has gone more than two refresh intervals without a
+// successful refresh. A widget that has never refreshed successfully is not stale, it
+// has no data to be out of date.
+//
+// Refreshes are only known of when the module reports them with SetError, so a module
+// that doesn't is never stale
+func (base *Base) Stale() bool {
+	base.statusMutex.Lock()
+	defer base.statusMutex.Unlock()
+
+	if base.lastSuccess.IsZero() {
+		return false
+	}
+
+	if base.lastError != nil {
+		return true
+	}
+
+	return base.refreshInterval > 0 && time.Since(base.lastSuccess) > 2*base.refreshInterval
+}
+
+// Stop disables the widget and signals its scheduler to quit. It does not block if
+// nothing is listening for the signal, i.e.: the widget has no refresh interval
 func (base *Base) Stop() {
 	base.enabledMutex.Lock()
 	base.enabled = false
 	base.enabledMutex.Unlock()
-	base.quitChan <- true
+
+	select {
+	case base.quitChan <- true:
+	default:
+	}
 }
 
 func (base *Base) String() string {
 	return base.name
 }
+
+/* -------------------- Unexported Functions -------------------- */
+
+// status returns a short description of a failing or stale widget's state, suitable for
+// displaying in its title, or an empty string if the widget is up to date
+func (base *Base) status() string {
+	switch {
+	case base.isCached() && base.LastError() == nil:
+		return "cached " + wtf.ShortDuration(time.Since(base.LastSuccess())) + " ago"
+	case base.Stale():
+		return "updated " + wtf.ShortDuration(time.Since(base.LastSuccess())) + " ago"
+	case base.LastError() != nil:
+		return "refresh failed"
+	default:
+		return ""
+	}
+}
+
+func (base *Base) cacheKey() string {
+	return base.commonSettings.Module.Type + "-" + base.name
+}
+
+func (base *Base) historyKey() string {
+	return base.cacheKey() + "-history"
+}
+
+// isCached returns TRUE if the widget is displaying data loaded from the cache
+func (base *Base) isCached() bool {
+	base.statusMutex.Lock()
+	defer base.statusMutex.Unlock()
+
+	return base.cached
+}
+
+// refreshBorderColor redraws the border in the color that reflects the widget's current
+// state. The focused widget keeps its focus color
+func (base *Base) refreshBorderColor() {
+	if base.view == nil || base.view.HasFocus() {
+		return
+	}
+
+	base.view.SetBorderColor(wtf.ColorFor(base.BorderColor()))
+}
+
+// loadHistories reads the module's histories from disk, resized to historySize. The
+// histories mutex must be held
+func (base *Base) loadHistories() {
+	loaded := map[string]*History{}
+	if _, err := cache.Load(base.historyKey(), 0, &loaded); err != nil {
+		return
+	}
+
+	for name, saved := range loaded {
+		history := NewHistory(base.commonSettings.HistorySize)
+		for _, sample := range saved.Samples() {
+			history.PushAt(sample.Time, sample.Value)
+		}
+
+		base.histories[name] = history
+	}
+}
+
+func (base *Base) setRendered(title, content string, wrap bool) {
+	base.renderedMutex.Lock()
+	defer base.renderedMutex.Unlock()
+
+	base.rendered = RenderedContent{
+		Title:   title,
+		Content: content,
+		Wrap:    wrap,
+	}
+}
@@ -1,7 +1,9 @@
 package view
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
@@ -74,3 +76,132 @@ func Test_ContextualTitle(t *testing.T) {
 		})
 	}
 }
+
+func Test_ContextualTitle_WithStatus(t *testing.T) {
+	base := NewBase(
+		tview.NewApplication(),
+		make(chan bool),
+		tview.NewPages(),
+		&cfg.Common{},
+	)
+	base.SetFocusChar("a")
+
+	base.SetError(errors.New("boom"))
+	assert.Equal(t, " cats [darkgray::u]a[::-][white] [red]refresh failed[white] ", base.ContextualTitle("cats"))
+
+	base.SetError(nil)
+	assert.Equal(t, " cats [darkgray::u]a[::-][white] ", base.ContextualTitle("cats"))
+
+	base.SetError(errors.New("boom"))
+	assert.Equal(t, " cats [darkgray::u]a[::-][white] [red]updated 0s ago[white] ", base.ContextualTitle("cats"))
+}
+
+func Test_BorderColor(t *testing.T) {
+	common := &cfg.Common{Enabled: true, Focusable: true}
+	common.Colors.BorderTheme = cfg.BorderTheme{Error: "red", Focusable: "blue", Unfocusable: "gray"}
+
+	base := NewBase(tview.NewApplication(), make(chan bool), tview.NewPages(), common)
+	assert.Equal(t, "blue", base.BorderColor())
+
+	base.SetError(errors.New("boom"))
+	assert.Equal(t, "red", base.BorderColor())
+
+	base.SetError(nil)
+	assert.Equal(t, "blue", base.BorderColor())
+}
+
+func Test_Stale(t *testing.T) {
+	tests := []struct {
+		name            string
+		refreshInterval time.Duration
+		lastSuccess     time.Duration
+		lastError       error
+		expected        bool
+	}{
+		{
+			name:     "when never refreshed",
+			expected: false,
+		},
+		{
+			name:      "when never refreshed successfully",
+			lastError: errors.New("boom"),
+			expected:  false,
+		},
+		{
+			name:            "when recently refreshed",
+			refreshInterval: time.Minute,
+			lastSuccess:     30 * time.Second,
+			expected:        false,
+		},
+		{
+			name:            "when the last refresh failed",
+			refreshInterval: time.Minute,
+			lastSuccess:     30 * time.Second,
+			lastError:       errors.New("boom"),
+			expected:        true,
+		},
+		{
+			name:            "when not refreshed for two intervals",
+			refreshInterval: time.Minute,
+			lastSuccess:     3 * time.Minute,
+			expected:        true,
+		},
+		{
+			name:        "when not refreshed on an interval",
+			lastSuccess: time.Hour,
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := NewBase(
+				tview.NewApplication(),
+				make(chan bool),
+				tview.NewPages(),
+				&cfg.Common{RefreshInterval: tt.refreshInterval},
+			)
+
+			if tt.lastSuccess > 0 {
+				base.lastSuccess = time.Now().Add(-tt.lastSuccess)
+			}
+			base.lastError = tt.lastError
+
+			assert.Equal(t, tt.expected, base.Stale())
+		})
+	}
+}
+
+func Test_Stale_WhenReported(t *testing.T) {
+	base := NewBase(
+		tview.NewApplication(),
+		make(chan bool),
+		tview.NewPages(),
+		&cfg.Common{RefreshInterval: time.Minute},
+	)
+
+	// Without SetError nothing is known of the refreshes, failed or not
+	assert.False(t, base.Stale())
+
+	base.SetError(nil)
+	assert.False(t, base.Stale())
+
+	base.SetError(errors.New("boom"))
+	assert.True(t, base.Stale())
+}
+
+func Test_ContextualTitle_WhenCached(t *testing.T) {
+	base := NewBase(
+		tview.NewApplication(),
+		make(chan bool),
+		tview.NewPages(),
+		&cfg.Common{},
+	)
+
+	base.cached = true
+	base.lastSuccess = time.Now().Add(-5 * time.Minute)
+	assert.Equal(t, " cats [red]cached 5m ago[white] ", base.ContextualTitle("cats"))
+
+	base.SetError(nil)
+	assert.Equal(t, " cats ", base.ContextualTitle("cats"))
+}
@@ -0,0 +1,209 @@
+package view
+
+import (
+	"encoding/json"
+	"math"
+	"sync"
+	"time"
+)
+
+// Sample is one value of a metric, and when it was measured
+type Sample struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// HistoryStats summarizes the samples in a history
+type HistoryStats struct {
+	Avg  float64
+	Last float64
+	Max  float64
+	Min  float64
+}
+
+// defaultHistorySize is the number of samples that a history which wasn't created with
+// NewHistory keeps
+const defaultHistorySize = 60
+
+// History is a ring buffer of the most recent samples of a metric. Once it's full, every
+// new sample replaces the oldest one. It's safe to use from several goroutines. The zero
+// value is an empty history that keeps defaultHistorySize samples
+type History struct {
+	mutex   sync.RWMutex
+	next    int
+	samples []Sample
+	size    int
+}
+
+// NewHistory creates and returns a history that keeps the given number of samples
+func NewHistory(size int) *History {
+	if size < 1 {
+		size = 1
+	}
+
+	return &History{
+		samples: make([]Sample, 0, size),
+		size:    size,
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Push adds a sample of the value, measured now
+func (history *History) Push(value float64) {
+	history.PushAt(time.Now(), value)
+}
+
+// PushAt adds a sample of the value, measured at the given time. Values that aren't
+// numbers, or are infinite, are dropped
+func (history *History) PushAt(at time.Time, value float64) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+
+	history.push(Sample{Time: at, Value: value})
+}
+
+// Last returns the most recent sample, if there is one
+func (history *History) Last() (Sample, bool) {
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
+
+	if len(history.samples) == 0 {
+		return Sample{}, false
+	}
+
+	last := (history.next + len(history.samples) - 1) % len(history.samples)
+
+	return history.samples[last], true
+}
+
+// Len returns the number of samples in the history
+func (history *History) Len() int {
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
+
+	return len(history.samples)
+}
+
+// Samples returns the samples in the history, oldest first
+func (history *History) Samples() []Sample {
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
+
+	samples := make([]Sample, 0, len(history.samples))
+	samples = append(samples, history.samples[history.next:]...)
+	samples = append(samples, history.samples[:history.next]...)
+
+	return samples
+}
+
+// Size returns the number of samples the history keeps
+func (history *History) Size() int {
+	history.mutex.RLock()
+	defer history.mutex.RUnlock()
+
+	if history.size < 1 {
+		return defaultHistorySize
+	}
+
+	return history.size
+}
+
+// Stats returns the lowest, highest, average and most recent values in the history. It
+// returns FALSE if the history is empty
+func (history *History) Stats() (HistoryStats, bool) {
+	samples := history.Samples()
+	if len(samples) == 0 {
+		return HistoryStats{}, false
+	}
+
+	stats := HistoryStats{
+		Last: samples[len(samples)-1].Value,
+		Max:  samples[0].Value,
+		Min:  samples[0].Value,
+	}
+
+	total := 0.0
+	for _, sample := range samples {
+		stats.Max = math.Max(stats.Max, sample.Value)
+		stats.Min = math.Min(stats.Min, sample.Value)
+		total += sample.Value
+	}
+	stats.Avg = total / float64(len(samples))
+
+	return stats, true
+}
+
+// Values returns the values of the samples in the history, oldest first
+func (history *History) Values() []float64 {
+	samples := history.Samples()
+
+	values := make([]float64, len(samples))
+	for idx, sample := range samples {
+		values[idx] = sample.Value
+	}
+
+	return values
+}
+
+// Sparkline returns the most recent values as a sparkline that's at most width
+// characters wide. See Sparkline
+func (history *History) Sparkline(width int) string {
+	return Sparkline(history.Values(), width)
+}
+
+// BrailleChart returns the most recent values as a braille line chart. See BrailleChart
+func (history *History) BrailleChart(width, height int) string {
+	return BrailleChart(history.Values(), width, height)
+}
+
+// MarshalJSON writes the history as its list of samples, oldest first
+func (history *History) MarshalJSON() ([]byte, error) {
+	return json.Marshal(history.Samples())
+}
+
+// UnmarshalJSON reads a list of samples into the history, replacing the ones it has. A
+// history that hasn't been sized with NewHistory is made big enough to hold them all
+func (history *History) UnmarshalJSON(data []byte) error {
+	samples := []Sample{}
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return err
+	}
+
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+
+	if history.size < 1 {
+		history.size = maxInt(len(samples), 1)
+	}
+
+	history.next = 0
+	history.samples = make([]Sample, 0, history.size)
+	for _, sample := range samples {
+		history.push(sample)
+	}
+
+	return nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// push adds the sample, replacing the oldest one if the history is full. The mutex must
+// be held
+func (history *History) push(sample Sample) {
+	if history.size < 1 {
+		history.size = defaultHistorySize
+	}
+
+	if len(history.samples) < history.size {
+		history.samples = append(history.samples, sample)
+		return
+	}
+
+	history.samples[history.next] = sample
+	history.next = (history.next + 1) % history.size
+}
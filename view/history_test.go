@@ -0,0 +1,114 @@
+package view
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+func Test_History_Push(t *testing.T) {
+	history := NewHistory(3)
+
+	_, ok := history.Last()
+	assert.False(t, ok)
+
+	for _, val := range []float64{1, 2, math.NaN(), 3, math.Inf(1), 4, 5} {
+		history.Push(val)
+	}
+
+	assert.Equal(t, []float64{3, 4, 5}, history.Values())
+	assert.Equal(t, 3, history.Len())
+	assert.Equal(t, 3, history.Size())
+
+	last, ok := history.Last()
+	assert.True(t, ok)
+	assert.Equal(t, 5.0, last.Value)
+}
+
+func Test_History_ZeroValue(t *testing.T) {
+	history := &History{}
+
+	for val := 0; val < defaultHistorySize+2; val++ {
+		history.Push(float64(val))
+	}
+
+	assert.Equal(t, defaultHistorySize, history.Len())
+	assert.Equal(t, defaultHistorySize, history.Size())
+	assert.Equal(t, 2.0, history.Values()[0])
+}
+
+func Test_History_UnmarshalJSON_Concurrent(t *testing.T) {
+	history := NewHistory(5)
+	data := []byte(`[{"time":"2021-01-01T00:00:00Z","value":1},{"time":"2021-01-01T00:01:00Z","value":2}]`)
+
+	done := make(chan bool)
+	go func() {
+		for idx := 0; idx < 100; idx++ {
+			history.Push(3)
+		}
+		close(done)
+	}()
+
+	for idx := 0; idx < 100; idx++ {
+		assert.NoError(t, json.Unmarshal(data, history))
+	}
+	<-done
+
+	assert.Equal(t, 5, history.Size())
+	assert.LessOrEqual(t, history.Len(), 5)
+}
+
+func Test_History_Stats(t *testing.T) {
+	history := NewHistory(10)
+
+	_, ok := history.Stats()
+	assert.False(t, ok)
+
+	for _, val := range []float64{4, 1, 7} {
+		history.Push(val)
+	}
+
+	stats, ok := history.Stats()
+	assert.True(t, ok)
+	assert.Equal(t, HistoryStats{Avg: 4, Last: 7, Max: 7, Min: 1}, stats)
+	assert.Equal(t, "min 1.00 avg 4.00 max 7.00", stats.Labels(nil))
+}
+
+func Test_History_JSON(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	history := NewHistory(2)
+	for idx, val := range []float64{1, 2, 3} {
+		history.PushAt(start.Add(time.Duration(idx)*time.Minute), val)
+	}
+
+	data, err := json.Marshal(history)
+	assert.NoError(t, err)
+
+	loaded := map[string]*History{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"cpu":`+string(data)+`}`), &loaded))
+
+	assert.Equal(t, history.Samples(), loaded["cpu"].Samples())
+
+	// A loaded history keeps on rotating
+	loaded["cpu"].Push(4)
+	assert.Equal(t, []float64{3, 4}, loaded["cpu"].Values())
+}
+
+func Test_Base_History(t *testing.T) {
+	base := NewBase(nil, nil, nil, &cfg.Common{HistorySize: 5})
+
+	history := base.History("cpu")
+	history.Push(1)
+
+	assert.Same(t, history, base.History("cpu"))
+	assert.NotSame(t, history, base.History("mem"))
+	assert.Equal(t, 5, history.Size())
+
+	// Without persistHistory there's nothing to write
+	base.SaveHistory()
+}
@@ -2,6 +2,8 @@ package view
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/wtfutil/wtf/cfg"
@@ -18,6 +20,33 @@ type helpItem struct {
 	Text string
 }
 
+// KeyboardAction is a keyboard command registered on a widget, with the function that
+// the key runs
+type KeyboardAction struct {
+	Key    string
+	Text   string
+	Action func()
+}
+
+// KeyRemapping is a keyboard command that has been moved to a different key by the `keys`
+// setting
+type KeyRemapping struct {
+	From string
+	To   string
+	Text string
+}
+
+// KeyBindingError describes a `keys` remapping that could not be applied
+type KeyBindingError struct {
+	From   string
+	To     string
+	Reason string
+}
+
+func (err KeyBindingError) Error() string {
+	return fmt.Sprintf("cannot remap %q to %q: %s", err.From, err.To, err.Reason)
+}
+
 // KeyboardWidget manages keyboard control for a widget
 type KeyboardWidget struct {
 	settings *cfg.Common
@@ -27,6 +56,13 @@ type KeyboardWidget struct {
 	charHelp []helpItem
 	keyHelp  []helpItem
 	maxKey   int
+
+	bindingErrors []KeyBindingError
+	remappings    []KeyRemapping
+
+	// keyHandler sees key presses before the keyboard commands do, and returns TRUE if it
+	// handled them, i.e.: while a search is being typed
+	keyHandler func(event *tcell.EventKey) bool
 }
 
 // NewKeyboardWidget creates and returns a new instance of KeyboardWidget
@@ -101,6 +137,10 @@ func (widget *KeyboardWidget) InputCapture(event *tcell.EventKey) *tcell.EventKe
 		return nil
 	}
 
+	if widget.keyHandler != nil && widget.keyHandler(event) {
+		return nil
+	}
+
 	fn := widget.charMap[string(event.Rune())]
 	if fn != nil {
 		fn()
@@ -116,6 +156,33 @@ func (widget *KeyboardWidget) InputCapture(event *tcell.EventKey) *tcell.EventKe
 	return event
 }
 
+// KeyBindingErrors returns the `keys` remappings that could not be applied, because the
+// key they name does not exist or is already bound to another command
+func (widget *KeyboardWidget) KeyBindingErrors() []KeyBindingError {
+	return widget.bindingErrors
+}
+
+// KeyboardActions returns every keyboard command registered on the widget, in the order
+// they are listed in the help text
+func (widget *KeyboardWidget) KeyboardActions() []KeyboardAction {
+	actions := []KeyboardAction{}
+
+	for _, item := range widget.charHelp {
+		actions = append(actions, KeyboardAction{item.Key, item.Text, widget.charMap[item.Key]})
+	}
+
+	for _, item := range widget.keyHelp {
+		for key, fn := range widget.keyMap {
+			if tcell.KeyNames[key] == item.Key {
+				actions = append(actions, KeyboardAction{item.Key, item.Text, fn})
+				break
+			}
+		}
+	}
+
+	return actions
+}
+
 // LaunchDocumentation opens the module docs in a browser
 func (widget *KeyboardWidget) LaunchDocumentation() {
 	path := widget.settings.DocPath
@@ -127,7 +194,29 @@ func (widget *KeyboardWidget) LaunchDocumentation() {
 	utils.OpenFile(url)
 }
 
-// SetKeyboardChar sets a character/function combination that responds to key presses
+// KeyRemappings returns the keyboard commands that the `keys` setting moved to other keys
+func (widget *KeyboardWidget) KeyRemappings() []KeyRemapping {
+	return widget.remappings
+}
+
+// KeyFromName returns the tcell.Key with the given name, i.e.: "Ctrl-D", ignoring case
+func KeyFromName(name string) (tcell.Key, bool) {
+	found := false
+	var foundKey tcell.Key
+
+	for key, keyName := range tcell.KeyNames {
+		// Some keys share a name, so prefer the lowest for consistency
+		if strings.EqualFold(keyName, name) && (!found || key < foundKey) {
+			foundKey = key
+			found = true
+		}
+	}
+
+	return foundKey, found
+}
+
+// SetKeyboardChar sets a character/function combination that responds to key presses.
+// The `keys` setting can move the command to a different key, or disable it
 // Example:
 //
 //	widget.SetKeyboardChar("d", widget.deleteSelectedItem)
@@ -136,20 +225,101 @@ func (widget *KeyboardWidget) SetKeyboardChar(char string, fn func(), helpText s
 		return
 	}
 
-	// Check to ensure that the key trying to be used isn't already being used for something
+	widget.bind(char, fn, helpText)
+}
+
+// SetKeyboardKey sets a tcell.Key/function combination that responds to key presses.
+// The `keys` setting can move the command to a different key, or disable it
+// Example:
+//
+//	widget.SetKeyboardKey(tcell.KeyCtrlD, widget.deleteSelectedItem)
+func (widget *KeyboardWidget) SetKeyboardKey(key tcell.Key, fn func(), helpText string) {
+	// Keys without names can't be referred to in the `keys` setting
+	if name, ok := tcell.KeyNames[key]; ok {
+		if _, remapped := widget.remap(name); remapped {
+			widget.bind(name, fn, helpText)
+			return
+		}
+
+		if _, ok := widget.keyMap[key]; ok && widget.isRemapTarget(name) {
+			widget.remappedOnto(name, helpText)
+			return
+		}
+	}
+
+	widget.bindKey(key, fn, helpText)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// bind assigns the function to the named key, or to the key that the `keys` setting
+// remaps it to. Names that are a single character are bound as text characters, and
+// longer names as the tcell.Key of that name
+func (widget *KeyboardWidget) bind(name string, fn func(), helpText string) {
+	target, remapped := widget.remap(name)
+	if target == "" {
+		return
+	}
+
+	if remapped {
+		widget.remappings = append(widget.remappings, KeyRemapping{From: name, To: target, Text: helpText})
+	}
+
+	if utf8.RuneCountInString(target) == 1 {
+		widget.bindChar(name, target, fn, helpText, remapped)
+		return
+	}
+
+	key, ok := KeyFromName(target)
+	if !ok {
+		widget.conflict(name, target, "there is no key with that name")
+		return
+	}
+
+	if _, ok := widget.keyMap[key]; ok {
+		switch {
+		case remapped:
+			widget.conflict(name, target, "the key is already bound to another command")
+			return
+		case widget.isRemapTarget(target):
+			widget.remappedOnto(target, helpText)
+			return
+		}
+	}
+
+	widget.bindKey(key, fn, helpText)
+}
+
+func (widget *KeyboardWidget) bindChar(name, char string, fn func(), helpText string, remapped bool) {
 	if _, ok := widget.charMap[char]; ok {
-		panic(fmt.Sprintf("Key is already mapped to a keyboard command: %s\n", char))
+		switch {
+		case remapped:
+			widget.conflict(name, char, "the key is already bound to another command")
+		case widget.isRemapTarget(char):
+			widget.remappedOnto(char, helpText)
+		default:
+			// Check to ensure that the key trying to be used isn't already being used for something
+			panic(fmt.Sprintf("Key is already mapped to a keyboard command: %s\n", char))
+		}
+
+		return
 	}
 
 	widget.charMap[char] = fn
 	widget.charHelp = append(widget.charHelp, helpItem{char, helpText})
 }
 
-// SetKeyboardKey sets a tcell.Key/function combination that responds to key presses
-// Example:
-//
-//	widget.SetKeyboardKey(tcell.KeyCtrlD, widget.deleteSelectedItem)
-func (widget *KeyboardWidget) SetKeyboardKey(key tcell.Key, fn func(), helpText string) {
+func (widget *KeyboardWidget) bindKey(key tcell.Key, fn func(), helpText string) {
+	// A module's own command replaces a common one on the same key, i.e.: Ctrl-F for search
+	if _, ok := widget.keyMap[key]; ok {
+		for idx, item := range widget.keyHelp {
+			if item.Key == tcell.KeyNames[key] {
+				widget.keyHelp = append(widget.keyHelp[:idx], widget.keyHelp[idx+1:]...)
+				break
+			}
+		}
+	}
+
 	widget.keyMap[key] = fn
 	widget.keyHelp = append(widget.keyHelp, helpItem{tcell.KeyNames[key], helpText})
 
@@ -158,7 +328,47 @@ func (widget *KeyboardWidget) SetKeyboardKey(key tcell.Key, fn func(), helpText
 	}
 }
 
-/* -------------------- Unexported Functions -------------------- */
+func (widget *KeyboardWidget) conflict(from, to, reason string) {
+	widget.bindingErrors = append(widget.bindingErrors, KeyBindingError{From: from, To: to, Reason: reason})
+}
+
+// isRemapTarget returns TRUE if the `keys` setting moves any command to the named key
+func (widget *KeyboardWidget) isRemapTarget(name string) bool {
+	for _, remapping := range widget.remappings {
+		if strings.EqualFold(remapping.To, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// remappedOnto records a conflict against the remapping that moved a command onto the
+// named key, when a command that was not remapped is also bound to it
+func (widget *KeyboardWidget) remappedOnto(name, helpText string) {
+	for _, remapping := range widget.remappings {
+		if strings.EqualFold(remapping.To, name) {
+			widget.conflict(remapping.From, remapping.To, fmt.Sprintf("the key is already bound to %q", helpText))
+			return
+		}
+	}
+}
+
+// remap returns the key that the `keys` setting binds the named key's command to, and
+// whether it is different from the named key. An empty name means the command is disabled
+func (widget *KeyboardWidget) remap(name string) (string, bool) {
+	if widget.settings == nil {
+		return name, false
+	}
+
+	for from, to := range widget.settings.Keys {
+		if from == name || (utf8.RuneCountInString(name) > 1 && strings.EqualFold(from, name)) {
+			return to, to != name
+		}
+	}
+
+	return name, false
+}
 
 // initializeCommonKeyboardControls sets up the keyboard controls that are common to
 // all widgets that accept keyboard input
@@ -171,6 +171,120 @@ func Test_InputCapture(t *testing.T) {
 	}
 }
 
+func Test_KeyboardActions(t *testing.T) {
+	keyWid := testKeyboardWidget()
+	keyWid.SetKeyboardChar("a", test, "char help")
+	keyWid.SetKeyboardKey(tcell.KeyCtrlD, test, "key help")
+
+	actions := keyWid.KeyboardActions()
+
+	assert.Equal(t, 3, len(actions))
+	assert.Equal(t, "\\", actions[0].Key)
+	assert.Equal(t, "a", actions[1].Key)
+	assert.Equal(t, "char help", actions[1].Text)
+	assert.Equal(t, "Ctrl-D", actions[2].Key)
+	assert.Equal(t, "key help", actions[2].Text)
+
+	for _, action := range actions {
+		assert.NotNil(t, action.Action)
+	}
+}
+
+func Test_KeyRemapping(t *testing.T) {
+	tests := []struct {
+		name             string
+		keys             map[string]string
+		expectedChars    []string
+		expectedKeys     []tcell.Key
+		expectedErrors   []KeyBindingError
+		expectedRemapped int
+	}{
+		{
+			name:          "with no remappings",
+			keys:          map[string]string{},
+			expectedChars: []string{"j", "k"},
+			expectedKeys:  []tcell.Key{tcell.KeyCtrlD},
+		},
+		{
+			name:             "with swapped chars",
+			keys:             map[string]string{"j": "k", "k": "j"},
+			expectedChars:    []string{"j", "k"},
+			expectedKeys:     []tcell.Key{tcell.KeyCtrlD},
+			expectedRemapped: 2,
+		},
+		{
+			name:             "with a char remapped to a key",
+			keys:             map[string]string{"j": "down"},
+			expectedChars:    []string{"k"},
+			expectedKeys:     []tcell.Key{tcell.KeyCtrlD, tcell.KeyDown},
+			expectedRemapped: 1,
+		},
+		{
+			name:             "with a key remapped to a char",
+			keys:             map[string]string{"ctrl-d": "x"},
+			expectedChars:    []string{"j", "k", "x"},
+			expectedKeys:     []tcell.Key{},
+			expectedRemapped: 1,
+		},
+		{
+			name:          "with a disabled char",
+			keys:          map[string]string{"j": ""},
+			expectedChars: []string{"k"},
+			expectedKeys:  []tcell.Key{tcell.KeyCtrlD},
+		},
+		{
+			name:             "with a conflicting remapping",
+			keys:             map[string]string{"j": "k"},
+			expectedChars:    []string{"k"},
+			expectedKeys:     []tcell.Key{tcell.KeyCtrlD},
+			expectedErrors:   []KeyBindingError{{From: "j", To: "k", Reason: `the key is already bound to "previous"`}},
+			expectedRemapped: 1,
+		},
+		{
+			name:             "with an unknown key",
+			keys:             map[string]string{"j": "Ctrl-Banana"},
+			expectedChars:    []string{"k"},
+			expectedKeys:     []tcell.Key{tcell.KeyCtrlD},
+			expectedErrors:   []KeyBindingError{{From: "j", To: "Ctrl-Banana", Reason: "there is no key with that name"}},
+			expectedRemapped: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyWid := NewKeyboardWidget(&cfg.Common{Keys: tt.keys})
+			keyWid.SetKeyboardChar("j", test, "next")
+			keyWid.SetKeyboardChar("k", test, "previous")
+			keyWid.SetKeyboardKey(tcell.KeyCtrlD, test, "delete")
+
+			chars := []string{}
+			for char := range keyWid.charMap {
+				if char != "\\" {
+					chars = append(chars, char)
+				}
+			}
+
+			keys := []tcell.Key{}
+			for key := range keyWid.keyMap {
+				keys = append(keys, key)
+			}
+
+			assert.ElementsMatch(t, tt.expectedChars, chars)
+			assert.ElementsMatch(t, tt.expectedKeys, keys)
+			assert.Equal(t, tt.expectedErrors, keyWid.KeyBindingErrors())
+			assert.Equal(t, tt.expectedRemapped, len(keyWid.KeyRemappings()))
+		})
+	}
+}
+
+func Test_KeyRemapping_HelpText(t *testing.T) {
+	keyWid := NewKeyboardWidget(&cfg.Common{Keys: map[string]string{"j": "n"}})
+	keyWid.SetKeyboardChar("j", test, "next")
+
+	assert.Contains(t, keyWid.HelpText(), "  n\tnext\n")
+	assert.NotContains(t, keyWid.HelpText(), "  j\t")
+}
+
 func Test_initializeCommonKeyboardControls(t *testing.T) {
 	t.Run("nil refreshFunc", func(t *testing.T) {
 		keyWid := testKeyboardWidget()
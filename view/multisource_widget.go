@@ -40,6 +40,11 @@ func (widget *MultiSourceWidget) CurrentSource() string {
 	return widget.Sources[widget.Idx]
 }
 
+// SourceKeys returns the configuration keys that the sources are read from
+func (widget *MultiSourceWidget) SourceKeys() []string {
+	return []string{widget.singular, widget.plural}
+}
+
 // NextSource displays the next source in the source list. If the current source is the last
 // source it wraps around to the first source
 func (widget *MultiSourceWidget) NextSource() {
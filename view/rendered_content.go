@@ -0,0 +1,38 @@
+package view
+
+import (
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// RenderedContent is the title and body that a widget last displayed onscreen, as
+// produced by its render function
+type RenderedContent struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Wrap    bool   `json:"wrap"`
+}
+
+// PlainContent returns the rendered body with all the tview color and region tags removed
+func (rendered RenderedContent) PlainContent() string {
+	return stripTags(rendered.Content)
+}
+
+// PlainTitle returns the rendered title with all the tview color and region tags removed
+func (rendered RenderedContent) PlainTitle() string {
+	return stripTags(rendered.Title)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// stripTags lets tview parse the tags out of the text, because a simple regular
+// expression would also strip out legitimate bracketed text, i.e.: "[WTF-123]"
+func stripTags(text string) string {
+	textView := tview.NewTextView()
+	textView.SetDynamicColors(true)
+	textView.SetRegions(true)
+	textView.SetText(text)
+
+	return strings.TrimRight(textView.GetText(true), "\n")
+}
@@ -0,0 +1,31 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RenderedContent_Plain(t *testing.T) {
+	rendered := RenderedContent{
+		Title:   "[green]Jira[white] (3)",
+		Content: "[\"0\"][red]WTF-123[\"\"] [::b]Fix the[::-] [WTF-124] thing\n\n",
+	}
+
+	assert.Equal(t, "Jira (3)", rendered.PlainTitle())
+	assert.Equal(t, "WTF-123 Fix the [WTF-124] thing", rendered.PlainContent())
+}
+
+func Test_TextWidget_Rendered(t *testing.T) {
+	txtWid := testTextWidget()
+	go func() {
+		for range txtWid.RedrawChan {
+		}
+	}()
+
+	txtWid.Redraw(func() (string, string, bool) {
+		return "title", "content", true
+	})
+
+	assert.Equal(t, RenderedContent{Title: "title", Content: "content", Wrap: true}, txtWid.Rendered())
+}
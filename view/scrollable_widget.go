@@ -3,6 +3,7 @@ package view
 import (
 	"strconv"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
 )
@@ -29,8 +30,18 @@ func NewScrollableWidget(tviewApp *tview.Application, redrawChan chan bool, page
 
 /* -------------------- Exported Functions -------------------- */
 
+// SetRenderFunction sets the function that displays the widget. It's called whenever the
+// selection changes, including when a search moves to a match in another row, so it must
+// display the data the widget already has rather than fetch it
 func (widget *ScrollableWidget) SetRenderFunction(displayFunc func()) {
 	widget.RenderFunction = displayFunc
+
+	widget.search.selectRow = func(row int) {
+		if row >= 0 && row < widget.maxItems && row != widget.Selected {
+			widget.Selected = row
+			widget.RenderFunction()
+		}
+	}
 }
 
 func (widget *ScrollableWidget) SetItemCount(items int) {
@@ -40,6 +51,48 @@ func (widget *ScrollableWidget) SetItemCount(items int) {
 	}
 }
 
+// HandleMouse responds to mouse events on the widget. A click selects the row under the
+// cursor, and the wheel moves the selection, stopping at the first and last rows. It
+// returns FALSE for events it doesn't handle, such as the wheel over a widget without
+// rows, which scrolls the text instead
+func (widget *ScrollableWidget) HandleMouse(action tview.MouseAction, event *tcell.EventMouse) bool {
+	if widget.maxItems == 0 || widget.RenderFunction == nil {
+		return false
+	}
+
+	switch action {
+	case tview.MouseLeftClick:
+		// Rows are rendered in regions named by their index. The view highlights the
+		// region that was clicked on
+		widget.View.MouseHandler()(action, event, func(tview.Primitive) {})
+
+		highlights := widget.View.GetHighlights()
+		if len(highlights) == 0 {
+			return false
+		}
+
+		idx, err := strconv.Atoi(highlights[0])
+		if err != nil || idx < 0 || idx >= widget.maxItems {
+			return false
+		}
+
+		widget.Selected = idx
+	case tview.MouseScrollUp:
+		if widget.Selected > 0 {
+			widget.Selected--
+		}
+	case tview.MouseScrollDown:
+		if widget.Selected < widget.maxItems-1 {
+			widget.Selected++
+		}
+	default:
+		return false
+	}
+
+	widget.RenderFunction()
+	return true
+}
+
 func (widget *ScrollableWidget) GetSelected() int {
 	return widget.Selected
 }
@@ -52,10 +105,16 @@ func (widget *ScrollableWidget) RowColor(idx int) string {
 	return widget.CommonSettings().RowColor(idx)
 }
 
+// Next selects the next row, skipping rows that a search filtered out
 func (widget *ScrollableWidget) Next() {
-	widget.Selected++
-	if widget.Selected >= widget.maxItems {
-		widget.Selected = 0
+	for tries := 0; tries < widget.maxItems; tries++ {
+		widget.Selected++
+		if widget.Selected >= widget.maxItems {
+			widget.Selected = 0
+		}
+		if !widget.search.rowHidden(widget.Selected) {
+			break
+		}
 	}
 	if widget.maxItems == 0 {
 		widget.Selected = -1
@@ -63,10 +122,16 @@ func (widget *ScrollableWidget) Next() {
 	widget.RenderFunction()
 }
 
+// Prev selects the previous row, skipping rows that a search filtered out
 func (widget *ScrollableWidget) Prev() {
-	widget.Selected--
-	if widget.Selected < 0 {
-		widget.Selected = widget.maxItems - 1
+	for tries := 0; tries < widget.maxItems; tries++ {
+		widget.Selected--
+		if widget.Selected < 0 {
+			widget.Selected = widget.maxItems - 1
+		}
+		if !widget.search.rowHidden(widget.Selected) {
+			break
+		}
 	}
 	if widget.maxItems == 0 {
 		widget.Selected = -1
@@ -0,0 +1,61 @@
+package view
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+func testScrollableWidget(t *testing.T) (*ScrollableWidget, *int) {
+	widget := NewScrollableWidget(nil, nil, nil, &cfg.Common{Module: cfg.Module{Name: "test"}})
+	renders := 0
+
+	widget.SetItemCount(3)
+	widget.SetRenderFunction(func() {
+		renders++
+
+		content := ""
+		for idx := 0; idx < 3; idx++ {
+			content += fmt.Sprintf("[\"%d\"]row %d[\"\"]\n", idx, idx)
+		}
+
+		widget.View.SetText(content)
+	})
+	widget.RenderFunction()
+
+	// Drawing works out where the rows' regions are onscreen
+	screen := tcell.NewSimulationScreen("")
+	assert.NoError(t, screen.Init())
+
+	widget.View.SetBorder(false)
+	widget.View.SetRect(0, 0, 20, 5)
+	widget.View.Draw(screen)
+
+	return &widget, &renders
+}
+
+func Test_ScrollableWidget_HandleMouse(t *testing.T) {
+	widget, renders := testScrollableWidget(t)
+
+	click := tcell.NewEventMouse(2, 1, tcell.Button1, tcell.ModNone)
+	assert.True(t, widget.HandleMouse(tview.MouseLeftClick, click))
+	assert.Equal(t, 1, widget.Selected)
+
+	wheel := tcell.NewEventMouse(2, 1, tcell.WheelDown, tcell.ModNone)
+	assert.True(t, widget.HandleMouse(tview.MouseScrollDown, wheel))
+	assert.True(t, widget.HandleMouse(tview.MouseScrollDown, wheel))
+	assert.Equal(t, 2, widget.Selected)
+
+	assert.True(t, widget.HandleMouse(tview.MouseScrollUp, wheel))
+	assert.Equal(t, 1, widget.Selected)
+	assert.Equal(t, 5, *renders)
+
+	assert.False(t, widget.HandleMouse(tview.MouseRightClick, click))
+
+	widget.SetItemCount(0)
+	assert.False(t, widget.HandleMouse(tview.MouseScrollDown, wheel))
+}
@@ -0,0 +1,488 @@
+package view
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	searchCurrentColor = "black:orange"
+	searchHelpText     = "Search: Ctrl-F again to filter, n/N to move between matches"
+	searchMatchColor   = "black:yellow"
+)
+
+// These match the tags that tview finds in dynamic text, at the start of the string
+var (
+	colorTagPattern  = regexp.MustCompile(`^\[([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([lbidrus]+|\-)?)?)?\]`)
+	regionTagPattern = regexp.MustCompile(`^\["([a-zA-Z0-9_,;: \-\.]*)"\]`)
+	escapeTagPattern = regexp.MustCompile(`^\[([a-zA-Z0-9_,;: \-\."#]+)\[(\[*)\]`)
+)
+
+// search is a widget's incremental search. While it's being typed, the query is shown in
+// the widget's title and every match in its text is highlighted. Once it's kept, n and N
+// move between the matches. When filtering, only the lines with a match are shown
+type search struct {
+	current int
+	filter  bool
+	query   string
+	typing  bool
+
+	// hiddenRows are the rows that filtering hid, by the index of their region
+	hiddenRows map[int]bool
+	matches    []searchMatch
+
+	// scrollPending is set when the view should scroll to the current match after it's
+	// next drawn
+	scrollPending bool
+
+	// drawn is set once the widget has been drawn, and its content can be drawn again
+	// with the search when the query changes
+	drawn bool
+	// redraw draws the widget's last content again
+	redraw func()
+	// selectRow selects the row the current match is in, in widgets that have rows, and
+	// draws them again if it changed
+	selectRow func(row int)
+	// filterRows filters the rows of widgets that do it themselves, i.e.: tables, by the
+	// query and draws them again. It returns FALSE if the rows didn't change
+	filterRows func(query string) bool
+}
+
+// searchMatch is where a match is in the widget's text
+type searchMatch struct {
+	line   int
+	region string
+}
+
+// searchToken is a piece of the widget's text: a tag, which isn't displayed, or the
+// display text of a character or an escaped tag
+type searchToken struct {
+	display []rune
+	raw     string
+	style   []string
+	region  *string
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// InitializeSearchKeyboardControl assigns the function that starts a search to Ctrl-F
+func (widget *TextWidget) InitializeSearchKeyboardControl() {
+	widget.SetKeyboardKey(tcell.KeyCtrlF, widget.StartSearch, searchHelpText)
+}
+
+// Searching returns TRUE if there's a search query, being typed or kept
+func (widget *TextWidget) Searching() bool {
+	return widget.search.typing || widget.search.query != ""
+}
+
+// SearchQuery returns the search query, and whether it's filtering the text
+func (widget *TextWidget) SearchQuery() (string, bool) {
+	return widget.search.query, widget.search.filter
+}
+
+// SetSearch searches for the query, and shows only the lines that match it if filter is
+// set. An empty query ends the search
+func (widget *TextWidget) SetSearch(query string, filter bool) {
+	widget.search.query = query
+	widget.search.filter = filter
+	widget.search.typing = false
+	widget.search.current = 0
+	widget.search.scrollPending = query != ""
+
+	widget.search.render()
+}
+
+// StartSearch starts typing a new search query
+func (widget *TextWidget) StartSearch() {
+	widget.search.typing = true
+	widget.search.query = ""
+	widget.search.filter = false
+	widget.search.current = 0
+
+	widget.search.render()
+}
+
+// TakingInput returns TRUE while a search query is being typed, when every key press
+// belongs to the widget
+func (widget *TextWidget) TakingInput() bool {
+	return widget.search.typing
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// handleKey handles the key presses of a search. It returns TRUE if the key was handled,
+// and FALSE if it's for the widget's own keyboard commands
+func (search *search) handleKey(event *tcell.EventKey) bool {
+	if search.typing {
+		search.handleTyping(event)
+		return true
+	}
+
+	if search.query == "" || event.Key() != tcell.KeyRune {
+		return false
+	}
+
+	switch event.Rune() {
+	case 'n':
+		search.jump(1)
+	case 'N':
+		search.jump(-1)
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (search *search) handleTyping(event *tcell.EventKey) {
+	switch event.Key() {
+	case tcell.KeyRune:
+		search.query += string(event.Rune())
+		search.current = 0
+		search.scrollPending = true
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if search.query == "" {
+			search.typing = false
+		} else {
+			_, size := utf8.DecodeLastRuneInString(search.query)
+			search.query = search.query[:len(search.query)-size]
+			search.current = 0
+		}
+	case tcell.KeyCtrlF:
+		search.filter = !search.filter
+	case tcell.KeyEnter:
+		search.typing = false
+	case tcell.KeyEsc:
+		search.typing = false
+		search.query = ""
+		search.filter = false
+	default:
+		return
+	}
+
+	search.render()
+}
+
+// jump moves the current match forwards or backwards, wrapping around at the ends
+func (search *search) jump(step int) {
+	if len(search.matches) == 0 {
+		return
+	}
+
+	search.current = (search.current + step + len(search.matches)) % len(search.matches)
+	search.scrollPending = true
+
+	search.render()
+}
+
+// render draws the widget's last content with the search. In widgets with rows, the row
+// of the current match is selected once drawing has found it, which draws it again
+func (search *search) render() {
+	if search.redraw == nil {
+		return
+	}
+
+	if search.filterRows == nil || !search.filterRows(search.rowFilter()) {
+		search.redraw()
+	}
+
+	if search.selectRow == nil || !search.scrollPending {
+		return
+	}
+	search.scrollPending = false
+
+	if search.current < len(search.matches) {
+		if row, err := strconv.Atoi(search.matches[search.current].region); err == nil {
+			search.selectRow(row)
+		}
+	}
+}
+
+// rowFilter returns the query that rows are filtered by, "" if they aren't
+func (search *search) rowFilter() string {
+	if !search.filter {
+		return ""
+	}
+
+	return search.query
+}
+
+// rowHidden returns TRUE if filtering hid the row
+func (search *search) rowHidden(row int) bool {
+	return search.filter && search.hiddenRows[row]
+}
+
+// title returns the widget's title with the search added to it
+func (search *search) title(title string) string {
+	if !search.typing && search.query == "" {
+		return title
+	}
+
+	prefix := "/"
+	if search.filter {
+		prefix = "filter /"
+	}
+
+	title = strings.TrimLeft(fmt.Sprintf("%s %s%s", title, prefix, tview.Escape(search.query)), " ")
+
+	switch {
+	case search.typing:
+		title += "_"
+	case len(search.matches) == 0:
+		title += " (no matches)"
+	default:
+		title += fmt.Sprintf(" (%d/%d)", search.current+1, len(search.matches))
+	}
+
+	return title
+}
+
+// apply highlights the query's matches in the text, and drops the lines without one when
+// filtering. Lines that aren't in a row are kept, so that headers stay in place in
+// widgets that have rows
+func (search *search) apply(content string) string {
+	search.matches = nil
+	search.hiddenRows = map[int]bool{}
+
+	query := []rune(strings.ToLower(search.query))
+	if len(query) == 0 {
+		return content
+	}
+
+	tokens := tokenize(content)
+
+	hasRegions := false
+	for _, token := range tokens {
+		if token.region != nil {
+			hasRegions = true
+			break
+		}
+	}
+
+	out := &strings.Builder{}
+	lineNum := 0
+	region := ""
+
+	// The style in effect at each token, to set it back after a match
+	style := [3]string{}
+
+	line := []searchToken{}
+	lineStyles := [][3]string{}
+	lineText := []rune{}
+	// lineTextRegions is the row that each rune of the line's text is in
+	lineTextRegions := []string{}
+	lineRegions := map[string]bool{}
+
+	flush := func() {
+		matches := findMatches(lineText, query)
+
+		if search.filter && len(matches) == 0 && (!hasRegions || len(lineRegions) > 0) {
+			for id := range lineRegions {
+				if row, err := strconv.Atoi(id); err == nil {
+					search.hiddenRows[row] = true
+				}
+			}
+		} else {
+			if lineNum > 0 {
+				out.WriteString("\n")
+			}
+
+			first := len(search.matches)
+			for _, start := range matches {
+				search.matches = append(search.matches, searchMatch{line: lineNum, region: lineTextRegions[start]})
+			}
+
+			out.WriteString(search.highlight(line, lineStyles, matches, first, len(query)))
+			lineNum++
+		}
+
+		line = line[:0]
+		lineStyles = lineStyles[:0]
+		lineText = lineText[:0]
+		lineTextRegions = lineTextRegions[:0]
+		lineRegions = map[string]bool{}
+		if region != "" {
+			lineRegions[region] = true
+		}
+	}
+
+	for _, token := range tokens {
+		if token.raw == "\n" {
+			flush()
+			continue
+		}
+
+		switch {
+		case token.style != nil:
+			style = overlayTag(style, token.style)
+		case token.region != nil:
+			// Rows start with a region named by their index, which may end straight away.
+			// Everything up to the next row is in the row
+			if *token.region != "" {
+				region = *token.region
+				lineRegions[region] = true
+			}
+		default:
+			for _, r := range token.display {
+				lineText = append(lineText, unicode.ToLower(r))
+				lineTextRegions = append(lineTextRegions, region)
+			}
+		}
+
+		line = append(line, token)
+		lineStyles = append(lineStyles, style)
+	}
+	flush()
+
+	// Rows with a match on one of their lines aren't hidden, even if others are
+	for _, match := range search.matches {
+		if row, err := strconv.Atoi(match.region); err == nil {
+			delete(search.hiddenRows, row)
+		}
+	}
+
+	if search.current >= len(search.matches) {
+		search.current = 0
+	}
+
+	return out.String()
+}
+
+// highlight returns the line's tokens with the matches, which start at the rune offsets,
+// highlighted. first is the index of the line's first match among all of them. The style
+// in effect is set back after each match, and set to the highlight again after any tags
+// inside a match
+func (search *search) highlight(tokens []searchToken, styles [][3]string, matches []int, first, length int) string {
+	str := &strings.Builder{}
+	pos := 0
+	matchIdx := 0
+	color := ""
+
+	for idx, token := range tokens {
+		if token.style != nil || token.region != nil {
+			str.WriteString(token.raw)
+			if color != "" && token.style != nil {
+				str.WriteString("[" + color + "]")
+			}
+			continue
+		}
+
+		width := len(token.display)
+
+		if color == "" && matchIdx < len(matches) && pos+width > matches[matchIdx] {
+			color = searchMatchColor
+			if first+matchIdx == search.current {
+				color = searchCurrentColor
+			}
+			str.WriteString("[" + color + "]")
+		}
+
+		str.WriteString(token.raw)
+		pos += width
+
+		if color != "" && pos >= matches[matchIdx]+length {
+			color = ""
+			for matchIdx < len(matches) && matches[matchIdx] < pos {
+				matchIdx++
+			}
+
+			str.WriteString(styleTag(styles[idx]))
+		}
+	}
+
+	return str.String()
+}
+
+// tokenize splits the text into its tags and the characters it displays
+func tokenize(text string) []searchToken {
+	tokens := []searchToken{}
+
+	for idx := 0; idx < len(text); {
+		if text[idx] == '[' {
+			rest := text[idx:]
+
+			if match := colorTagPattern.FindStringSubmatch(rest); match != nil {
+				tokens = append(tokens, searchToken{raw: match[0], style: match})
+				idx += len(match[0])
+				continue
+			}
+
+			if match := regionTagPattern.FindStringSubmatch(rest); match != nil {
+				region := match[1]
+				tokens = append(tokens, searchToken{raw: match[0], region: &region})
+				idx += len(match[0])
+				continue
+			}
+
+			if match := escapeTagPattern.FindStringSubmatch(rest); match != nil {
+				display := []rune("[" + match[1] + match[2] + "]")
+				tokens = append(tokens, searchToken{raw: match[0], display: display})
+				idx += len(match[0])
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(text[idx:])
+		tokens = append(tokens, searchToken{raw: text[idx : idx+size], display: []rune{r}})
+		idx += size
+	}
+
+	return tokens
+}
+
+// findMatches returns the rune offsets of the query's matches in the line
+func findMatches(line, query []rune) []int {
+	matches := []int{}
+
+	for start := 0; start+len(query) <= len(line); {
+		found := true
+		for idx, r := range query {
+			if line[start+idx] != r {
+				found = false
+				break
+			}
+		}
+
+		if found {
+			matches = append(matches, start)
+			start += len(query)
+		} else {
+			start++
+		}
+	}
+
+	return matches
+}
+
+// overlayTag applies a color tag to a style, as tview does
+func overlayTag(style [3]string, tag []string) [3]string {
+	if tag[1] != "" {
+		style[0] = tag[1]
+	}
+	if tag[2] != "" && tag[3] != "" {
+		style[1] = tag[3]
+	}
+	if tag[4] != "" && tag[5] != "" {
+		style[2] = tag[5]
+	}
+
+	return style
+}
+
+// styleTag returns a tag that sets the style, with anything that wasn't set reset
+func styleTag(style [3]string) string {
+	for idx, val := range style {
+		if val == "" {
+			style[idx] = "-"
+		}
+	}
+
+	return fmt.Sprintf("[%s:%s:%s]", style[0], style[1], style[2])
+}
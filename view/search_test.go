@@ -0,0 +1,190 @@
+package view
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+func Test_search_apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		filter   bool
+		content  string
+		expected string
+		matches  int
+	}{
+		{
+			name:     "no query",
+			content:  "[red]alpha\nbeta",
+			expected: "[red]alpha\nbeta",
+		},
+		{
+			name:     "ignores case",
+			query:    "ALP",
+			content:  "alpha\nbeta alpine",
+			expected: "[black:orange]alp[-:-:-]ha\nbeta [black:yellow]alp[-:-:-]ine",
+			matches:  2,
+		},
+		{
+			name:     "sets the style back",
+			query:    "et",
+			content:  "[red::b]beta[white]",
+			expected: "[red::b]b[black:orange]et[red:-:b]a[white]",
+			matches:  1,
+		},
+		{
+			name:     "across tags",
+			query:    "ab",
+			content:  "a[red]b",
+			expected: "[black:orange]a[red][black:orange]b[red:-:-]",
+			matches:  1,
+		},
+		{
+			name:     "escaped tags",
+			query:    "[x]",
+			content:  "a [x[] b",
+			expected: "a [black:orange][x[][-:-:-] b",
+			matches:  1,
+		},
+		{
+			name:     "not in tags",
+			query:    "red",
+			content:  "[red]blue",
+			expected: "[red]blue",
+		},
+		{
+			name:     "filter",
+			query:    "a",
+			filter:   true,
+			content:  "alpha\nbeta\ngamma\nxyz",
+			expected: "[black:orange]a[-:-:-]lph[black:yellow]a[-:-:-]\nbet[black:yellow]a[-:-:-]\ng[black:yellow]a[-:-:-]mm[black:yellow]a[-:-:-]",
+			matches:  5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			search := &search{query: tt.query, filter: tt.filter}
+
+			assert.Equal(t, tt.expected, search.apply(tt.content))
+			assert.Equal(t, tt.matches, len(search.matches))
+		})
+	}
+}
+
+func Test_search_apply_rows(t *testing.T) {
+	content := "header\n"
+	for idx, row := range []string{"alpha", "beta", "gamma"} {
+		content += fmt.Sprintf(`["%d"][""]%s[""]`+"\n", idx, row)
+	}
+
+	search := &search{query: "mm", filter: true}
+
+	assert.Equal(
+		t,
+		"header\n[\"2\"][\"\"]ga[black:orange]mm[-:-:-]a[\"\"]",
+		search.apply(content),
+	)
+	assert.Equal(t, []searchMatch{{line: 1, region: "2"}}, search.matches)
+	assert.True(t, search.rowHidden(0))
+	assert.True(t, search.rowHidden(1))
+	assert.False(t, search.rowHidden(2))
+}
+
+func Test_search_typing(t *testing.T) {
+	widget := NewTextWidget(nil, nil, nil, &cfg.Common{Module: cfg.Module{Name: "test"}})
+	widget.RedrawChan = make(chan bool, 100)
+	widget.Draw(func() (string, string, bool) { return "Test", "one\ntwo\nthree", false })
+
+	press := func(key tcell.Key, r rune) {
+		widget.KeyboardWidget.InputCapture(tcell.NewEventKey(key, r, tcell.ModNone))
+	}
+
+	press(tcell.KeyCtrlF, 0)
+	assert.True(t, widget.TakingInput())
+
+	press(tcell.KeyRune, 'o')
+	assert.Equal(t, " Test /o_ ", widget.View.GetTitle())
+
+	press(tcell.KeyEnter, 0)
+	assert.False(t, widget.TakingInput())
+	assert.Equal(t, " Test /o (1/2) ", widget.View.GetTitle())
+
+	press(tcell.KeyRune, 'n')
+	assert.Equal(t, " Test /o (2/2) ", widget.View.GetTitle())
+	press(tcell.KeyRune, 'n')
+	assert.Equal(t, " Test /o (1/2) ", widget.View.GetTitle())
+	press(tcell.KeyRune, 'N')
+	assert.Equal(t, " Test /o (2/2) ", widget.View.GetTitle())
+
+	press(tcell.KeyCtrlF, 0)
+	press(tcell.KeyRune, 'x')
+	press(tcell.KeyCtrlF, 0)
+	press(tcell.KeyEnter, 0)
+	assert.Equal(t, " Test filter /x (no matches) ", widget.View.GetTitle())
+
+	widget.SetSearch("", false)
+	assert.False(t, widget.Searching())
+	assert.Equal(t, " Test ", widget.View.GetTitle())
+	assert.Equal(t, "one\ntwo\nthree\n", widget.View.GetText(false))
+}
+
+func Test_search_ScrollableWidget(t *testing.T) {
+	widget := NewScrollableWidget(nil, nil, nil, &cfg.Common{Module: cfg.Module{Name: "test"}})
+	widget.RedrawChan = make(chan bool, 100)
+
+	rows := []string{"alpha", "beta", "gamma", "delta"}
+	widget.SetItemCount(len(rows))
+	renders := 0
+	widget.SetRenderFunction(func() {
+		renders++
+		widget.Redraw(func() (string, string, bool) {
+			content := ""
+			for idx, row := range rows {
+				content += fmt.Sprintf(`["%d"][""]%s[""]`+"\n", idx, row)
+			}
+			return "Test", content, false
+		})
+	})
+	widget.RenderFunction()
+
+	// Typing a query redraws what's displayed, and the module only renders again when
+	// the selection moves
+	widget.StartSearch()
+	widget.KeyboardWidget.InputCapture(tcell.NewEventKey(tcell.KeyRune, 'z', tcell.ModNone))
+	assert.Equal(t, 1, renders)
+
+	widget.SetSearch("ta", false)
+	assert.Equal(t, 1, widget.Selected)
+
+	widget.search.jump(1)
+	assert.Equal(t, 3, widget.Selected)
+
+	// Rows that a search filters out are skipped
+	widget.SetSearch("ta", true)
+	widget.Next()
+	assert.Equal(t, 3, widget.Selected)
+	widget.Next()
+	assert.Equal(t, 1, widget.Selected)
+	widget.Prev()
+	assert.Equal(t, 3, widget.Selected)
+}
+
+func Test_search_keyOverride(t *testing.T) {
+	widget := testTextWidget()
+
+	called := false
+	widget.SetKeyboardKey(tcell.KeyCtrlF, func() { called = true }, "Make item first")
+
+	widget.KeyboardWidget.InputCapture(tcell.NewEventKey(tcell.KeyCtrlF, 0, tcell.ModNone))
+	assert.True(t, called)
+	assert.False(t, widget.TakingInput())
+
+	assert.Contains(t, widget.HelpText(), "Make item first")
+	assert.NotContains(t, widget.HelpText(), searchHelpText)
+}
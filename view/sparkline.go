@@ -0,0 +1,174 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sparkChars are the blocks a sparkline is drawn with, from the lowest to the highest
+var sparkChars = []rune("▁▂▃▄▅▆▇█")
+
+// brailleDots are the bits of the dots in a braille character, by their column and by
+// their row from the top
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+const brailleBlank = 0x2800
+
+// Sparkline returns the most recent values, at most width of them, as a line of block
+// characters scaled between the lowest and the highest of them
+func Sparkline(values []float64, width int) string {
+	values = lastValues(values, width)
+	if len(values) == 0 {
+		return ""
+	}
+
+	min, max := valueRange(values)
+
+	line := make([]rune, len(values))
+	for idx, val := range values {
+		line[idx] = sparkChars[scale(val, min, max, len(sparkChars))]
+	}
+
+	return string(line)
+}
+
+// BrailleChart returns the most recent values as a line chart of braille characters,
+// width characters wide and height lines high, scaled between the lowest and the
+// highest of them. Each character shows two values, with four dots of height each, so
+// the chart is twice as detailed as a sparkline of the same width. The newest values are
+// on the right
+func BrailleChart(values []float64, width, height int) string {
+	if width < 1 || height < 1 {
+		return ""
+	}
+
+	values = lastValues(values, width*2)
+	if len(values) == 0 {
+		return ""
+	}
+
+	min, max := valueRange(values)
+	rows := height * 4
+
+	// The dots are drawn from the bottom up, right-aligned
+	dots := make([][]bool, rows)
+	for row := range dots {
+		dots[row] = make([]bool, width*2)
+	}
+
+	offset := width*2 - len(values)
+	prev := -1
+	for idx, val := range values {
+		y := scale(val, min, max, rows)
+		col := offset + idx
+
+		// Join the point to the one before it, so the chart is a line rather than dots
+		from, to := y, y
+		if prev >= 0 {
+			from, to = minInt(prev, y), maxInt(prev, y)
+		}
+		for row := from; row <= to; row++ {
+			dots[row][col] = true
+		}
+
+		prev = y
+	}
+
+	lines := make([]string, height)
+	for line := 0; line < height; line++ {
+		chars := make([]rune, width)
+
+		for char := 0; char < width; char++ {
+			r := rune(brailleBlank)
+			for dotCol := 0; dotCol < 2; dotCol++ {
+				for dotRow := 0; dotRow < 4; dotRow++ {
+					// Rows in the chart count up from the bottom, rows in the character down
+					// from the top
+					row := rows - 1 - (line*4 + dotRow)
+					if dots[row][char*2+dotCol] {
+						r |= brailleDots[dotCol][dotRow]
+					}
+				}
+			}
+
+			chars[char] = r
+		}
+
+		lines[line] = string(chars)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Labels returns the lowest, average and highest values, formatted by the function, as
+// labels to show alongside a chart. Without a function, values are shown with %.2f
+func (stats HistoryStats) Labels(format func(float64) string) string {
+	if format == nil {
+		format = func(val float64) string { return fmt.Sprintf("%.2f", val) }
+	}
+
+	return fmt.Sprintf("min %s avg %s max %s", format(stats.Min), format(stats.Avg), format(stats.Max))
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func lastValues(values []float64, count int) []float64 {
+	if count < 1 {
+		return nil
+	}
+
+	if len(values) > count {
+		return values[len(values)-count:]
+	}
+
+	return values
+}
+
+func valueRange(values []float64) (float64, float64) {
+	min, max := values[0], values[0]
+	for _, val := range values {
+		if val < min {
+			min = val
+		}
+		if val > max {
+			max = val
+		}
+	}
+
+	return min, max
+}
+
+// scale returns which of the steps, from 0, the value falls in between min and max. When
+// all the values are the same they're on the lowest step
+func scale(val, min, max float64, steps int) int {
+	if max <= min {
+		return 0
+	}
+
+	step := int((val - min) / (max - min) * float64(steps))
+	if step >= steps {
+		step = steps - 1
+	}
+	if step < 0 {
+		step = 0
+	}
+
+	return step
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
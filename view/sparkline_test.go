@@ -0,0 +1,52 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Sparkline(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		width    int
+		expected string
+	}{
+		{name: "empty", values: []float64{}, width: 10, expected: ""},
+		{name: "no width", values: []float64{1, 2}, width: 0, expected: ""},
+		{name: "scaled", values: []float64{0, 1, 2, 3, 4, 5, 6, 7}, width: 10, expected: "▁▂▃▄▅▆▇█"},
+		{name: "most recent", values: []float64{100, 0, 7}, width: 2, expected: "▁█"},
+		{name: "flat", values: []float64{3, 3, 3}, width: 10, expected: "▁▁▁"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Sparkline(tt.values, tt.width))
+		})
+	}
+}
+
+func Test_BrailleChart(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		width    int
+		height   int
+		expected string
+	}{
+		{name: "empty", values: []float64{}, width: 2, height: 1, expected: ""},
+		{name: "no size", values: []float64{1}, width: 0, height: 1, expected: ""},
+		// A rising line: the left column's bottom dot, the right column's dots rising to
+		// the top
+		{name: "rising", values: []float64{0, 3}, width: 1, height: 1, expected: "⣸"},
+		// Values are right-aligned, and join the one before them
+		{name: "right-aligned", values: []float64{0, 7}, width: 2, height: 2, expected: "⠀⢸\n⠀⣸"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, BrailleChart(tt.values, tt.width, tt.height))
+		})
+	}
+}
@@ -0,0 +1,446 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rivo/tview"
+)
+
+// Alignment is how a column's values are lined up within its width
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+	AlignCenter
+)
+
+// columnGap is the space between two columns
+const columnGap = "  "
+
+// Column describes one column of a table of items of type T. Its values are sorted by
+// their type: numbers numerically, times chronologically and everything else as
+// case-insensitive text
+type Column[T any] struct {
+	// Key identifies the column when sorting by it
+	Key string
+	// Title is shown in the header row. The key is used if it's empty
+	Title string
+
+	// Width fixes the width of the column. With no width, the column is as wide as its
+	// widest value, up to MaxWidth if that's set. Values that don't fit are truncated
+	Width    int
+	MaxWidth int
+	Align    Alignment
+
+	// Value returns the item's value in this column
+	Value func(item T) interface{}
+	// Format turns a value into the text displayed. Without it, times are formatted with
+	// the table's TimeFormat and everything else with fmt.Sprint
+	Format func(val interface{}) string
+	// Color returns the color to display the item's value in, or "" to use the row's
+	Color func(item T) string
+}
+
+// Table is a list of items displayed as rows of columns, which can be sorted by any of its
+// columns and filtered down to the rows whose text contains a string
+type Table[T any] struct {
+	// TimeFormat is the layout that time values are displayed with
+	TimeFormat string
+
+	columns []Column[T]
+	filter  string
+	items   []T
+	// rows are the indexes into items of the rows displayed, filtered and sorted
+	rows     []int
+	sortDesc bool
+	sortKey  string
+}
+
+// NewTable creates and returns a table with the given columns
+func NewTable[T any](columns ...Column[T]) *Table[T] {
+	return &Table[T]{
+		TimeFormat: time.RFC822,
+		columns:    columns,
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Columns returns the table's columns
+func (table *Table[T]) Columns() []Column[T] {
+	return table.columns
+}
+
+// SetColumns replaces the table's columns. If the sort column is gone, the rows are left
+// in the order of their items
+func (table *Table[T]) SetColumns(columns ...Column[T]) {
+	table.columns = columns
+	if _, ok := table.column(table.sortKey); !ok {
+		table.sortKey = ""
+		table.sortDesc = false
+	}
+	table.update()
+}
+
+// SetItems replaces the items in the table
+func (table *Table[T]) SetItems(items []T) {
+	table.items = items
+	table.update()
+}
+
+// Len returns the number of rows displayed, after filtering
+func (table *Table[T]) Len() int {
+	return len(table.rows)
+}
+
+// Item returns the item displayed in the given row
+func (table *Table[T]) Item(row int) (T, bool) {
+	if row < 0 || row >= len(table.rows) {
+		var none T
+		return none, false
+	}
+
+	return table.items[table.rows[row]], true
+}
+
+// Items returns the items displayed, in the order of their rows
+func (table *Table[T]) Items() []T {
+	items := make([]T, len(table.rows))
+	for row, idx := range table.rows {
+		items[row] = table.items[idx]
+	}
+
+	return items
+}
+
+// Filter returns the text that rows are filtered by
+func (table *Table[T]) Filter() string {
+	return table.filter
+}
+
+// SetFilter displays only the rows with a value that contains the text, ignoring case.
+// An empty filter displays every row
+func (table *Table[T]) SetFilter(text string) {
+	table.filter = text
+	table.update()
+}
+
+// SortKey returns the key of the column the rows are sorted by, "" if they're in the
+// order of their items, and whether they're sorted in descending order
+func (table *Table[T]) SortKey() (string, bool) {
+	return table.sortKey, table.sortDesc
+}
+
+// SortBy sorts the rows by the column with the key. It returns FALSE, and leaves the order
+// as it is, if there's no such column. An empty key puts the rows back in the order of
+// their items
+func (table *Table[T]) SortBy(key string, descending bool) bool {
+	if key != "" {
+		if _, ok := table.column(key); !ok {
+			return false
+		}
+	}
+
+	table.sortKey = key
+	table.sortDesc = descending && key != ""
+	table.update()
+
+	return true
+}
+
+// NextSortColumn sorts the rows by the column after the current sort column, in
+// ascending order. After the last column come the items in their own order
+func (table *Table[T]) NextSortColumn() {
+	next := 0
+	if table.sortKey != "" {
+		for idx, col := range table.columns {
+			if col.Key == table.sortKey {
+				next = idx + 1
+			}
+		}
+	}
+
+	if next >= len(table.columns) {
+		table.SortBy("", false)
+		return
+	}
+
+	table.SortBy(table.columns[next].Key, false)
+}
+
+// ReverseSort switches the rows between ascending and descending order
+func (table *Table[T]) ReverseSort() {
+	if table.sortKey == "" {
+		return
+	}
+
+	table.SortBy(table.sortKey, !table.sortDesc)
+}
+
+// Header returns the header row: the columns' titles, with an arrow on the sort column
+func (table *Table[T]) Header() string {
+	widths := table.widths()
+	cells := make([]string, len(table.columns))
+
+	for idx, col := range table.columns {
+		title := col.Title
+		if title == "" {
+			title = col.Key
+		}
+
+		if col.Key == table.sortKey {
+			arrow := "▲"
+			if table.sortDesc {
+				arrow = "▼"
+			}
+			title = truncate(title, widths[idx]-1) + arrow
+		}
+
+		cells[idx] = tview.Escape(pad(title, widths[idx], col.Align))
+	}
+
+	return strings.TrimRight(strings.Join(cells, columnGap), " ")
+}
+
+// Row returns the text of the given row, with each value colored by its column's Color
+// and in rowColor otherwise, and the width of the text as displayed
+func (table *Table[T]) Row(row int, rowColor string) (string, int) {
+	item, ok := table.Item(row)
+	if !ok {
+		return "", 0
+	}
+
+	widths := table.widths()
+	cells := make([]string, len(table.columns))
+	width := 0
+
+	for idx, col := range table.columns {
+		cell := tview.Escape(pad(table.text(col, item), widths[idx], col.Align))
+
+		if col.Color != nil {
+			if color := col.Color(item); color != "" {
+				cell = fmt.Sprintf("[%s]%s[%s]", color, cell, rowColor)
+			}
+		}
+
+		cells[idx] = cell
+		width += widths[idx]
+	}
+
+	width += len(columnGap) * (len(cells) - 1)
+
+	return fmt.Sprintf("[%s]%s", rowColor, strings.Join(cells, columnGap)), width
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (table *Table[T]) column(key string) (Column[T], bool) {
+	for _, col := range table.columns {
+		if col.Key == key {
+			return col, true
+		}
+	}
+
+	return Column[T]{}, false
+}
+
+// update works out which rows are displayed, and in what order
+func (table *Table[T]) update() {
+	table.rows = table.rows[:0]
+
+	filter := strings.ToLower(table.filter)
+	for idx, item := range table.items {
+		if filter == "" || table.matches(item, filter) {
+			table.rows = append(table.rows, idx)
+		}
+	}
+
+	col, ok := table.column(table.sortKey)
+	if !ok || col.Value == nil {
+		return
+	}
+
+	sort.SliceStable(table.rows, func(i, j int) bool {
+		a := col.Value(table.items[table.rows[i]])
+		b := col.Value(table.items[table.rows[j]])
+
+		if table.sortDesc {
+			return compareValues(b, a) < 0
+		}
+		return compareValues(a, b) < 0
+	})
+}
+
+func (table *Table[T]) matches(item T, filter string) bool {
+	for _, col := range table.columns {
+		if strings.Contains(strings.ToLower(table.text(col, item)), filter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// text returns the item's value in the column as it's displayed
+func (table *Table[T]) text(col Column[T], item T) string {
+	if col.Value == nil {
+		return ""
+	}
+
+	val := col.Value(item)
+
+	if col.Format != nil {
+		return col.Format(val)
+	}
+
+	switch typed := val.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		if typed.IsZero() {
+			return ""
+		}
+		return typed.Format(table.TimeFormat)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// widths returns the width of each column, fitting the rows that are displayed
+func (table *Table[T]) widths() []int {
+	widths := make([]int, len(table.columns))
+
+	for idx, col := range table.columns {
+		if col.Width > 0 {
+			widths[idx] = col.Width
+			continue
+		}
+
+		title := col.Title
+		if title == "" {
+			title = col.Key
+		}
+		width := utf8.RuneCountInString(title)
+		if col.Key == table.sortKey {
+			width++
+		}
+
+		for _, row := range table.rows {
+			if w := utf8.RuneCountInString(table.text(col, table.items[row])); w > width {
+				width = w
+			}
+		}
+
+		if col.MaxWidth > 0 && width > col.MaxWidth {
+			width = col.MaxWidth
+		}
+
+		widths[idx] = width
+	}
+
+	return widths
+}
+
+// pad truncates or pads the text to the width, lined up as the alignment says
+func pad(text string, width int, align Alignment) string {
+	count := utf8.RuneCountInString(text)
+	if count > width {
+		return truncate(text, width)
+	}
+
+	space := width - count
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", space) + text
+	case AlignCenter:
+		return strings.Repeat(" ", space/2) + text + strings.Repeat(" ", space-space/2)
+	default:
+		return text + strings.Repeat(" ", space)
+	}
+}
+
+// truncate cuts the text down to the width in runes, ending it with an ellipsis if it's
+// cut
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	switch {
+	case width < 1:
+		return ""
+	case len(runes) <= width:
+		return text
+	case width == 1:
+		return string(runes[:1])
+	default:
+		return string(runes[:width-1]) + "…"
+	}
+}
+
+// compareValues returns a negative number if a sorts before b, a positive one if it sorts
+// after it, and 0 if they sort the same
+func compareValues(a, b interface{}) int {
+	if numA, ok := number(a); ok {
+		if numB, ok := number(b); ok {
+			switch {
+			case numA < numB:
+				return -1
+			case numA > numB:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	if timeA, ok := a.(time.Time); ok {
+		if timeB, ok := b.(time.Time); ok {
+			switch {
+			case timeA.Before(timeB):
+				return -1
+			case timeA.After(timeB):
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	return strings.Compare(strings.ToLower(fmt.Sprint(a)), strings.ToLower(fmt.Sprint(b)))
+}
+
+func number(val interface{}) (float64, bool) {
+	switch typed := val.(type) {
+	case int:
+		return float64(typed), true
+	case int8:
+		return float64(typed), true
+	case int16:
+		return float64(typed), true
+	case int32:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	case uint:
+		return float64(typed), true
+	case uint8:
+		return float64(typed), true
+	case uint16:
+		return float64(typed), true
+	case uint32:
+		return float64(typed), true
+	case uint64:
+		return float64(typed), true
+	case float32:
+		return float64(typed), true
+	case float64:
+		return typed, true
+	case time.Duration:
+		return float64(typed), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,209 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+type testRow struct {
+	name    string
+	size    int
+	updated time.Time
+}
+
+var testRows = []testRow{
+	{name: "beta", size: 10, updated: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+	{name: "Alpha", size: 9, updated: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{name: "gamma", size: 100, updated: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)},
+}
+
+func testTable() *Table[testRow] {
+	table := NewTable(
+		Column[testRow]{Key: "name", Title: "Name", Value: func(row testRow) interface{} { return row.name }},
+		Column[testRow]{Key: "size", Title: "Size", Align: AlignRight, Value: func(row testRow) interface{} { return row.size }},
+		Column[testRow]{Key: "updated", Value: func(row testRow) interface{} { return row.updated }},
+	)
+	table.TimeFormat = "Jan"
+	table.SetItems(testRows)
+
+	return table
+}
+
+func names(table *Table[testRow]) []string {
+	names := []string{}
+	for _, row := range table.Items() {
+		names = append(names, row.name)
+	}
+	return names
+}
+
+func Test_Table_SortBy(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		descending bool
+		ok         bool
+		expected   []string
+	}{
+		{name: "unsorted", key: "", expected: []string{"beta", "Alpha", "gamma"}, ok: true},
+		{name: "text ignores case", key: "name", expected: []string{"Alpha", "beta", "gamma"}, ok: true},
+		{name: "numbers", key: "size", expected: []string{"Alpha", "beta", "gamma"}, ok: true},
+		{name: "numbers descending", key: "size", descending: true, expected: []string{"gamma", "beta", "Alpha"}, ok: true},
+		{name: "times", key: "updated", expected: []string{"Alpha", "gamma", "beta"}, ok: true},
+		{name: "unknown column", key: "color", expected: []string{"beta", "Alpha", "gamma"}, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := testTable()
+
+			assert.Equal(t, tt.ok, table.SortBy(tt.key, tt.descending))
+			assert.Equal(t, tt.expected, names(table))
+		})
+	}
+}
+
+func Test_Table_NextSortColumn(t *testing.T) {
+	table := testTable()
+
+	keys := []string{}
+	for idx := 0; idx < 4; idx++ {
+		table.NextSortColumn()
+		key, _ := table.SortKey()
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []string{"name", "size", "updated", ""}, keys)
+
+	table.SortBy("size", false)
+	table.ReverseSort()
+	key, descending := table.SortKey()
+	assert.Equal(t, "size", key)
+	assert.True(t, descending)
+}
+
+func Test_Table_SetFilter(t *testing.T) {
+	table := testTable()
+
+	table.SetFilter("ALP")
+	assert.Equal(t, []string{"Alpha"}, names(table))
+
+	table.SetFilter("10")
+	assert.Equal(t, []string{"beta", "gamma"}, names(table))
+
+	table.SetFilter("feb")
+	assert.Equal(t, []string{"gamma"}, names(table))
+
+	table.SetFilter("")
+	assert.Equal(t, 3, table.Len())
+}
+
+func Test_Table_Render(t *testing.T) {
+	table := testTable()
+	table.SortBy("size", true)
+
+	assert.Equal(t, "Name   Size▼  updated", table.Header())
+
+	row, width := table.Row(0, "white")
+	assert.Equal(t, "[white]gamma    100  Feb    ", row)
+	assert.Equal(t, 21, width)
+
+	row, _ = table.Row(3, "white")
+	assert.Equal(t, "", row)
+}
+
+func Test_Table_Widths(t *testing.T) {
+	table := NewTable(
+		Column[string]{Key: "fixed", Width: 4, Value: func(row string) interface{} { return row }},
+		Column[string]{Key: "max", MaxWidth: 5, Align: AlignCenter, Value: func(row string) interface{} { return row }},
+		Column[string]{Key: "colored", Value: func(row string) interface{} { return "[x]" }, Color: func(row string) string { return "red" }},
+	)
+	table.SetItems([]string{"abcdefgh"})
+
+	row, width := table.Row(0, "white")
+	assert.Equal(t, "[white]abc…  abcd…  [red][x[]    [white]", row)
+	assert.Equal(t, 20, width)
+}
+
+func Test_TableWidget_Selection(t *testing.T) {
+	widget := NewTableWidget(nil, nil, nil, &cfg.Common{Module: cfg.Module{Name: "test"}},
+		Column[testRow]{Key: "name", Value: func(row testRow) interface{} { return row.name }},
+		Column[testRow]{Key: "size", Value: func(row testRow) interface{} { return row.size }},
+	)
+	renders := 0
+	widget.SetRenderFunction(func() { renders++ })
+
+	widget.SetItems(testRows)
+	widget.Selected = 1
+
+	selected, ok := widget.SelectedItem()
+	assert.True(t, ok)
+	assert.Equal(t, "Alpha", selected.name)
+
+	// The selection follows the item to its new row
+	widget.SortBy("size", true)
+	assert.Equal(t, 2, widget.Selected)
+
+	widget.SetFilter("a")
+	selected, _ = widget.SelectedItem()
+	assert.Equal(t, "Alpha", selected.name)
+
+	// An item that's filtered out can't be selected
+	widget.SetFilter("gamma")
+	assert.Equal(t, -1, widget.Selected)
+	_, ok = widget.SelectedItem()
+	assert.False(t, ok)
+
+	// Fewer items leave the selection on the last row
+	widget.SetFilter("")
+	widget.Selected = 2
+	widget.SetItems(testRows[:1])
+	assert.Equal(t, 0, widget.Selected)
+
+	assert.Equal(t, 4, renders)
+}
+
+func Test_pad(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		align    Alignment
+		expected string
+	}{
+		{name: "left", text: "ab", width: 4, align: AlignLeft, expected: "ab  "},
+		{name: "right", text: "ab", width: 4, align: AlignRight, expected: "  ab"},
+		{name: "center", text: "ab", width: 5, align: AlignCenter, expected: " ab  "},
+		{name: "truncated", text: "abcdef", width: 3, align: AlignLeft, expected: "ab…"},
+		{name: "truncated by runes", text: "ééééé", width: 3, align: AlignLeft, expected: "éé…"},
+		{name: "one rune", text: "ééééé", width: 1, align: AlignLeft, expected: "é"},
+		{name: "no width", text: "ééééé", width: 0, align: AlignLeft, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, pad(tt.text, tt.width, tt.align))
+		})
+	}
+}
+
+func Test_TableWidget_SearchFilter(t *testing.T) {
+	widget := NewTableWidget(nil, nil, nil, &cfg.Common{Module: cfg.Module{Name: "test"}},
+		Column[testRow]{Key: "name", Value: func(row testRow) interface{} { return row.name }},
+	)
+	widget.SetRenderFunction(func() {})
+	widget.SetItems(testRows)
+
+	widget.SetSearch("ET", false)
+	assert.Equal(t, "", widget.Table.Filter())
+	assert.Equal(t, 3, widget.Table.Len())
+
+	widget.SetSearch("ET", true)
+	assert.Equal(t, "ET", widget.Table.Filter())
+	assert.Equal(t, []string{"beta"}, names(widget.Table))
+
+	widget.SetSearch("", false)
+	assert.Equal(t, 3, widget.Table.Len())
+}
@@ -0,0 +1,133 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/utils"
+)
+
+// TableWidget is a scrollable widget that displays its items in a table. Its Selected
+// index is the row selected in the table, and it stays on the same item when the table is
+// sorted or filtered
+type TableWidget[T any] struct {
+	ScrollableWidget
+
+	Table *Table[T]
+}
+
+// NewTableWidget creates and returns a widget that displays a table with the columns
+func NewTableWidget[T any](tviewApp *tview.Application, redrawChan chan bool, pages *tview.Pages, commonSettings *cfg.Common, columns ...Column[T]) TableWidget[T] {
+	return TableWidget[T]{
+		ScrollableWidget: NewScrollableWidget(tviewApp, redrawChan, pages, commonSettings),
+
+		Table: NewTable(columns...),
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// InitializeTableKeyboardControls sets up the keys that sort the table
+func (widget *TableWidget[T]) InitializeTableKeyboardControls() {
+	widget.SetKeyboardChar(">", widget.NextSortColumn, "Sort by the next column")
+	widget.SetKeyboardChar("<", widget.ReverseSort, "Reverse the sort order")
+}
+
+// SetRenderFunction sets the function that displays the widget. When a search filters the
+// widget, the table filters its rows by the query, so that the columns fit the rows that
+// are left and the selection stays on its item
+func (widget *TableWidget[T]) SetRenderFunction(displayFunc func()) {
+	widget.ScrollableWidget.SetRenderFunction(displayFunc)
+
+	widget.search.filterRows = func(query string) bool {
+		if query == widget.Table.Filter() {
+			return false
+		}
+
+		widget.SetFilter(query)
+		return true
+	}
+}
+
+// SelectedItem returns the item in the selected row, if a row is selected
+func (widget *TableWidget[T]) SelectedItem() (T, bool) {
+	return widget.Table.Item(widget.Selected)
+}
+
+// SetItems replaces the items in the table. The selection stays on the same row, or the
+// last row if there are fewer of them now
+func (widget *TableWidget[T]) SetItems(items []T) {
+	widget.Table.SetItems(items)
+	widget.updateSelection(widget.Selected)
+}
+
+// SetFilter displays only the rows with a value that contains the text, ignoring case
+func (widget *TableWidget[T]) SetFilter(text string) {
+	widget.keepSelection(func() { widget.Table.SetFilter(text) })
+}
+
+// SortBy sorts the table by the column with the key, in descending order if asked to
+func (widget *TableWidget[T]) SortBy(key string, descending bool) {
+	widget.keepSelection(func() { widget.Table.SortBy(key, descending) })
+}
+
+// NextSortColumn sorts the table by the column after the one it's sorted by
+func (widget *TableWidget[T]) NextSortColumn() {
+	widget.keepSelection(widget.Table.NextSortColumn)
+}
+
+// ReverseSort switches the table between ascending and descending order
+func (widget *TableWidget[T]) ReverseSort() {
+	widget.keepSelection(widget.Table.ReverseSort)
+}
+
+// TableContent returns the table's header and rows, with each row highlightable and
+// colored for the selection
+func (widget *TableWidget[T]) TableContent() string {
+	str := fmt.Sprintf(" [%s::b]%s[-:-:-]\n", widget.CommonSettings().Colors.Subheading, widget.Table.Header())
+
+	for idx := 0; idx < widget.Table.Len(); idx++ {
+		row, width := widget.Table.Row(idx, widget.RowColor(idx))
+		str += utils.HighlightableHelper(widget.View, " "+row, idx, width+1)
+	}
+
+	return str
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// keepSelection changes the table's rows and moves the selection to the row that the
+// selected item is in now. If it's been filtered out, nothing is selected
+func (widget *TableWidget[T]) keepSelection(change func()) {
+	selected := -1
+	if widget.Selected >= 0 && widget.Selected < len(widget.Table.rows) {
+		selected = widget.Table.rows[widget.Selected]
+	}
+
+	change()
+
+	row := -1
+	for idx, itemIdx := range widget.Table.rows {
+		if itemIdx == selected {
+			row = idx
+			break
+		}
+	}
+
+	widget.updateSelection(row)
+
+	if widget.RenderFunction != nil {
+		widget.RenderFunction()
+	}
+}
+
+func (widget *TableWidget[T]) updateSelection(row int) {
+	count := widget.Table.Len()
+	if row >= count {
+		row = count - 1
+	}
+
+	widget.SetItemCount(count)
+	widget.Selected = row
+}
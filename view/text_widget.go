@@ -14,6 +14,8 @@ type TextWidget struct {
 	*KeyboardWidget
 
 	View *tview.TextView
+
+	search *search
 }
 
 // NewTextWidget creates and returns an instance of TextWidget
@@ -21,11 +23,18 @@ func NewTextWidget(tviewApp *tview.Application, redrawChan chan bool, pages *tvi
 	widget := TextWidget{
 		Base:           NewBase(tviewApp, redrawChan, pages, commonSettings),
 		KeyboardWidget: NewKeyboardWidget(commonSettings),
+
+		search: &search{},
 	}
 
 	widget.View = widget.createView(widget.bordered)
 	widget.View.SetInputCapture(widget.KeyboardWidget.InputCapture)
 
+	// Draw the last content again rather than asking for it, which might fetch it
+	widget.search.redraw = widget.RedrawRendered
+	widget.KeyboardWidget.keyHandler = widget.search.handleKey
+	widget.InitializeSearchKeyboardControl()
+
 	widget.Base.SetView(widget.View)
 	widget.Base.helpTextFunc = widget.KeyboardWidget.HelpText
 
@@ -39,20 +48,65 @@ func (widget *TextWidget) TextView() *tview.TextView {
 	return widget.View
 }
 
-// Redraw forces a refresh of the onscreen text content of this widget
-func (widget *TextWidget) Redraw(data func() (string, string, bool)) {
+// Draw updates the onscreen text content of this widget without asking the app to redraw
+// the screen. Use it to display content, i.e.: cached data, before the app has started.
+// Once it is running use Redraw instead
+func (widget *TextWidget) Draw(data func() (string, string, bool)) {
 	title, content, wrap := data()
 
+	widget.setRendered(title, content, wrap)
+
+	widget.search.drawn = true
+	content = widget.search.apply(content)
+
 	widget.View.Clear()
 	widget.View.SetWrap(wrap)
-	widget.View.SetTitle(widget.ContextualTitle(title))
+	widget.View.SetTitle(widget.ContextualTitle(widget.search.title(title)))
+	widget.refreshBorderColor()
 	widget.View.SetText(strings.TrimRight(content, "\n"))
 
+	widget.scrollToSearchMatch()
+}
+
+// RedrawRendered draws the widget's last content again without asking the module for
+// it, which might fetch it, i.e.: to show it with a search or in a new theme. Widgets that
+// haven't been drawn yet are left alone
+func (widget *TextWidget) RedrawRendered() {
+	if !widget.search.drawn {
+		return
+	}
+
+	rendered := widget.Rendered()
+	widget.Redraw(func() (string, string, bool) { return rendered.Title, rendered.Content, rendered.Wrap })
+}
+
+// Redraw forces a refresh of the onscreen text content of this widget
+func (widget *TextWidget) Redraw(data func() (string, string, bool)) {
+	widget.Draw(data)
+
 	widget.RedrawChan <- true
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
+// scrollToSearchMatch scrolls to the line of the current search match, after a search
+// moves to it. Widgets with rows select the match's row instead, which they scroll to.
+// Wrapped lines aren't accounted for, so in wrapped text the match may be a little below
+// the top of the view
+func (widget *TextWidget) scrollToSearchMatch() {
+	search := widget.search
+	if !search.scrollPending || search.selectRow != nil {
+		return
+	}
+	search.scrollPending = false
+
+	if search.current >= len(search.matches) {
+		return
+	}
+
+	widget.View.ScrollTo(search.matches[search.current].line, 0)
+}
+
 func (widget *TextWidget) createView(bordered bool) *tview.TextView {
 	view := tview.NewTextView()
 
@@ -38,3 +38,18 @@ func PrettyDate(dateStr string) string {
 func UnixTime(unix int64) time.Time {
 	return time.Unix(unix, 0)
 }
+
+// ShortDuration returns the duration rounded down to its largest whole unit, in a compact
+// form suitable for displaying in a widget title, i.e.: 45s, 5m, 2h, 3d
+func ShortDuration(duration time.Duration) string {
+	switch {
+	case duration < time.Minute:
+		return fmt.Sprintf("%ds", int(duration.Seconds()))
+	case duration < time.Hour:
+		return fmt.Sprintf("%dm", int(duration.Minutes()))
+	case duration < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(duration.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(duration.Hours()/24))
+	}
+}
@@ -72,6 +72,46 @@ func Test_PrettyDate(t *testing.T) {
 		})
 	}
 }
+
+func Test_ShortDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		expected string
+	}{
+		{
+			name:     "with seconds",
+			duration: 45 * time.Second,
+			expected: "45s",
+		},
+		{
+			name:     "with minutes",
+			duration: 5*time.Minute + 30*time.Second,
+			expected: "5m",
+		},
+		{
+			name:     "with hours",
+			duration: 2*time.Hour + 59*time.Minute,
+			expected: "2h",
+		},
+		{
+			name:     "with days",
+			duration: 75 * time.Hour,
+			expected: "3d",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := ShortDuration(tt.duration)
+
+			if tt.expected != actual {
+				t.Errorf("\nexpected: %s\n     got: %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
 func Test_UnixTime(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1,6 +1,8 @@
 package wtf
 
 import (
+	"time"
+
 	"github.com/wtfutil/wtf/cfg"
 
 	"github.com/rivo/tview"
@@ -17,9 +19,12 @@ type Wtfable interface {
 	FocusChar() string
 	Focusable() bool
 	HelpText() string
+	LastError() error
+	LastSuccess() time.Time
 	Name() string
 	QuitChan() chan bool
 	SetFocusChar(string)
+	Stale() bool
 	TextView() *tview.TextView
 
 	CommonSettings() *cfg.Common